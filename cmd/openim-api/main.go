@@ -27,6 +27,7 @@ import (
 	_ "net/http/pprof"
 
 	"github.com/OpenIMSDK/Open-IM-Server/internal/api"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/cmd"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
@@ -63,6 +64,10 @@ func run(port int) error {
 	if err != nil {
 		return err
 	}
+	// fail at startup rather than on the first request when the token keys are misconfigured
+	if err := authverify.LoadSigningKeys(); err != nil {
+		return err
+	}
 	fmt.Println("api start init discov client")
 	var client discoveryregistry.SvcDiscoveryRegistry
 	client, err = openKeeper.NewClient(config.Config.Zookeeper.ZkAddr, config.Config.Zookeeper.Schema,
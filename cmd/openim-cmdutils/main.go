@@ -25,15 +25,23 @@ func main() {
 	clearCmd := cmd.NewClearCmd()
 	seqCmd := cmd.NewSeqCmd()
 	msgCmd := cmd.NewMsgCmd()
-	getCmd.AddCommand(seqCmd.GetSeqCmd(), msgCmd.GetMsgCmd())
+	replayCmd := cmd.NewReplayCmd()
+	deadLetterCmd := cmd.NewDeadLetterCmd()
+	verifyCmd := cmd.NewVerifyCmd()
+	indexCmd := cmd.NewIndexCmd()
+	getCmd.AddCommand(seqCmd.GetSeqCmd(), msgCmd.GetMsgCmd(), deadLetterCmd.GetDeadLetterCmd())
 	getCmd.AddSuperGroupIDFlag()
 	getCmd.AddUserIDFlag()
 	getCmd.AddBeginSeqFlag()
 	getCmd.AddLimitFlag()
+	getCmd.AddPartitionFlag()
+	getCmd.AddOffsetFlag()
+	getCmd.AddConfFlag()
 	// openIM get seq --userID=xxx
 	// openIM get seq --superGroupID=xxx
 	// openIM get msg --userID=xxx --beginSeq=100 --limit=10
 	// openIM get msg --superGroupID=xxx --beginSeq=100 --limit=10
+	// openIM get deadLetter --partition=0 --offset=100 --limit=10
 
 	fixCmd.AddCommand(seqCmd.FixSeqCmd())
 	fixCmd.AddSuperGroupIDFlag()
@@ -52,7 +60,26 @@ func main() {
 	// openIM clear msg --userID=xxx --beginSeq=100 --limit=10
 	// openIM clear msg --superGroupID=xxx --beginSeq=100 --limit=10
 	// openIM clear msg --clearAll
-	msgUtilsCmd.AddCommand(&getCmd.Command, &fixCmd.Command, &clearCmd.Command)
+	replayCmd.AddCommand(deadLetterCmd.ReplayDeadLetterCmd())
+	replayCmd.AddPartitionFlag()
+	replayCmd.AddOffsetFlag()
+	replayCmd.AddLimitFlag()
+	replayCmd.AddConfFlag()
+	// openIM replay deadLetter --partition=0 --offset=100 --limit=10
+
+	verifyCmd.AddCommand(seqCmd.VerifySeqCmd())
+	verifyCmd.AddConversationIDFlag()
+	verifyCmd.AddConfFlag()
+	// openIM verify seq --conversationID=xxx
+	// openIM verify seq
+
+	indexCmd.AddCommand(msgCmd.IndexMsgCmd())
+	indexCmd.AddConversationIDFlag()
+	indexCmd.AddConfFlag()
+	// openIM index msg --conversationID=xxx
+	// openIM index msg
+
+	msgUtilsCmd.AddCommand(&getCmd.Command, &fixCmd.Command, &clearCmd.Command, &replayCmd.Command, &verifyCmd.Command, &indexCmd.Command)
 	if err := msgUtilsCmd.Execute(); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,113 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/authext"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/tools/a2r"
+	"github.com/OpenIMSDK/tools/apiresp"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+)
+
+const (
+	headerClientID        = "clientID"
+	headerClientSecret    = "clientSecret"
+	headerClientAssertion = "clientAssertion"
+)
+
+// appCredentialScopes 凭证可访问的路由, 以/结尾的按前缀匹配, 不在其中的路由凭证不能访问.
+var appCredentialScopes = []struct {
+	path  string
+	scope string
+}{
+	{"/auth/user_token", relation.AppCredentialScopeTokenIssue},
+	{"/auth/force_logout", relation.AppCredentialScopeTokenIssue},
+	{"/user/user_register", relation.AppCredentialScopeUserImport},
+	{"/friend/import_friend", relation.AppCredentialScopeUserImport},
+	{"/msg/send_msg", relation.AppCredentialScopeSendAs},
+	{"/msg/batch_send_msg", relation.AppCredentialScopeSendAs},
+	{"/group/", relation.AppCredentialScopeGroupAdmin},
+	{"/super_group/", relation.AppCredentialScopeGroupAdmin},
+}
+
+func appCredentialScope(path string) string {
+	for _, s := range appCredentialScopes {
+		if path == s.path || (strings.HasSuffix(s.path, "/") && strings.HasPrefix(path, s.path)) {
+			return s.scope
+		}
+	}
+	return ""
+}
+
+// GinAppCredential 请求头带有clientID时使用凭证认证, 以凭证的opUserID作为操作者, 否则交给next处理, next为空时继续.
+func GinAppCredential(authRpc *rpcclient.Auth, next gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientID := c.Request.Header.Get(headerClientID)
+		if clientID == "" {
+			if next != nil {
+				next(c)
+			}
+			return
+		}
+		scope := appCredentialScope(c.FullPath())
+		if scope == "" {
+			log.ZWarn(c, "app credential not allowed", nil, "clientID", clientID, "path", c.FullPath())
+			apiresp.GinError(c, errs.ErrNoPermission.Wrap("app credential can not access "+c.FullPath()))
+			c.Abort()
+			return
+		}
+		resp, err := authRpc.ExtClient.VerifyAppCredential(c, &authext.VerifyAppCredentialReq{
+			ClientID:        clientID,
+			ClientSecret:    c.Request.Header.Get(headerClientSecret),
+			ClientAssertion: c.Request.Header.Get(headerClientAssertion),
+			Scope:           scope,
+			Path:            c.FullPath(),
+			Ip:              c.ClientIP(),
+		})
+		if err != nil {
+			log.ZWarn(c, "verify app credential error", err, "clientID", clientID, "path", c.FullPath())
+			apiresp.GinError(c, err)
+			c.Abort()
+			return
+		}
+		c.Set(constant.OpUserPlatform, constant.PlatformIDToName(constant.AdminPlatformID))
+		c.Set(constant.OpUserID, resp.OpUserID)
+		c.Next()
+	}
+}
+
+func (o *AuthApi) CreateAppCredential(c *gin.Context) {
+	a2r.Call(authext.AuthExtClient.CreateAppCredential, o.ExtClient, c)
+}
+
+func (o *AuthApi) RevokeAppCredential(c *gin.Context) {
+	a2r.Call(authext.AuthExtClient.RevokeAppCredential, o.ExtClient, c)
+}
+
+func (o *AuthApi) GetAppCredentials(c *gin.Context) {
+	a2r.Call(authext.AuthExtClient.GetAppCredentials, o.ExtClient, c)
+}
+
+func (o *AuthApi) GetAppCredentialLogs(c *gin.Context) {
+	a2r.Call(authext.AuthExtClient.GetAppCredentialLogs, o.ExtClient, c)
+}
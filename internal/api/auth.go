@@ -15,11 +15,18 @@
 package api
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/authext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 	"github.com/OpenIMSDK/protocol/auth"
+	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/tools/a2r"
+	"github.com/OpenIMSDK/tools/apiresp"
+	"github.com/OpenIMSDK/tools/errs"
 )
 
 type AuthApi rpcclient.Auth
@@ -32,6 +39,15 @@ func (o *AuthApi) UserToken(c *gin.Context) {
 	a2r.Call(auth.AuthClient.UserToken, o.Client, c)
 }
 
+// GetUserTokens replaces UserToken on /auth/user_token, the resp is the same with the refresh token added.
+func (o *AuthApi) GetUserTokens(c *gin.Context) {
+	a2r.Call(authext.AuthExtClient.GetUserTokens, o.ExtClient, c)
+}
+
+func (o *AuthApi) RefreshToken(c *gin.Context) {
+	a2r.Call(authext.AuthExtClient.RefreshToken, o.ExtClient, c)
+}
+
 func (o *AuthApi) ParseToken(c *gin.Context) {
 	a2r.Call(auth.AuthClient.ParseToken, o.Client, c)
 }
@@ -39,3 +55,50 @@ func (o *AuthApi) ParseToken(c *gin.Context) {
 func (o *AuthApi) ForceLogout(c *gin.Context) {
 	a2r.Call(auth.AuthClient.ForceLogout, o.Client, c)
 }
+
+// JWKS returns the token verification public keys in the standard JWK Set format, not wrapped in the api resp.
+func (o *AuthApi) JWKS(c *gin.Context) {
+	keys, err := authverify.GetJWKSet()
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, keys)
+}
+
+// GetSessions marks the session of the token in the header as current.
+func (o *AuthApi) GetSessions(c *gin.Context) {
+	var req authext.GetSessionsReq
+	if err := c.BindJSON(&req); err != nil {
+		apiresp.GinError(c, errs.ErrArgs.WithDetail(err.Error()).Wrap())
+		return
+	}
+	req.Token = c.GetHeader(constant.Token)
+	resp, err := o.ExtClient.GetSessions(c, &req)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	apiresp.GinSuccess(c, resp)
+}
+
+func (o *AuthApi) RevokeSession(c *gin.Context) {
+	a2r.Call(authext.AuthExtClient.RevokeSession, o.ExtClient, c)
+}
+
+// RevokeOtherSessions keeps the session of the token in the header.
+func (o *AuthApi) RevokeOtherSessions(c *gin.Context) {
+	var req authext.RevokeOtherSessionsReq
+	if err := c.BindJSON(&req); err != nil {
+		apiresp.GinError(c, errs.ErrArgs.WithDetail(err.Error()).Wrap())
+		return
+	}
+	req.Token = c.GetHeader(constant.Token)
+	resp, err := o.ExtClient.RevokeOtherSessions(c, &req)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	apiresp.GinSuccess(c, resp)
+}
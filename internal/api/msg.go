@@ -22,6 +22,8 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/apistruct"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/protocol/msg"
@@ -80,6 +82,9 @@ func (m MessageApi) newUserSendMsgReq(c *gin.Context, params *apistruct.SendMsg)
 	if params.NotOfflinePush {
 		utils.SetSwitchFromOptions(options, constant.IsOfflinePush, false)
 	}
+	if params.SendTime > 0 {
+		options[msgprocessor.IsScheduled] = true
+	}
 	if params.ContentType == constant.CustomOnlineOnly {
 		m.SetOptions(options, false)
 	} else if params.ContentType == constant.CustomNotTriggerConversation {
@@ -100,6 +105,7 @@ func (m MessageApi) newUserSendMsgReq(c *gin.Context, params *apistruct.SendMsg)
 			CreateTime:       utils.GetCurrentTimestampByMill(),
 			Options:          options,
 			OfflinePushInfo:  params.OfflinePushInfo,
+			SendTime:         params.SendTime,
 		},
 	}
 	if params.ContentType == constant.OANotification {
@@ -125,6 +131,58 @@ func (m *MessageApi) RevokeMsg(c *gin.Context) {
 	a2r.Call(msg.MsgClient.RevokeMsg, m.Client, c)
 }
 
+func (m *MessageApi) EditMsg(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.EditMsg, m.ExtClient, c)
+}
+
+func (m *MessageApi) AddReaction(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.AddReaction, m.ExtClient, c)
+}
+
+func (m *MessageApi) RemoveReaction(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.RemoveReaction, m.ExtClient, c)
+}
+
+func (m *MessageApi) GetReactions(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.GetReactions, m.ExtClient, c)
+}
+
+func (m *MessageApi) GetRevokeAudits(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.GetRevokeAudits, m.ExtClient, c)
+}
+
+func (m *MessageApi) CancelScheduledMsg(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.CancelScheduledMsg, m.ExtClient, c)
+}
+
+func (m *MessageApi) GetScheduledMsgs(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.GetScheduledMsgs, m.ExtClient, c)
+}
+
+func (m *MessageApi) SendThreadMsg(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.SendThreadMsg, m.ExtClient, c)
+}
+
+func (m *MessageApi) GetThreads(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.GetThreads, m.ExtClient, c)
+}
+
+func (m *MessageApi) PinMsg(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.PinMsg, m.ExtClient, c)
+}
+
+func (m *MessageApi) UnpinMsg(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.UnpinMsg, m.ExtClient, c)
+}
+
+func (m *MessageApi) GetPinnedMsgs(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.GetPinnedMsgs, m.ExtClient, c)
+}
+
+func (m *MessageApi) SearchMsgs(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.SearchMsgs, m.ExtClient, c)
+}
+
 func (m *MessageApi) MarkMsgsAsRead(c *gin.Context) {
 	a2r.Call(msg.MsgClient.MarkMsgsAsRead, m.Client, c)
 }
@@ -280,6 +338,10 @@ func (m *MessageApi) BatchSendMsg(c *gin.Context) {
 	}
 	for _, recvID := range recvIDs {
 		sendMsgReq.MsgData.RecvID = recvID
+		if req.SendTime > 0 {
+			// scheduled msgs are kept by clientMsgID
+			sendMsgReq.MsgData.ClientMsgID = utils.GetMsgID(req.SendID)
+		}
 		rpcResp, err := m.Client.SendMsg(c, sendMsgReq)
 		if err != nil {
 			resp.FailedIDs = append(resp.FailedIDs, recvID)
@@ -22,7 +22,6 @@ import (
 	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/tools/apiresp"
 	"github.com/OpenIMSDK/tools/errs"
-	"github.com/OpenIMSDK/tools/tokenverify"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -67,10 +66,11 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 		r.Use(prome.PrometheusMiddleware)
 		r.GET("/metrics", prome.PrometheusHandler())
 	}
-	ParseToken := GinParseToken(rdb)
+	ParseToken := GinAppCredential(authRpc, GinParseToken(rdb))
+	AppCredential := GinAppCredential(authRpc, nil)
 	userRouterGroup := r.Group("/user")
 	{
-		userRouterGroup.POST("/user_register", u.UserRegister)
+		userRouterGroup.POST("/user_register", AppCredential, u.UserRegister)
 		userRouterGroup.POST("/update_user_info", ParseToken, u.UpdateUserInfo)
 		userRouterGroup.POST("/set_global_msg_recv_opt", ParseToken, u.SetGlobalRecvMessageOpt)
 		userRouterGroup.POST("/get_users_info", ParseToken, u.GetUsersPublicInfo)
@@ -140,9 +140,22 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 	authRouterGroup := r.Group("/auth")
 	{
 		a := NewAuthApi(*authRpc)
-		authRouterGroup.POST("/user_token", a.UserToken)
+		authRouterGroup.POST("/user_token", AppCredential, a.GetUserTokens)
+		authRouterGroup.POST("/refresh_token", a.RefreshToken)
+		authRouterGroup.GET("/jwks", a.JWKS)
 		authRouterGroup.POST("/parse_token", a.ParseToken)
 		authRouterGroup.POST("/force_logout", ParseToken, a.ForceLogout)
+		authRouterGroup.POST("/get_sessions", ParseToken, a.GetSessions)
+		authRouterGroup.POST("/revoke_session", ParseToken, a.RevokeSession)
+		authRouterGroup.POST("/revoke_other_sessions", ParseToken, a.RevokeOtherSessions)
+	}
+	appCredentialGroup := r.Group("/app_credential", ParseToken)
+	{
+		a := NewAuthApi(*authRpc)
+		appCredentialGroup.POST("/create", a.CreateAppCredential)
+		appCredentialGroup.POST("/revoke", a.RevokeAppCredential)
+		appCredentialGroup.POST("/get_credentials", a.GetAppCredentials)
+		appCredentialGroup.POST("/get_logs", a.GetAppCredentialLogs)
 	}
 	// Third service
 	thirdGroup := r.Group("/third", ParseToken)
@@ -169,6 +182,19 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 		msgGroup.POST("/send_msg", m.SendMessage)
 		msgGroup.POST("/pull_msg_by_seq", m.PullMsgBySeqs)
 		msgGroup.POST("/revoke_msg", m.RevokeMsg)
+		msgGroup.POST("/edit_msg", m.EditMsg)
+		msgGroup.POST("/add_reaction", m.AddReaction)
+		msgGroup.POST("/remove_reaction", m.RemoveReaction)
+		msgGroup.POST("/get_reactions", m.GetReactions)
+		msgGroup.POST("/get_revoke_audits", m.GetRevokeAudits)
+		msgGroup.POST("/cancel_scheduled_msg", m.CancelScheduledMsg)
+		msgGroup.POST("/get_scheduled_msgs", m.GetScheduledMsgs)
+		msgGroup.POST("/send_thread_msg", m.SendThreadMsg)
+		msgGroup.POST("/get_threads", m.GetThreads)
+		msgGroup.POST("/pin_msg", m.PinMsg)
+		msgGroup.POST("/unpin_msg", m.UnpinMsg)
+		msgGroup.POST("/get_pinned_msgs", m.GetPinnedMsgs)
+		msgGroup.POST("/search_msgs", m.SearchMsgs)
 		msgGroup.POST("/mark_msgs_as_read", m.MarkMsgsAsRead)
 		msgGroup.POST("/mark_conversation_as_read", m.MarkConversationAsRead)
 		msgGroup.POST("/get_conversations_has_read_and_max_seq", m.GetConversationsHasReadAndMaxSeq)
@@ -206,8 +232,8 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 func GinParseToken(rdb redis.UniversalClient) gin.HandlerFunc {
 	dataBase := controller.NewAuthDatabase(
 		cache.NewMsgCacheModel(rdb),
-		config.Config.Secret,
-		config.Config.TokenPolicy.Expire,
+		authverify.AccessTokenExpire(),
+		authverify.RefreshTokenExpire(),
 	)
 	return func(c *gin.Context) {
 		switch c.Request.Method {
@@ -219,7 +245,7 @@ func GinParseToken(rdb redis.UniversalClient) gin.HandlerFunc {
 				c.Abort()
 				return
 			}
-			claims, err := tokenverify.GetClaimFromToken(token, authverify.Secret())
+			claims, err := authverify.VerifyAccessToken(token)
 			if err != nil {
 				log.ZWarn(c, "jwt get token error", errs.ErrTokenUnknown.Wrap())
 				apiresp.GinError(c, errs.ErrTokenUnknown.Wrap())
@@ -240,16 +266,9 @@ func GinParseToken(rdb redis.UniversalClient) gin.HandlerFunc {
 				return
 			}
 			if v, ok := m[token]; ok {
-				switch v {
-				case constant.NormalToken:
-				case constant.KickedToken:
-					log.ZWarn(c, "cache kicked token error", errs.ErrTokenKicked.Wrap())
-					apiresp.GinError(c, errs.ErrTokenKicked.Wrap())
-					c.Abort()
-					return
-				default:
-					log.ZWarn(c, "cache unknown token error", errs.ErrTokenUnknown.Wrap())
-					apiresp.GinError(c, errs.ErrTokenUnknown.Wrap())
+				if err := authverify.CheckTokenStatus(v); err != nil {
+					log.ZWarn(c, "cache token status error", err, "status", v)
+					apiresp.GinError(c, err)
 					c.Abort()
 					return
 				}
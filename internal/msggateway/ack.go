@@ -0,0 +1,144 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msggateway
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/prome"
+)
+
+// unackedPush is an online push waiting for the client to ack it with a WsPushAckMsg carrying its MsgIncr.
+type unackedPush struct {
+	resp     Resp
+	msgData  *sdkws.MsgData
+	pushTime time.Time
+	retries  int
+	timer    *time.Timer
+}
+
+func (c *Client) pushAckEnabled() bool {
+	return c.pushAckInterval > 0
+}
+
+// trackPush must be called before resp is written, so that a fast ack always finds it.
+func (c *Client) trackPush(resp Resp, msgData *sdkws.MsgData) {
+	c.ackLock.Lock()
+	defer c.ackLock.Unlock()
+	if c.unacked == nil {
+		c.unacked = make(map[string]*unackedPush)
+	}
+	p := &unackedPush{resp: resp, msgData: msgData, pushTime: time.Now()}
+	p.timer = time.AfterFunc(c.pushAckInterval, func() { c.retryPush(resp.MsgIncr, p) })
+	if old, ok := c.unacked[resp.MsgIncr]; ok {
+		old.timer.Stop()
+	}
+	c.unacked[resp.MsgIncr] = p
+}
+
+func (c *Client) ackPush(ctx context.Context, msgIncr string) {
+	c.ackLock.Lock()
+	p, ok := c.unacked[msgIncr]
+	if ok {
+		p.timer.Stop()
+		delete(c.unacked, msgIncr)
+	}
+	c.ackLock.Unlock()
+	if !ok {
+		log.ZDebug(ctx, "ack of unknown push", "msgIncr", msgIncr)
+		return
+	}
+	prome.ObserveVec(prome.MsgGatewayPushAckLatency, time.Since(p.pushTime).Seconds(), constant.PlatformIDToName(c.PlatformID))
+}
+
+// retryPush resends p with a doubling interval until the ack window expires, then hands it to the offline pusher.
+func (c *Client) retryPush(msgIncr string, p *unackedPush) {
+	c.ackLock.Lock()
+	if c.unacked[msgIncr] != p {
+		// acked, replaced or the client is gone
+		c.ackLock.Unlock()
+		return
+	}
+	remain := c.pushAckWindow - time.Since(p.pushTime)
+	if remain <= 0 {
+		delete(c.unacked, msgIncr)
+		c.ackLock.Unlock()
+		c.longConnServer.offlinePushUnacked(c.UserID, c.PlatformID, []*sdkws.MsgData{p.msgData})
+		return
+	}
+	p.retries++
+	interval := c.pushAckInterval << p.retries
+	if interval > remain {
+		interval = remain
+	}
+	p.timer = time.AfterFunc(interval, func() { c.retryPush(msgIncr, p) })
+	c.ackLock.Unlock()
+	if err := c.writeMsg(p.resp); err != nil {
+		log.ZWarn(c.ctx, "resend push err", err, "userID", c.UserID, "msgIncr", msgIncr, "retries", p.retries)
+	}
+}
+
+// dropUnacked is called when the connection closes, pushes still waiting for an ack go to the offline pusher.
+func (c *Client) dropUnacked() {
+	c.ackLock.Lock()
+	unacked := c.unacked
+	c.unacked = nil
+	c.ackLock.Unlock()
+	if len(unacked) == 0 {
+		return
+	}
+	msgs := make([]*sdkws.MsgData, 0, len(unacked))
+	for _, p := range unacked {
+		p.timer.Stop()
+		msgs = append(msgs, p.msgData)
+	}
+	// the client is back in the pool once unregistered
+	go c.longConnServer.offlinePushUnacked(c.UserID, c.PlatformID, msgs)
+}
+
+// resetUnacked is called when a pooled client is reused, the lock is kept because timers of the previous
+// connection may still fire, they find their push gone and return.
+func (c *Client) resetUnacked() {
+	if c.ackLock == nil {
+		c.ackLock = new(sync.Mutex)
+		return
+	}
+	c.ackLock.Lock()
+	defer c.ackLock.Unlock()
+	for _, p := range c.unacked {
+		p.timer.Stop()
+	}
+	c.unacked = nil
+}
+
+func (ws *WsServer) offlinePushUnacked(userID string, platformID int, msgs []*sdkws.MsgData) {
+	prome.AddVec(prome.MsgGatewayPushAckTimeoutCounter, len(msgs), constant.PlatformIDToName(platformID))
+	// like the online push result, only mobile connections fall back to the offline pusher
+	if constant.PlatformIDToClass(platformID) != constant.TerminalMobile {
+		return
+	}
+	ctx := mcontext.NewCtx(utils.GetSelfFuncName() + "-" + utils.OperationIDGenerator())
+	if err := ws.OfflinePushUnacked(ctx, userID, platformID, msgs); err != nil {
+		log.ZWarn(ctx, "OfflinePushUnacked err", err, "userID", userID, "platformID", platformID, "msgNum", len(msgs))
+	}
+}
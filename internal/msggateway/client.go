@@ -21,9 +21,13 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
 	"runtime/debug"
 	"sync"
+	"time"
 
+	"golang.org/x/time/rate"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/gatewayext"
+
 	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/protocol/sdkws"
 	"github.com/OpenIMSDK/tools/apiresp"
@@ -37,6 +41,7 @@ var (
 	ErrNotSupportMessageProtocol = errors.New("not support message protocol")
 	ErrClientClosed              = errors.New("client actively close the connection")
 	ErrPanic                     = errors.New("panic error")
+	ErrRateLimitKicked           = errors.New("kicked for exceeding the rate limit")
 )
 
 const (
@@ -72,30 +77,55 @@ type Client struct {
 	closed         bool
 	closedErr      error
 	token          string
+	encoder        Encoder
+	compressor     Compressor
+	// textProtocol is set once the peer sends a text frame, from then on the connection speaks
+	// uncompressed JSON envelopes in text frames.
+	textProtocol bool
+	// limiters and limitedNum are only accessed by the readMessage goroutine.
+	limiters   map[int32]*rate.Limiter
+	limitedNum int
+	// resumeSeqs are the last seen seqs given at handshake, live pushes are held in pendingPush
+	// until the messages missed since them have been sent.
+	resumeSeqs  map[string]int64
+	resuming    bool
+	pendingPush []Resp
+	// pushAckInterval is set when the client acks pushes, the unacked pushes are resent and pushed offline.
+	pushAckInterval time.Duration
+	pushAckWindow   time.Duration
+	ackLock         *sync.Mutex
+	unacked         map[string]*unackedPush
 }
 
-func newClient(ctx *UserConnContext, conn LongConn, isCompress bool) *Client {
+func newClient(ctx *UserConnContext, conn LongConn, compressor Compressor, encoder Encoder) *Client {
 	return &Client{
 		w:          new(sync.Mutex),
+		ackLock:    new(sync.Mutex),
 		conn:       conn,
 		PlatformID: utils.StringToInt(ctx.GetPlatformID()),
-		IsCompress: isCompress,
+		IsCompress: compressor != nil,
 		UserID:     ctx.GetUserID(),
 		ctx:        ctx,
+		encoder:    encoder,
+		compressor: compressor,
 	}
 }
 
 func (c *Client) ResetClient(
 	ctx *UserConnContext,
 	conn LongConn,
-	isBackground, isCompress bool,
+	isBackground bool,
+	compressor Compressor,
+	encoder Encoder,
 	longConnServer LongConnServer,
 	token string,
 ) {
 	c.w = new(sync.Mutex)
 	c.conn = conn
 	c.PlatformID = utils.StringToInt(ctx.GetPlatformID())
-	c.IsCompress = isCompress
+	c.IsCompress = compressor != nil
+	c.compressor = compressor
+	c.encoder = encoder
 	c.IsBackground = isBackground
 	c.UserID = ctx.GetUserID()
 	c.ctx = ctx
@@ -104,6 +134,15 @@ func (c *Client) ResetClient(
 	c.closed = false
 	c.closedErr = nil
 	c.token = token
+	c.textProtocol = false
+	c.limiters = nil
+	c.limitedNum = 0
+	c.resumeSeqs = nil
+	c.resuming = false
+	c.pendingPush = nil
+	c.pushAckInterval = 0
+	c.pushAckWindow = 0
+	c.resetUnacked()
 }
 
 func (c *Client) pongHandler(_ string) error {
@@ -142,8 +181,13 @@ func (c *Client) readMessage() {
 				return
 			}
 		case MessageText:
-			c.closedErr = ErrNotSupportMessageProtocol
-			return
+			_ = c.conn.SetReadDeadline(pongWait)
+			c.setTextProtocol()
+			parseDataErr := c.handleMessage(message)
+			if parseDataErr != nil {
+				c.closedErr = parseDataErr
+				return
+			}
 		case PingMessage:
 			err := c.writePongMsg()
 			log.ZError(c.ctx, "writePongMsg", err)
@@ -155,16 +199,26 @@ func (c *Client) readMessage() {
 	}
 }
 
+func (c *Client) setTextProtocol() {
+	c.w.Lock()
+	defer c.w.Unlock()
+	if !c.textProtocol {
+		c.textProtocol = true
+		c.encoder = NewJsonEncoder()
+	}
+}
+
 func (c *Client) handleMessage(message []byte) error {
-	if c.IsCompress {
+	defer c.longConnServer.trackMessage()()
+	if c.IsCompress && !c.textProtocol {
 		var decompressErr error
-		message, decompressErr = c.longConnServer.DeCompress(message)
+		message, decompressErr = c.compressor.DeCompress(message)
 		if decompressErr != nil {
 			return utils.Wrap(decompressErr, "")
 		}
 	}
 	var binaryReq Req
-	err := c.longConnServer.Decode(message, &binaryReq)
+	err := c.encoder.Decode(message, &binaryReq)
 	if err != nil {
 		return utils.Wrap(err, "")
 	}
@@ -178,6 +232,17 @@ func (c *Client) handleMessage(message []byte) error {
 		[]string{binaryReq.OperationID, binaryReq.SendID, constant.PlatformIDToName(c.PlatformID), c.ctx.GetConnID()},
 	)
 	log.ZDebug(ctx, "gateway req message", "req", binaryReq.String())
+	if limited, kick := c.longConnServer.limitMessage(c, binaryReq.ReqIdentifier); limited {
+		c.replyMessage(ctx, &binaryReq, ErrConnRateLimit.Wrap(), nil)
+		if kick {
+			// readMessage退出时关闭连接并经注销通道从用户表删除, 这里只通知客户端
+			if err := c.KickOnlineMessage(); err != nil {
+				log.ZWarn(ctx, "KickOnlineMessage err", err)
+			}
+			return ErrRateLimitKicked
+		}
+		return nil
+	}
 	var messageErr error
 	var resp []byte
 	switch binaryReq.ReqIdentifier {
@@ -193,6 +258,10 @@ func (c *Client) handleMessage(message []byte) error {
 		resp, messageErr = c.longConnServer.UserLogout(ctx, binaryReq)
 	case WsSetBackgroundStatus:
 		resp, messageErr = c.setAppBackgroundStatus(ctx, binaryReq)
+	case WsPushAckMsg:
+		// acks are not replied
+		c.ackPush(ctx, binaryReq.MsgIncr)
+		return nil
 	default:
 		return fmt.Errorf(
 			"ReqIdentifier failed,sendID:%s,msgIncr:%s,reqIdentifier:%d",
@@ -220,6 +289,7 @@ func (c *Client) close() {
 	defer c.w.Unlock()
 	c.closed = true
 	c.conn.Close()
+	c.dropUnacked()
 	c.longConnServer.UnRegister(c)
 }
 
@@ -234,9 +304,9 @@ func (c *Client) replyMessage(ctx context.Context, binaryReq *Req, err error, re
 		Data:          resp,
 	}
 	log.ZDebug(ctx, "gateway reply message", "resp", mReply.String())
-	err = c.writeBinaryMsg(mReply)
+	err = c.writeMsg(mReply)
 	if err != nil {
-		log.ZWarn(ctx, "wireBinaryMsg replyMessage", err, "resp", mReply.String())
+		log.ZWarn(ctx, "writeMsg replyMessage", err, "resp", mReply.String())
 	}
 }
 
@@ -259,32 +329,59 @@ func (c *Client) PushMessage(ctx context.Context, msgData *sdkws.MsgData) error
 		OperationID:   mcontext.GetOperationID(ctx),
 		Data:          data,
 	}
-	return c.writeBinaryMsg(resp)
+	if c.pushAckEnabled() {
+		resp.MsgIncr = msgData.ServerMsgID
+		c.trackPush(resp, msgData)
+	}
+	c.w.Lock()
+	defer c.w.Unlock()
+	if c.resuming {
+		c.pendingPush = append(c.pendingPush, resp)
+		return nil
+	}
+	return c.write(resp)
+}
+
+func (c *Client) ReconnectMessage(backoff time.Duration) error {
+	data, err := proto.Marshal(&gatewayext.ReconnectTips{Backoff: backoff.Milliseconds()})
+	if err != nil {
+		return err
+	}
+	resp := Resp{
+		ReqIdentifier: WsReconnectMsg,
+		Data:          data,
+	}
+	return c.writeMsg(resp)
 }
 
 func (c *Client) KickOnlineMessage() error {
 	resp := Resp{
 		ReqIdentifier: WSKickOnlineMsg,
 	}
-	return c.writeBinaryMsg(resp)
+	return c.writeMsg(resp)
 }
 
-func (c *Client) writeBinaryMsg(resp Resp) error {
+func (c *Client) writeMsg(resp Resp) error {
 	c.w.Lock()
 	defer c.w.Unlock()
+	return c.write(resp)
+}
+
+// write must be called with c.w held.
+func (c *Client) write(resp Resp) error {
 	if c.closed == true {
 		return nil
 	}
-	encodedBuf := bufferPool.Get().([]byte)
-	resultBuf := bufferPool.Get().([]byte)
-	encodedBuf, err := c.longConnServer.Encode(resp)
+	encodedBuf, err := c.encoder.Encode(resp)
 	if err != nil {
 		return utils.Wrap(err, "")
 	}
 	_ = c.conn.SetWriteDeadline(writeWait)
+	if c.textProtocol {
+		return c.conn.WriteMessage(MessageText, encodedBuf)
+	}
 	if c.IsCompress {
-		var compressErr error
-		resultBuf, compressErr = c.longConnServer.Compress(encodedBuf)
+		resultBuf, compressErr := c.compressor.Compress(encodedBuf)
 		if compressErr != nil {
 			return utils.Wrap(compressErr, "")
 		}
@@ -16,8 +16,14 @@ package msggateway
 
 import (
 	"bytes"
+	"compress/flate"
 	"compress/gzip"
+	"errors"
+	"fmt"
 	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
 
 	"github.com/OpenIMSDK/tools/utils"
 )
@@ -26,32 +32,88 @@ type Compressor interface {
 	Compress(rawData []byte) ([]byte, error)
 	DeCompress(compressedData []byte) ([]byte, error)
 }
+
+// newCompressors returns the compressors a client may negotiate at handshake,
+// frames shorter than minSize are sent uncompressed, see ThresholdCompressor.
+func newCompressors(minSize int) (map[string]Compressor, error) {
+	zstdCompressor, err := NewZstdCompressor()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]Compressor{
+		GzipCompressionProtocol:    NewThresholdCompressor(NewGzipCompressor(), minSize),
+		ZstdCompressionProtocol:    NewThresholdCompressor(zstdCompressor, minSize),
+		DeflateCompressionProtocol: NewThresholdCompressor(NewDeflateCompressor(), minSize),
+	}, nil
+}
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// copyBuffer returns a copy of buf's content and puts buf back to the pool.
+func copyBuffer(buf *bytes.Buffer) []byte {
+	data := make([]byte, buf.Len())
+	copy(data, buf.Bytes())
+	bufferPool.Put(buf)
+	return data
+}
+
 type GzipCompressor struct {
 	compressProtocol string
+	writerPool       sync.Pool
+	readerPool       sync.Pool
 }
 
 func NewGzipCompressor() *GzipCompressor {
-	return &GzipCompressor{compressProtocol: "gzip"}
+	return &GzipCompressor{
+		compressProtocol: GzipCompressionProtocol,
+		writerPool: sync.Pool{
+			New: func() interface{} {
+				return gzip.NewWriter(nil)
+			},
+		},
+	}
 }
 
 func (g *GzipCompressor) Compress(rawData []byte) ([]byte, error) {
-	gzipBuffer := bytes.Buffer{}
-	gz := gzip.NewWriter(&gzipBuffer)
+	gzipBuffer := getBuffer()
+	gz := g.writerPool.Get().(*gzip.Writer)
+	defer g.writerPool.Put(gz)
+	gz.Reset(gzipBuffer)
 	if _, err := gz.Write(rawData); err != nil {
 		return nil, utils.Wrap(err, "")
 	}
 	if err := gz.Close(); err != nil {
 		return nil, utils.Wrap(err, "")
 	}
-	return gzipBuffer.Bytes(), nil
+	return copyBuffer(gzipBuffer), nil
 }
 
 func (g *GzipCompressor) DeCompress(compressedData []byte) ([]byte, error) {
-	buff := bytes.NewBuffer(compressedData)
-	reader, err := gzip.NewReader(buff)
+	buff := bytes.NewReader(compressedData)
+	var (
+		reader *gzip.Reader
+		err    error
+	)
+	if r, ok := g.readerPool.Get().(*gzip.Reader); ok {
+		reader = r
+		err = reader.Reset(buff)
+	} else {
+		reader, err = gzip.NewReader(buff)
+	}
 	if err != nil {
 		return nil, utils.Wrap(err, "NewReader failed")
 	}
+	defer g.readerPool.Put(reader)
 	compressedData, err = io.ReadAll(reader)
 	if err != nil {
 		return nil, utils.Wrap(err, "ReadAll failed")
@@ -59,3 +121,134 @@ func (g *GzipCompressor) DeCompress(compressedData []byte) ([]byte, error) {
 	_ = reader.Close()
 	return compressedData, nil
 }
+
+// DeflateCompressor uses raw deflate (RFC 1951) without any header, so every frame is compressed.
+type DeflateCompressor struct {
+	writerPool sync.Pool
+	readerPool sync.Pool
+}
+
+func NewDeflateCompressor() *DeflateCompressor {
+	return &DeflateCompressor{
+		writerPool: sync.Pool{
+			New: func() interface{} {
+				w, _ := flate.NewWriter(nil, flate.DefaultCompression)
+				return w
+			},
+		},
+	}
+}
+
+func (d *DeflateCompressor) Compress(rawData []byte) ([]byte, error) {
+	buffer := getBuffer()
+	w := d.writerPool.Get().(*flate.Writer)
+	defer d.writerPool.Put(w)
+	w.Reset(buffer)
+	if _, err := w.Write(rawData); err != nil {
+		return nil, utils.Wrap(err, "")
+	}
+	if err := w.Close(); err != nil {
+		return nil, utils.Wrap(err, "")
+	}
+	return copyBuffer(buffer), nil
+}
+
+func (d *DeflateCompressor) DeCompress(compressedData []byte) ([]byte, error) {
+	buff := bytes.NewReader(compressedData)
+	reader, ok := d.readerPool.Get().(io.ReadCloser)
+	if ok {
+		if err := reader.(flate.Resetter).Reset(buff, nil); err != nil {
+			return nil, utils.Wrap(err, "Reset failed")
+		}
+	} else {
+		reader = flate.NewReader(buff)
+	}
+	defer d.readerPool.Put(reader)
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, utils.Wrap(err, "ReadAll failed")
+	}
+	_ = reader.Close()
+	return data, nil
+}
+
+// ZstdCompressor shares one encoder and decoder, EncodeAll and DecodeAll are safe for concurrent use
+// and keep their own internal pools.
+type ZstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func NewZstdCompressor() (*ZstdCompressor, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	if err != nil {
+		return nil, utils.Wrap(err, "")
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, utils.Wrap(err, "")
+	}
+	return &ZstdCompressor{encoder: encoder, decoder: decoder}, nil
+}
+
+func (z *ZstdCompressor) Compress(rawData []byte) ([]byte, error) {
+	return z.encoder.EncodeAll(rawData, nil), nil
+}
+
+func (z *ZstdCompressor) DeCompress(compressedData []byte) ([]byte, error) {
+	data, err := z.decoder.DecodeAll(compressedData, nil)
+	if err != nil {
+		return nil, utils.Wrap(err, "DecodeAll failed")
+	}
+	return data, nil
+}
+
+// flags of the first byte of a frame sent by ThresholdCompressor.
+const (
+	frameRaw        byte = 0
+	frameCompressed byte = 1
+)
+
+var (
+	errEmptyFrame       = errors.New("empty compressed frame")
+	errUnknownFrameFlag = errors.New("unknown compressed frame flag")
+)
+
+// ThresholdCompressor leaves frames shorter than minSize uncompressed, every frame starts with a flag byte,
+// frameRaw or frameCompressed, followed by the payload.
+type ThresholdCompressor struct {
+	Compressor
+	minSize int
+}
+
+func NewThresholdCompressor(compressor Compressor, minSize int) Compressor {
+	if minSize <= 0 {
+		return compressor
+	}
+	return &ThresholdCompressor{Compressor: compressor, minSize: minSize}
+}
+
+func (t *ThresholdCompressor) Compress(rawData []byte) ([]byte, error) {
+	if len(rawData) < t.minSize {
+		return append([]byte{frameRaw}, rawData...), nil
+	}
+	data, err := t.Compressor.Compress(rawData)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{frameCompressed}, data...), nil
+}
+
+func (t *ThresholdCompressor) DeCompress(compressedData []byte) ([]byte, error) {
+	if len(compressedData) == 0 {
+		return nil, utils.Wrap1(errEmptyFrame)
+	}
+	switch compressedData[0] {
+	case frameRaw:
+		return compressedData[1:], nil
+	case frameCompressed:
+		return t.Compressor.DeCompress(compressedData[1:])
+	default:
+		return nil, utils.Wrap(errUnknownFrameFlag, fmt.Sprintf("flag %d", compressedData[0]))
+	}
+}
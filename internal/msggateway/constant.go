@@ -17,15 +17,29 @@ package msggateway
 import "time"
 
 const (
-	WsUserID                = "sendID"
-	CommonUserID            = "userID"
-	PlatformID              = "platformID"
-	ConnID                  = "connID"
-	Token                   = "token"
-	OperationID             = "operationID"
-	Compression             = "compression"
-	GzipCompressionProtocol = "gzip"
-	BackgroundStatus        = "isBackground"
+	WsUserID         = "sendID"
+	CommonUserID     = "userID"
+	PlatformID       = "platformID"
+	ConnID           = "connID"
+	Token            = "token"
+	OperationID      = "operationID"
+	Compression      = "compression"
+	BackgroundStatus = "isBackground"
+	Encoding         = "encoding"
+	Resume           = "resume"
+	PushAck          = "pushAck"
+)
+
+const (
+	GzipCompressionProtocol    = "gzip"
+	DeflateCompressionProtocol = "deflate"
+	ZstdCompressionProtocol    = "zstd"
+)
+
+const (
+	GobEncodingProtocol      = "gob"
+	JsonEncodingProtocol     = "json"
+	ProtobufEncodingProtocol = "protobuf"
 )
 
 const (
@@ -38,10 +52,13 @@ const (
 	WSPullMsgBySeqList    = 1002
 	WSSendMsg             = 1003
 	WSSendSignalMsg       = 1004
+	WsPushAckMsg          = 1005
 	WSPushMsg             = 2001
 	WSKickOnlineMsg       = 2002
 	WsLogoutMsg           = 2003
 	WsSetBackgroundStatus = 2004
+	WsReconnectMsg        = 2005
+	WsResumeDoneMsg       = 2006
 	WSDataError           = 3001
 )
 
@@ -15,8 +15,11 @@
 package msggateway
 
 import (
+	"fmt"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/OpenIMSDK/protocol/constant"
@@ -76,6 +79,70 @@ func (c *UserConnContext) GetRemoteAddr() string {
 	return c.RemoteAddr
 }
 
+// GetClientIP 仅当直连地址是可信代理时才读取X-Forwarded-For, 从右往左跳过可信代理取第一个不可信地址.
+func (c *UserConnContext) GetClientIP(trustedProxies []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(c.RemoteAddr)
+	if err != nil {
+		host = c.RemoteAddr
+	}
+	if !isTrustedProxy(host, trustedProxies) {
+		return host
+	}
+	hops := strings.Split(c.Req.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		host = hop
+		if !isTrustedProxy(hop, trustedProxies) {
+			break
+		}
+	}
+	return host
+}
+
+func isTrustedProxy(host string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTrustedProxies 解析可信代理配置, 单个IP按/32或/128处理.
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if strings.Contains(proxy, "/") {
+			_, ipNet, err := net.ParseCIDR(proxy)
+			if err != nil {
+				return nil, err
+			}
+			nets = append(nets, ipNet)
+			continue
+		}
+		ip := net.ParseIP(proxy)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return nets, nil
+}
+
 func (c *UserConnContext) Query(key string) (string, bool) {
 	var value string
 	if value = c.Req.URL.Query().Get(key); value == "" {
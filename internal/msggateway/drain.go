@@ -0,0 +1,132 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msggateway
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+const (
+	// drainCheckInterval is how often a draining gateway checks whether its clients have left.
+	drainCheckInterval = time.Second
+	// drainWorkerNum is how many clients are asked to reconnect at the same time.
+	drainWorkerNum = 64
+)
+
+func (ws *WsServer) isDraining() bool {
+	return atomic.LoadInt32(&ws.draining) == 1
+}
+
+func (ws *WsServer) trackMessage() (done func()) {
+	atomic.AddInt64(&ws.handlingNum, 1)
+	return func() {
+		atomic.AddInt64(&ws.handlingNum, -1)
+	}
+}
+
+// Drain stops accepting new connections and asks every connected client to reconnect to another gateway
+// after a random backoff within window, so that they do not all arrive at the same time.
+// It returns the number of connections asked to reconnect, the http server is shut down in the background
+// once the clients have left and in-flight messages are handled, or the drain timeout expires.
+func (ws *WsServer) Drain(ctx context.Context, window time.Duration) int {
+	if !atomic.CompareAndSwapInt32(&ws.draining, 0, 1) {
+		return 0
+	}
+	if window <= 0 {
+		window = ws.drainWindow
+	}
+	if ws.routeEnabled() {
+		// the pusher falls back to broadcast for users still routed here
+		if err := ws.cache.DelGatewayAlive(ctx, ws.routeAddr); err != nil {
+			log.ZWarn(ctx, "DelGatewayAlive err", err, "gateway", ws.routeAddr)
+		}
+	}
+	clients := ws.clients.Clients()
+	go ws.askReconnect(clients, window)
+	log.ZInfo(ctx, "gateway draining", "connNum", len(clients), "window", window, "timeout", ws.drainTimeout)
+	timeout := ws.drainTimeout
+	if timeout < window {
+		timeout = window
+	}
+	go ws.shutdown(timeout)
+	return len(clients)
+}
+
+// askReconnect sends the reconnect tips concurrently, a slow client only holds up its own worker for writeWait.
+func (ws *WsServer) askReconnect(clients []*Client, window time.Duration) {
+	clientCh := make(chan *Client)
+	var wg sync.WaitGroup
+	for i := 0; i < drainWorkerNum && i < len(clients); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range clientCh {
+				var backoff time.Duration
+				if window > 0 {
+					backoff = time.Duration(rand.Int63n(int64(window)))
+				}
+				if err := c.ReconnectMessage(backoff); err != nil {
+					log.ZWarn(c.ctx, "ReconnectMessage err", err, "userID", c.UserID, "platformID", c.PlatformID)
+				}
+			}
+		}()
+	}
+	for _, c := range clients {
+		clientCh <- c
+	}
+	close(clientCh)
+	wg.Wait()
+}
+
+func (ws *WsServer) shutdown(timeout time.Duration) {
+	ctx := mcontext.NewCtx(utils.GetSelfFuncName() + "-" + utils.OperationIDGenerator())
+	deadline := time.Now().Add(timeout)
+	for atomic.LoadInt64(&ws.onlineUserConnNum) > 0 && time.Now().Before(deadline) {
+		time.Sleep(drainCheckInterval)
+	}
+	if clients := ws.clients.Clients(); len(clients) > 0 {
+		log.ZWarn(ctx, "close connections left after drain timeout", nil, "connNum", len(clients))
+		for _, c := range clients {
+			// readMessage returns on the closed conn and unregisters the client
+			_ = c.conn.Close()
+		}
+	}
+	// give the messages read before the close a last writeWait to be handled
+	deadline = time.Now().Add(writeWait)
+	for atomic.LoadInt64(&ws.handlingNum) > 0 && time.Now().Before(deadline) {
+		time.Sleep(drainCheckInterval / 10)
+	}
+	if n := atomic.LoadInt64(&ws.handlingNum); n > 0 {
+		log.ZWarn(ctx, "messages still in handling after drain", nil, "handlingNum", n)
+	}
+	ws.httpServerLock.Lock()
+	httpServer := ws.httpServer
+	ws.httpServerLock.Unlock()
+	if httpServer == nil {
+		return
+	}
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.ZError(ctx, "http server shutdown err", err)
+	}
+	log.ZInfo(ctx, "gateway drained")
+}
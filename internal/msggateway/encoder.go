@@ -17,7 +17,13 @@ package msggateway
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
 
+	"google.golang.org/protobuf/proto"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/gatewayext"
 	"github.com/OpenIMSDK/tools/utils"
 )
 
@@ -26,6 +32,21 @@ type Encoder interface {
 	Decode(encodeData []byte, decodeData interface{}) error
 }
 
+var encoders = map[string]Encoder{
+	GobEncodingProtocol:      NewGobEncoder(),
+	JsonEncodingProtocol:     NewJsonEncoder(),
+	ProtobufEncodingProtocol: NewProtobufEncoder(),
+}
+
+// getEncoder returns the encoder negotiated at handshake, gob is used when the client does not specify one.
+func getEncoder(protocol string) (Encoder, bool) {
+	if protocol == "" {
+		protocol = GobEncodingProtocol
+	}
+	encoder, ok := encoders[protocol]
+	return encoder, ok
+}
+
 type GobEncoder struct{}
 
 func NewGobEncoder() *GobEncoder {
@@ -51,3 +72,75 @@ func (g *GobEncoder) Decode(encodeData []byte, decodeData interface{}) error {
 	}
 	return nil
 }
+
+type JsonEncoder struct{}
+
+func NewJsonEncoder() *JsonEncoder {
+	return &JsonEncoder{}
+}
+
+func (j *JsonEncoder) Encode(data interface{}) ([]byte, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, utils.Wrap(err, "")
+	}
+	return b, nil
+}
+
+func (j *JsonEncoder) Decode(encodeData []byte, decodeData interface{}) error {
+	if err := json.Unmarshal(encodeData, decodeData); err != nil {
+		return utils.Wrap(err, "")
+	}
+	return nil
+}
+
+var errProtoNotMessage = errors.New("protobuf encoder only supports proto messages")
+
+// ProtobufEncoder encodes Req and Resp as gatewayext.WsReq and gatewayext.WsResp.
+type ProtobufEncoder struct{}
+
+func NewProtobufEncoder() *ProtobufEncoder {
+	return &ProtobufEncoder{}
+}
+
+func (p *ProtobufEncoder) Encode(data interface{}) ([]byte, error) {
+	if resp, ok := data.(Resp); ok {
+		data = &gatewayext.WsResp{
+			ReqIdentifier: resp.ReqIdentifier,
+			MsgIncr:       resp.MsgIncr,
+			OperationID:   resp.OperationID,
+			ErrCode:       int32(resp.ErrCode),
+			ErrMsg:        resp.ErrMsg,
+			Data:          resp.Data,
+		}
+	}
+	m, ok := data.(proto.Message)
+	if !ok {
+		return nil, utils.Wrap(errProtoNotMessage, fmt.Sprintf("%T", data))
+	}
+	b, err := proto.Marshal(m)
+	return b, utils.Wrap1(err)
+}
+
+func (p *ProtobufEncoder) Decode(encodeData []byte, decodeData interface{}) error {
+	if req, ok := decodeData.(*Req); ok {
+		var m gatewayext.WsReq
+		if err := proto.Unmarshal(encodeData, &m); err != nil {
+			return utils.Wrap(err, "")
+		}
+		*req = Req{
+			ReqIdentifier: m.ReqIdentifier,
+			Token:         m.Token,
+			SendID:        m.SendID,
+			OperationID:   m.OperationID,
+			MsgIncr:       m.MsgIncr,
+			Data:          m.Data,
+		}
+		return nil
+	}
+	m, ok := decodeData.(proto.Message)
+	if !ok {
+		return utils.Wrap(errProtoNotMessage, fmt.Sprintf("%T", decodeData))
+	}
+	return utils.Wrap1(proto.Unmarshal(encodeData, m))
+}
@@ -17,6 +17,7 @@ package msggateway
 import (
 	"context"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"time"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
 	"github.com/OpenIMSDK/tools/errs"
@@ -25,10 +26,12 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/prome"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/startrpc"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/gatewayext"
 	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/protocol/msggateway"
 	"github.com/OpenIMSDK/tools/discoveryregistry"
 	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
 	"github.com/OpenIMSDK/tools/utils"
 )
 
@@ -38,9 +41,15 @@ func (s *Server) InitServer(client discoveryregistry.SvcDiscoveryRegistry, serve
 		return err
 	}
 	msgModel := cache.NewMsgCacheModel(rdb)
+	s.discov = client
+	prome.NewMsgGatewayRateLimitCounter()
+	prome.NewMsgGatewayRateLimitKickCounter()
+	prome.NewMsgGatewayPushAckLatency()
+	prome.NewMsgGatewayPushAckTimeoutCounter()
 	s.LongConnServer.SetDiscoveryRegistry(client)
 	s.LongConnServer.SetCacheHandler(msgModel)
 	msggateway.RegisterMsgGatewayServer(server, s)
+	gatewayext.RegisterMsgGatewayExtServer(server, s)
 	return nil
 }
 
@@ -58,6 +67,7 @@ type Server struct {
 	prometheusPort int
 	LongConnServer LongConnServer
 	pushTerminal   []int
+	discov         discoveryregistry.SvcDiscoveryRegistry
 }
 
 func (s *Server) SetLongConnServer(LongConnServer LongConnServer) {
@@ -83,7 +93,8 @@ func (s *Server) GetUsersOnlineStatus(
 	ctx context.Context,
 	req *msggateway.GetUsersOnlineStatusReq,
 ) (*msggateway.GetUsersOnlineStatusResp, error) {
-	if !authverify.IsAppManagerUid(ctx) {
+	// 用户可以查询自己的连接
+	if !authverify.IsAppManagerUid(ctx) && !(len(req.UserIDs) == 1 && req.UserIDs[0] == mcontext.GetOpUserID(ctx)) {
 		return nil, errs.ErrNoPermission.Wrap("only app manager")
 	}
 	var resp msggateway.GetUsersOnlineStatusResp
@@ -195,6 +206,26 @@ func (s *Server) MultiTerminalLoginCheck(
 	ctx context.Context,
 	req *msggateway.MultiTerminalLoginCheckReq,
 ) (*msggateway.MultiTerminalLoginCheckResp, error) {
-	// TODO implement me
-	panic("implement me")
+	// 签发token时已按多端登录策略踢下线其他会话, 这里关闭被踢会话在本网关上的连接
+	closed := s.LongConnServer.closeKickedConns(ctx, req.UserID)
+	log.ZDebug(ctx, "multi terminal login check", "userID", req.UserID, "platformID", req.PlatformID, "closedNum", closed)
+	return &msggateway.MultiTerminalLoginCheckResp{}, nil
+}
+
+func (s *Server) Drain(ctx context.Context, req *gatewayext.DrainReq) (*gatewayext.DrainResp, error) {
+	if !authverify.IsAppManagerUid(ctx) {
+		return nil, errs.ErrNoPermission.Wrap("only app manager")
+	}
+	return &gatewayext.DrainResp{ConnNum: int64(s.drain(ctx, time.Duration(req.Window)*time.Second))}, nil
+}
+
+// drain deregisters the gateway so that pushers and reconnecting clients pick another one,
+// then migrates the connected clients away.
+func (s *Server) drain(ctx context.Context, window time.Duration) int {
+	if s.discov != nil {
+		if err := s.discov.UnRegister(); err != nil {
+			log.ZWarn(ctx, "UnRegister err", err)
+		}
+	}
+	return s.LongConnServer.Drain(ctx, window)
 }
@@ -16,9 +16,19 @@ package msggateway
 
 import (
 	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/loginpolicy"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/network"
+	"github.com/OpenIMSDK/tools/utils"
 )
 
 func RunWsAndServer(rpcPort, wsPort, prometheusPort int) error {
@@ -30,11 +40,61 @@ func RunWsAndServer(rpcPort, wsPort, prometheusPort int) error {
 		", OpenIM version: ",
 		config.Version,
 	)
-	longServer, err := NewWsServer(
+	// fail at startup rather than on the first connection when the token keys are misconfigured
+	if err := authverify.LoadSigningKeys(); err != nil {
+		return err
+	}
+	if _, err := loginpolicy.Default(); err != nil {
+		return err
+	}
+	trustedProxies, err := ParseTrustedProxies(config.Config.LongConnSvr.TrustedProxies)
+	if err != nil {
+		return err
+	}
+	opts := []Option{
 		WithPort(wsPort),
 		WithMaxConnNum(int64(config.Config.LongConnSvr.WebsocketMaxConnNum)),
-		WithHandshakeTimeout(time.Duration(config.Config.LongConnSvr.WebsocketTimeout)*time.Second),
-		WithMessageMaxMsgLength(config.Config.LongConnSvr.WebsocketMaxMsgLen))
+		WithHandshakeTimeout(time.Duration(config.Config.LongConnSvr.WebsocketTimeout) * time.Second),
+		WithMessageMaxMsgLength(config.Config.LongConnSvr.WebsocketMaxMsgLen),
+		WithCompressMinSize(config.Config.LongConnSvr.Compression.MinSize),
+		WithPermessageDeflate(config.Config.LongConnSvr.Compression.PermessageDeflate),
+		WithResumeMaxMsgNum(config.Config.LongConnSvr.Resume.MaxMsgNum),
+		WithDrain(
+			time.Duration(config.Config.LongConnSvr.Drain.Window)*time.Second,
+			time.Duration(config.Config.LongConnSvr.Drain.Timeout)*time.Second,
+		),
+		WithTrustedProxies(trustedProxies),
+	}
+	if config.Config.LongConnSvr.PushAck.Enable {
+		opts = append(opts, WithPushAck(
+			time.Duration(config.Config.LongConnSvr.PushAck.RetryInterval)*time.Millisecond,
+			time.Duration(config.Config.LongConnSvr.PushAck.Window)*time.Second,
+		))
+	}
+	if config.Config.LongConnSvr.RateLimit.Enable {
+		limits := make(map[int32]RateLimit)
+		for _, l := range config.Config.LongConnSvr.RateLimit.Limits {
+			limits[l.ReqIdentifier] = RateLimit{
+				ConnRate:  l.ConnRate,
+				ConnBurst: l.ConnBurst,
+				UserRate:  l.UserRate,
+				UserBurst: l.UserBurst,
+			}
+		}
+		opts = append(opts, WithRateLimit(limits, config.Config.LongConnSvr.RateLimit.KickThreshold))
+	}
+	if config.Config.LongConnSvr.GatewayRoute.Enable {
+		// must be the same address that startrpc registers, the pusher matches routes against it
+		registerIP, err := network.GetRpcRegisterIP(config.Config.Rpc.RegisterIP)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, WithRoute(
+			net.JoinHostPort(registerIP, strconv.Itoa(rpcPort)),
+			time.Duration(config.Config.LongConnSvr.GatewayRoute.Expire)*time.Second,
+		))
+	}
+	longServer, err := NewWsServer(opts...)
 	if err != nil {
 		return err
 	}
@@ -45,5 +105,12 @@ func RunWsAndServer(rpcPort, wsPort, prometheusPort int) error {
 			panic(err.Error())
 		}
 	}()
+	go func() {
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGTERM)
+		<-sigs
+		ctx := mcontext.NewCtx("SIGTERM-" + utils.OperationIDGenerator())
+		hubServer.drain(ctx, 0)
+	}()
 	return hubServer.LongConnServer.Run()
 }
@@ -46,13 +46,20 @@ type LongConn interface {
 	GenerateLongConn(w http.ResponseWriter, r *http.Request) error
 }
 type GWebSocket struct {
-	protocolType     int
-	conn             *websocket.Conn
-	handshakeTimeout time.Duration
+	protocolType      int
+	conn              *websocket.Conn
+	handshakeTimeout  time.Duration
+	permessageDeflate bool
+	compressMinSize   int
 }
 
-func newGWebSocket(protocolType int, handshakeTimeout time.Duration) *GWebSocket {
-	return &GWebSocket{protocolType: protocolType, handshakeTimeout: handshakeTimeout}
+func newGWebSocket(protocolType int, handshakeTimeout time.Duration, permessageDeflate bool, compressMinSize int) *GWebSocket {
+	return &GWebSocket{
+		protocolType:      protocolType,
+		handshakeTimeout:  handshakeTimeout,
+		permessageDeflate: permessageDeflate,
+		compressMinSize:   compressMinSize,
+	}
 }
 
 func (d *GWebSocket) Close() error {
@@ -61,8 +68,9 @@ func (d *GWebSocket) Close() error {
 
 func (d *GWebSocket) GenerateLongConn(w http.ResponseWriter, r *http.Request) error {
 	upgrader := &websocket.Upgrader{
-		HandshakeTimeout: d.handshakeTimeout,
-		CheckOrigin:      func(r *http.Request) bool { return true },
+		HandshakeTimeout:  d.handshakeTimeout,
+		CheckOrigin:       func(r *http.Request) bool { return true },
+		EnableCompression: d.permessageDeflate,
 	}
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -74,6 +82,10 @@ func (d *GWebSocket) GenerateLongConn(w http.ResponseWriter, r *http.Request) er
 
 func (d *GWebSocket) WriteMessage(messageType int, message []byte) error {
 	// d.setSendConn(d.conn)
+	if d.permessageDeflate {
+		// only takes effect when the peer negotiated permessage-deflate
+		d.conn.EnableWriteCompression(len(message) >= d.compressMinSize)
+	}
 	return d.conn.WriteMessage(messageType, message)
 }
 
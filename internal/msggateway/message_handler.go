@@ -23,6 +23,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/pushext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 	"github.com/OpenIMSDK/protocol/msg"
 	"github.com/OpenIMSDK/protocol/sdkws"
@@ -62,6 +63,9 @@ type MessageHandler interface {
 	PullMessageBySeqList(context context.Context, data Req) ([]byte, error)
 	UserLogout(context context.Context, data Req) ([]byte, error)
 	SetUserDeviceBackground(context context.Context, data Req) ([]byte, bool, error)
+	GetMaxSeqs(context context.Context, userID string) (map[string]int64, error)
+	PullMessageBySeqRanges(context context.Context, userID string, seqRanges []*sdkws.SeqRange) (*sdkws.PullMessageBySeqsResp, error)
+	OfflinePushUnacked(context context.Context, userID string, platformID int, msgs []*sdkws.MsgData) error
 }
 
 var _ MessageHandler = (*GrpcHandler)(nil)
@@ -100,6 +104,29 @@ func (g GrpcHandler) GetSeq(context context.Context, data Req) ([]byte, error) {
 	return c, nil
 }
 
+func (g GrpcHandler) GetMaxSeqs(context context.Context, userID string) (map[string]int64, error) {
+	resp, err := g.msgRpcClient.GetMaxSeq(context, &sdkws.GetMaxSeqReq{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.MaxSeqs, nil
+}
+
+func (g GrpcHandler) PullMessageBySeqRanges(
+	context context.Context,
+	userID string,
+	seqRanges []*sdkws.SeqRange,
+) (*sdkws.PullMessageBySeqsResp, error) {
+	req := sdkws.PullMessageBySeqsReq{UserID: userID, SeqRanges: seqRanges, Order: sdkws.PullOrder_PullOrderAsc}
+	return g.msgRpcClient.PullMessageBySeqList(context, &req)
+}
+
+func (g GrpcHandler) OfflinePushUnacked(context context.Context, userID string, platformID int, msgs []*sdkws.MsgData) error {
+	req := pushext.OfflinePushUnackedReq{UserID: userID, PlatformID: int32(platformID), Msgs: msgs}
+	_, err := g.pushClient.OfflinePushUnacked(context, &req)
+	return err
+}
+
 func (g GrpcHandler) SendMessage(context context.Context, data Req) ([]byte, error) {
 	msgData := sdkws.MsgData{}
 	if err := proto.Unmarshal(data.Data, &msgData); err != nil {
@@ -19,17 +19,18 @@ import (
 	"errors"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
+	"net"
 	"net/http"
 	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/loginpolicy"
 	"github.com/OpenIMSDK/protocol/constant"
-
-	"github.com/redis/go-redis/v9"
+	"github.com/OpenIMSDK/protocol/sdkws"
 
 	"github.com/OpenIMSDK/tools/discoveryregistry"
 
@@ -37,6 +38,7 @@ import (
 
 	"github.com/OpenIMSDK/tools/errs"
 	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
 	"github.com/OpenIMSDK/tools/utils"
 )
 
@@ -49,24 +51,22 @@ type LongConnServer interface {
 	SetCacheHandler(cache cache.MsgModel)
 	SetDiscoveryRegistry(client discoveryregistry.SvcDiscoveryRegistry)
 	KickUserConn(client *Client) error
+	closeKickedConns(ctx context.Context, userID string) int
 	UnRegister(c *Client)
-	Compressor
-	Encoder
+	Drain(ctx context.Context, window time.Duration) int
+	trackMessage() (done func())
+	limitMessage(client *Client, reqIdentifier int32) (limited bool, kick bool)
+	offlinePushUnacked(userID string, platformID int, msgs []*sdkws.MsgData)
 	MessageHandler
 }
 
-var bufferPool = sync.Pool{
-	New: func() interface{} {
-		return make([]byte, 1024)
-	},
-}
-
 type WsServer struct {
 	port              int
 	wsMaxConnNum      int64
 	registerChan      chan *Client
 	unregisterChan    chan *Client
 	kickHandlerChan   chan *kickHandler
+	kickConnChan      chan *connRef
 	clients           *UserMap
 	clientPool        sync.Pool
 	onlineUserNum     int64
@@ -75,15 +75,48 @@ type WsServer struct {
 	hubServer         *Server
 	validate          *validator.Validate
 	cache             cache.MsgModel
+	authDatabase      controller.AuthDatabase
 	userClient        *rpcclient.UserRpcClient
-	Compressor
-	Encoder
+	routeAddr         string
+	routeExpire       time.Duration
+	compressors       map[string]Compressor
+	compressMinSize   int
+	permessageDeflate bool
+	drainWindow       time.Duration
+	drainTimeout      time.Duration
+	draining          int32
+	handlingNum       int64
+	httpServer        *http.Server
+	httpServerLock    sync.Mutex
+	rateLimits        map[int32]RateLimit
+	rateLimitKick     int
+	userLimiters      sync.Map
+	resumeMaxMsgNum   int64
+	pushAckInterval   time.Duration
+	pushAckWindow     time.Duration
+	trustedProxies    []*net.IPNet
 	MessageHandler
 }
+
+// kickHandler 多端登录检查在注册协程之外执行, 连接信息在注册时复制, 客户端断开后会被复用.
 type kickHandler struct {
+	ctx        *UserConnContext
+	platformID int
 	clientOK   bool
-	oldClients []*Client
-	newClient  *Client
+	oldClients []*connRef
+	newClient  *connRef
+}
+
+// connRef 按client和connID标识一个连接, client放回clientPool后会被其他连接复用.
+type connRef struct {
+	client *Client
+	userID string
+	connID string
+	token  string
+}
+
+func newConnRef(client *Client) *connRef {
+	return &connRef{client: client, userID: client.UserID, connID: client.ctx.GetConnID(), token: client.token}
 }
 
 func (ws *WsServer) SetDiscoveryRegistry(client discoveryregistry.SvcDiscoveryRegistry) {
@@ -114,6 +147,7 @@ func (ws *WsServer) SetUserOnlineStatus(ctx context.Context, client *Client, sta
 
 func (ws *WsServer) SetCacheHandler(cache cache.MsgModel) {
 	ws.cache = cache
+	ws.authDatabase = controller.NewAuthDatabase(cache, authverify.AccessTokenExpire(), authverify.RefreshTokenExpire())
 }
 
 func (ws *WsServer) UnRegister(c *Client) {
@@ -141,6 +175,10 @@ func NewWsServer(opts ...Option) (*WsServer, error) {
 		return nil, errors.New("port not allow to listen")
 	}
 	v := validator.New()
+	compressors, err := newCompressors(config.compressMinSize)
+	if err != nil {
+		return nil, err
+	}
 	return &WsServer{
 		port:             config.port,
 		wsMaxConnNum:     config.maxConnNum,
@@ -150,13 +188,25 @@ func NewWsServer(opts ...Option) (*WsServer, error) {
 				return new(Client)
 			},
 		},
-		registerChan:    make(chan *Client, 1000),
-		unregisterChan:  make(chan *Client, 1000),
-		kickHandlerChan: make(chan *kickHandler, 1000),
-		validate:        v,
-		clients:         newUserMap(),
-		Compressor:      NewGzipCompressor(),
-		Encoder:         NewGobEncoder(),
+		registerChan:      make(chan *Client, 1000),
+		unregisterChan:    make(chan *Client, 1000),
+		kickHandlerChan:   make(chan *kickHandler, 1000),
+		kickConnChan:      make(chan *connRef, 1000),
+		validate:          v,
+		clients:           newUserMap(),
+		routeAddr:         config.routeAddr,
+		routeExpire:       config.routeExpire,
+		compressors:       compressors,
+		compressMinSize:   config.compressMinSize,
+		permessageDeflate: config.permessageDeflate,
+		drainWindow:       config.drainWindow,
+		drainTimeout:      config.drainTimeout,
+		rateLimits:        config.rateLimits,
+		rateLimitKick:     config.rateLimitKickThreshold,
+		resumeMaxMsgNum:   config.resumeMaxMsgNum,
+		pushAckInterval:   config.pushAckInterval,
+		pushAckWindow:     config.pushAckWindow,
+		trustedProxies:    config.trustedProxies,
 	}, nil
 }
 
@@ -169,14 +219,31 @@ func (ws *WsServer) Run() error {
 				ws.registerClient(client)
 			case client = <-ws.unregisterChan:
 				ws.unregisterClient(client)
-			case onlineInfo := <-ws.kickHandlerChan:
-				ws.multiTerminalLoginChecker(onlineInfo.clientOK, onlineInfo.oldClients, onlineInfo.newClient)
+			case conn := <-ws.kickConnChan:
+				ws.kickConn(conn)
 			}
 		}
 	}()
-	http.HandleFunc("/", ws.wsHandler)
-	// http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {})
-	return http.ListenAndServe(":"+utils.IntToString(ws.port), nil) // Start listening
+	// 多端登录检查需要读写redis, 不阻塞连接的注册和注销
+	go func() {
+		for kick := range ws.kickHandlerChan {
+			ws.multiTerminalLoginChecker(kick)
+		}
+	}()
+	go ws.refreshRoutes()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", ws.wsHandler)
+	// mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {})
+	httpServer := &http.Server{Addr: ":" + utils.IntToString(ws.port), Handler: mux}
+	ws.httpServerLock.Lock()
+	ws.httpServer = httpServer
+	ws.httpServerLock.Unlock()
+	err := httpServer.ListenAndServe() // Start listening
+	if err == http.ErrServerClosed {
+		// closed by Drain, all connections have been migrated
+		return nil
+	}
+	return err
 }
 
 func (ws *WsServer) registerClient(client *Client) {
@@ -185,6 +252,7 @@ func (ws *WsServer) registerClient(client *Client) {
 		clientOK   bool
 		oldClients []*Client
 	)
+	ws.touchSession(client, true)
 	oldClients, userOK, clientOK = ws.clients.Get(client.UserID, client.PlatformID)
 	if !userOK {
 		ws.clients.Set(client.UserID, client)
@@ -192,12 +260,6 @@ func (ws *WsServer) registerClient(client *Client) {
 		atomic.AddInt64(&ws.onlineUserNum, 1)
 		atomic.AddInt64(&ws.onlineUserConnNum, 1)
 	} else {
-		i := &kickHandler{
-			clientOK:   clientOK,
-			oldClients: oldClients,
-			newClient:  client,
-		}
-		ws.kickHandlerChan <- i
 		log.ZDebug(client.ctx, "user exist", "userID", client.UserID, "platformID", client.PlatformID)
 		if clientOK {
 			ws.clients.Set(client.UserID, client)
@@ -210,6 +272,28 @@ func (ws *WsServer) registerClient(client *Client) {
 			atomic.AddInt64(&ws.onlineUserConnNum, 1)
 		}
 	}
+	// 其他网关上的会话也受多端登录策略限制, 用户在本网关首次连接时同样检查, 连接加入用户表后再检查, 被拒绝时才能移除
+	if policy, err := loginpolicy.Default(); err == nil && policy.Limited(client.PlatformID) {
+		kick := &kickHandler{
+			ctx:        client.ctx,
+			platformID: client.PlatformID,
+			clientOK:   clientOK,
+			newClient:  newConnRef(client),
+		}
+		for _, c := range oldClients {
+			kick.oldClients = append(kick.oldClients, newConnRef(c))
+		}
+		select {
+		case ws.kickHandlerChan <- kick:
+		default:
+			// 检查积压时不阻塞注册
+			go ws.multiTerminalLoginChecker(kick)
+		}
+	}
+	ws.addUserRoute(client)
+	if client.resuming {
+		go ws.resume(client)
+	}
 	ws.SetUserOnlineStatus(client.ctx, client, constant.Online)
 	log.ZInfo(
 		client.ctx,
@@ -233,90 +317,133 @@ func getRemoteAdders(client []*Client) string {
 	return ret
 }
 
+// KickUserConn 交给注册协程踢下线, 用户表只在注册协程中修改.
 func (ws *WsServer) KickUserConn(client *Client) error {
-	ws.clients.deleteClients(client.UserID, []*Client{client})
-	return client.KickOnlineMessage()
+	ws.kickConnChan <- newConnRef(client)
+	return nil
 }
 
-func (ws *WsServer) multiTerminalLoginChecker(clientOK bool, oldClients []*Client, newClient *Client) {
-	switch config.Config.MultiLoginPolicy {
-	case constant.DefalutNotKick:
-	case constant.PCAndOther:
-		if constant.PlatformIDToClass(newClient.PlatformID) == constant.TerminalPC {
+// kickConn 在注册协程中执行, 连接已断开或client已被其他连接复用时忽略.
+func (ws *WsServer) kickConn(conn *connRef) {
+	clients, ok := ws.clients.GetAll(conn.userID)
+	if !ok {
+		return
+	}
+	for _, c := range clients {
+		if c != conn.client {
+			continue
+		}
+		if c.ctx.GetConnID() != conn.connID {
 			return
 		}
-		fallthrough
-	case constant.AllLoginButSameTermKick:
-		if clientOK {
-			ws.clients.deleteClients(newClient.UserID, oldClients)
-			for _, c := range oldClients {
-				err := c.KickOnlineMessage()
-				if err != nil {
-					log.ZWarn(c.ctx, "KickOnlineMessage", err)
-				}
-			}
-			m, err := ws.cache.GetTokensWithoutError(
-				newClient.ctx,
-				newClient.UserID,
-				newClient.PlatformID,
-			)
-			if err != nil && err != redis.Nil {
-				log.ZWarn(
-					newClient.ctx,
-					"get token from redis err",
-					err,
-					"userID",
-					newClient.UserID,
-					"platformID",
-					newClient.PlatformID,
-				)
-				return
-			}
-			if m == nil {
-				log.ZWarn(
-					newClient.ctx,
-					"m is nil",
-					errors.New("m is nil"),
-					"userID",
-					newClient.UserID,
-					"platformID",
-					newClient.PlatformID,
-				)
-				return
-			}
-			log.ZDebug(
-				newClient.ctx,
-				"get token from redis",
-				"userID",
-				newClient.UserID,
-				"platformID",
-				newClient.PlatformID,
-				"tokenMap",
-				m,
-			)
-
-			for k := range m {
-				if k != newClient.ctx.GetToken() {
-					m[k] = constant.KickedToken
-				}
-			}
-			log.ZDebug(newClient.ctx, "set token map is ", "token map", m, "userID", newClient.UserID)
-			err = ws.cache.SetTokenMapByUidPid(newClient.ctx, newClient.UserID, newClient.PlatformID, m)
+		ws.clients.deleteClients(conn.userID, []*Client{c})
+		if err := c.KickOnlineMessage(); err != nil {
+			log.ZWarn(c.ctx, "KickOnlineMessage", err)
+		}
+		return
+	}
+}
+
+// multiTerminalLoginChecker 按多端登录策略检查新连接所属的会话, 与签发token时使用同一策略.
+func (ws *WsServer) multiTerminalLoginChecker(kick *kickHandler) {
+	newClient := kick.newClient
+	claims, err := authverify.GetTokenClaims(newClient.token)
+	if err != nil {
+		log.ZWarn(kick.ctx, "parse token failed", err, "userID", newClient.userID, "platformID", kick.platformID)
+		return
+	}
+	sessionID := authverify.SessionID(newClient.token, claims)
+	kicked, err := ws.authDatabase.ApplyLoginPolicy(kick.ctx, newClient.userID, kick.platformID, sessionID)
+	if err != nil {
+		if loginpolicy.ErrLoginRejected.Is(err) {
+			// token签发后策略有变化或有更新的登录, 会话的token已被标记为被踢, 重连时会被拒绝
+			log.ZInfo(kick.ctx, "login rejected by multi login policy", "userID", newClient.userID, "platformID", kick.platformID)
+			ws.kickConnChan <- newClient
+			return
+		}
+		log.ZWarn(kick.ctx, "ApplyLoginPolicy failed", err, "userID", newClient.userID, "platformID", kick.platformID)
+		return
+	}
+	if len(kicked) != 0 {
+		log.ZInfo(kick.ctx, "sessions kicked by multi login policy", "userID", newClient.userID, "kickedNum", len(kicked))
+		ws.closeKickedConns(kick.ctx, newClient.userID)
+	}
+	if !kick.clientOK {
+		return
+	}
+	// 同一会话在受限平台上重复连接, 只保留新连接
+	for _, c := range kick.oldClients {
+		if c.token == "" {
+			continue
+		}
+		if claims, err := authverify.GetTokenClaims(c.token); err != nil || authverify.SessionID(c.token, claims) != sessionID {
+			continue
+		}
+		ws.kickConnChan <- c
+	}
+}
+
+// closeKickedConns 关闭用户在本网关上token已不可用的连接, 被踢会话的token也可能已在再次登录时被清除.
+func (ws *WsServer) closeKickedConns(ctx context.Context, userID string) int {
+	clients, ok := ws.clients.GetAll(userID)
+	if !ok {
+		return 0
+	}
+	var (
+		closed   int
+		platform = make(map[int]map[string]int)
+	)
+	for _, c := range clients {
+		tokens, ok := platform[c.PlatformID]
+		if !ok {
+			var err error
+			tokens, err = ws.cache.GetTokensWithoutError(ctx, userID, c.PlatformID)
 			if err != nil {
-				log.ZWarn(newClient.ctx, "SetTokenMapByUidPid err", err, "userID", newClient.UserID, "platformID", newClient.PlatformID)
-				return
+				log.ZWarn(ctx, "get tokens failed", err, "userID", userID, "platformID", c.PlatformID)
+				continue
 			}
+			platform[c.PlatformID] = tokens
+		}
+		if status, ok := tokens[c.token]; ok && authverify.CheckTokenStatus(status) == nil {
+			continue
+		}
+		if err := ws.KickUserConn(c); err != nil {
+			log.ZWarn(c.ctx, "KickOnlineMessage", err)
 		}
+		closed++
 	}
+	return closed
+}
+
+// touchSession 记录连接所属登录会话的最近在线时间, 建立连接时同时记录ip.
+func (ws *WsServer) touchSession(client *Client, connected bool) {
+	claims, err := authverify.GetTokenClaims(client.token)
+	if err != nil {
+		return
+	}
+	userID, sessionID := client.UserID, authverify.SessionID(client.token, claims)
+	info := map[string]any{"platformID": client.PlatformID, "lastSeenTime": time.Now().UnixMilli()}
+	if connected {
+		info["ip"] = client.ctx.GetClientIP(ws.trustedProxies)
+	}
+	go func() {
+		ctx := mcontext.NewCtx("touchSession-" + utils.OperationIDGenerator())
+		if err := ws.cache.SetSessionInfo(ctx, userID, sessionID, info, authverify.SessionExpire()); err != nil {
+			log.ZWarn(ctx, "set session info failed", err, "userID", userID, "sessionID", sessionID)
+		}
+	}()
 }
 
 func (ws *WsServer) unregisterClient(client *Client) {
 	defer ws.clientPool.Put(client)
+	ws.touchSession(client, false)
 	isDeleteUser := ws.clients.delete(client.UserID, client.ctx.GetRemoteAddr())
 	if isDeleteUser {
 		atomic.AddInt64(&ws.onlineUserNum, -1)
+		ws.userLimiters.Delete(client.UserID)
 	}
 	atomic.AddInt64(&ws.onlineUserConnNum, -1)
+	ws.delUserRoute(client)
 	ws.SetUserOnlineStatus(client.ctx, client, constant.Offline)
 	log.ZInfo(client.ctx, "user offline", "close reason", client.closedErr, "online user Num", ws.onlineUserNum, "online user conn Num",
 		ws.onlineUserConnNum,
@@ -325,6 +452,11 @@ func (ws *WsServer) unregisterClient(client *Client) {
 
 func (ws *WsServer) wsHandler(w http.ResponseWriter, r *http.Request) {
 	connContext := newContext(w, r)
+	if ws.isDraining() {
+		// load balancers and clients retry another gateway on 503
+		connContext.ErrReturn("gateway is draining", http.StatusServiceUnavailable)
+		return
+	}
 	if ws.onlineUserConnNum >= ws.wsMaxConnNum {
 		httpError(connContext, errs.ErrConnOverMaxNumLimit)
 		return
@@ -334,7 +466,7 @@ func (ws *WsServer) wsHandler(w http.ResponseWriter, r *http.Request) {
 		userID        string
 		platformIDStr string
 		exists        bool
-		compression   bool
+		compressor    Compressor
 	)
 
 	token, exists = connContext.Query(Token)
@@ -367,20 +499,41 @@ func (ws *WsServer) wsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if v, ok := m[token]; ok {
-		switch v {
-		case constant.NormalToken:
-		case constant.KickedToken:
-			httpError(connContext, errs.ErrTokenKicked.Wrap())
-			return
-		default:
-			httpError(connContext, errs.ErrTokenUnknown.Wrap())
+		if err := authverify.CheckTokenStatus(v); err != nil {
+			httpError(connContext, err)
 			return
 		}
 	} else {
 		httpError(connContext, errs.ErrTokenNotExist.Wrap())
 		return
 	}
-	wsLongConn := newGWebSocket(WebSocket, ws.handshakeTimeout)
+	encodingProtoc, exists := connContext.Query(Encoding)
+	if !exists {
+		encodingProtoc, _ = connContext.GetHeader(Encoding)
+	}
+	encoder, ok := getEncoder(encodingProtoc)
+	if !ok {
+		httpError(connContext, errs.ErrConnArgsErr.Wrap("encoding not supported: "+encodingProtoc))
+		return
+	}
+	var resumeSeqs map[string]int64
+	resumeStr, exists := connContext.Query(Resume)
+	if !exists {
+		resumeStr, exists = connContext.GetHeader(Resume)
+	}
+	if exists {
+		resumeSeqs, err = parseResumeSeqs(resumeStr)
+		if err != nil {
+			httpError(connContext, err)
+			return
+		}
+	}
+	pushAckStr, exists := connContext.Query(PushAck)
+	if !exists {
+		pushAckStr, _ = connContext.GetHeader(PushAck)
+	}
+	pushAck, _ := strconv.ParseBool(pushAckStr)
+	wsLongConn := newGWebSocket(WebSocket, ws.handshakeTimeout, ws.permessageDeflate, ws.compressMinSize)
 	err = wsLongConn.GenerateLongConn(w, r)
 	if err != nil {
 		httpError(connContext, err)
@@ -388,18 +541,22 @@ func (ws *WsServer) wsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	compressProtoc, exists := connContext.Query(Compression)
 	if exists {
-		if compressProtoc == GzipCompressionProtocol {
-			compression = true
-		}
+		compressor = ws.compressors[compressProtoc]
 	}
 	compressProtoc, exists = connContext.GetHeader(Compression)
 	if exists {
-		if compressProtoc == GzipCompressionProtocol {
-			compression = true
-		}
+		compressor = ws.compressors[compressProtoc]
 	}
 	client := ws.clientPool.Get().(*Client)
-	client.ResetClient(connContext, wsLongConn, connContext.GetBackground(), compression, ws, token)
+	client.ResetClient(connContext, wsLongConn, connContext.GetBackground(), compressor, encoder, ws, token)
+	if len(resumeSeqs) > 0 {
+		client.resumeSeqs = resumeSeqs
+		client.resuming = true
+	}
+	if ws.pushAckInterval > 0 && pushAck {
+		client.pushAckInterval = ws.pushAckInterval
+		client.pushAckWindow = ws.pushAckWindow
+	}
 	ws.registerChan <- client
 	go client.readMessage()
 }
@@ -14,7 +14,10 @@
 
 package msggateway
 
-import "time"
+import (
+	"net"
+	"time"
+)
 
 type (
 	Option  func(opt *configs)
@@ -27,6 +30,30 @@ type (
 		handshakeTimeout time.Duration
 		// 允许消息最大长度
 		messageMaxMsgLength int
+		// 本网关注册到服务发现的地址, 用于在线推送路由
+		routeAddr string
+		// 路由表条目过期时间
+		routeExpire time.Duration
+		// 小于该长度的消息不压缩
+		compressMinSize int
+		// 是否协商 websocket permessage-deflate
+		permessageDeflate bool
+		// 下线时通知客户端重连的时间窗口
+		drainWindow time.Duration
+		// 下线时等待客户端断开的最长时间
+		drainTimeout time.Duration
+		// 按请求类型的限流配置
+		rateLimits map[int32]RateLimit
+		// 连续被限流多少次后踢下线, 0 不踢
+		rateLimitKickThreshold int
+		// 重连时每个会话最多补发的消息数
+		resumeMaxMsgNum int64
+		// 推送确认的重试间隔, 0 不开启
+		pushAckInterval time.Duration
+		// 超过该时间未确认则转离线推送
+		pushAckWindow time.Duration
+		// 允许携带X-Forwarded-For的代理地址
+		trustedProxies []*net.IPNet
 	}
 )
 
@@ -53,3 +80,55 @@ func WithMessageMaxMsgLength(length int) Option {
 		opt.messageMaxMsgLength = length
 	}
 }
+
+func WithCompressMinSize(size int) Option {
+	return func(opt *configs) {
+		opt.compressMinSize = size
+	}
+}
+
+func WithPermessageDeflate(enable bool) Option {
+	return func(opt *configs) {
+		opt.permessageDeflate = enable
+	}
+}
+
+func WithDrain(window, timeout time.Duration) Option {
+	return func(opt *configs) {
+		opt.drainWindow = window
+		opt.drainTimeout = timeout
+	}
+}
+
+func WithRateLimit(limits map[int32]RateLimit, kickThreshold int) Option {
+	return func(opt *configs) {
+		opt.rateLimits = limits
+		opt.rateLimitKickThreshold = kickThreshold
+	}
+}
+
+func WithResumeMaxMsgNum(num int64) Option {
+	return func(opt *configs) {
+		opt.resumeMaxMsgNum = num
+	}
+}
+
+func WithPushAck(interval, window time.Duration) Option {
+	return func(opt *configs) {
+		opt.pushAckInterval = interval
+		opt.pushAckWindow = window
+	}
+}
+
+func WithRoute(addr string, expire time.Duration) Option {
+	return func(opt *configs) {
+		opt.routeAddr = addr
+		opt.routeExpire = expire
+	}
+}
+
+func WithTrustedProxies(proxies []*net.IPNet) Option {
+	return func(opt *configs) {
+		opt.trustedProxies = proxies
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msggateway
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"golang.org/x/time/rate"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/prome"
+)
+
+// ConnRateLimit is returned in Resp.ErrCode when a request is rejected by the rate limiter,
+// it follows the long connection gateway error codes of the tools errs package.
+const ConnRateLimit = 1603
+
+var ErrConnRateLimit = errs.NewCodeError(ConnRateLimit, "ConnRateLimit")
+
+// RateLimit is the token bucket limit of one ReqIdentifier, a zero rate means unlimited.
+type RateLimit struct {
+	ConnRate  float64
+	ConnBurst int
+	UserRate  float64
+	UserBurst int
+}
+
+// userLimiters holds the limiters shared by all connections of a user on this gateway.
+type userLimiters struct {
+	lock     sync.Mutex
+	limiters map[int32]*rate.Limiter
+}
+
+func (u *userLimiters) get(reqIdentifier int32, limit RateLimit) *rate.Limiter {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	l, ok := u.limiters[reqIdentifier]
+	if !ok {
+		l = newLimiter(limit.UserRate, limit.UserBurst)
+		u.limiters[reqIdentifier] = l
+	}
+	return l
+}
+
+func newLimiter(r float64, burst int) *rate.Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(r), burst)
+}
+
+// limitMessage takes a token from the connection and user buckets of reqIdentifier,
+// kick is reported once the connection has been limited rateLimitKick times in a row.
+func (ws *WsServer) limitMessage(client *Client, reqIdentifier int32) (limited bool, kick bool) {
+	limit, ok := ws.rateLimits[reqIdentifier]
+	if !ok {
+		return false, false
+	}
+	var scope string
+	if limit.ConnRate > 0 {
+		if client.limiters == nil {
+			client.limiters = make(map[int32]*rate.Limiter)
+		}
+		l, ok := client.limiters[reqIdentifier]
+		if !ok {
+			l = newLimiter(limit.ConnRate, limit.ConnBurst)
+			client.limiters[reqIdentifier] = l
+		}
+		if !l.Allow() {
+			scope = "conn"
+		}
+	}
+	if scope == "" && limit.UserRate > 0 {
+		v, _ := ws.userLimiters.LoadOrStore(client.UserID, &userLimiters{limiters: make(map[int32]*rate.Limiter)})
+		if !v.(*userLimiters).get(reqIdentifier, limit).Allow() {
+			scope = "user"
+		}
+	}
+	if scope == "" {
+		client.limitedNum = 0
+		return false, false
+	}
+	client.limitedNum++
+	prome.IncVec(prome.MsgGatewayRateLimitCounter, strconv.Itoa(int(reqIdentifier)), scope)
+	if ws.rateLimitKick > 0 && client.limitedNum >= ws.rateLimitKick {
+		prome.Inc(prome.MsgGatewayRateLimitKickCounter)
+		return true, true
+	}
+	return true, false
+}
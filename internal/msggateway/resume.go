@@ -0,0 +1,167 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msggateway
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/apiresp"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/gatewayext"
+)
+
+// resumeBatchSize limits the number of conversations pulled in one rpc.
+const resumeBatchSize = 50
+
+// parseResumeSeqs parses the resume handshake parameter, a comma separated list of conversationID:seq
+// with the last seq the client has seen in each conversation, e.g. si_a_b:10,sg_c:42.
+func parseResumeSeqs(s string) (map[string]int64, error) {
+	seqs := make(map[string]int64)
+	for _, item := range strings.Split(s, ",") {
+		if item == "" {
+			continue
+		}
+		i := strings.LastIndexByte(item, ':')
+		if i <= 0 {
+			return nil, errs.ErrConnArgsErr.Wrap("invalid resume item: " + item)
+		}
+		seq, err := strconv.ParseInt(item[i+1:], 10, 64)
+		if err != nil || seq < 0 {
+			return nil, errs.ErrConnArgsErr.Wrap("invalid resume seq: " + item)
+		}
+		seqs[item[:i]] = seq
+	}
+	return seqs, nil
+}
+
+// resume sends the messages the client missed since its resumeSeqs and then releases the live pushes
+// held meanwhile. It runs after the client is registered, so that every message newer than the max seqs
+// read here is either pushed live or already included.
+func (ws *WsServer) resume(client *Client) {
+	ctx := mcontext.WithMustInfoCtx(
+		[]string{utils.OperationIDGenerator(), client.UserID, constant.PlatformIDToName(client.PlatformID), client.ctx.GetConnID()},
+	)
+	done, err := ws.resumeMessages(ctx, client)
+	if err != nil {
+		log.ZWarn(ctx, "resume messages err", err, "userID", client.UserID, "resumeSeqs", client.resumeSeqs)
+	} else {
+		log.ZDebug(ctx, "resume messages", "userID", client.UserID, "msgNum", done.MsgNum, "incomplete", done.IncompleteConversationIDs)
+	}
+	if err := client.finishResume(ctx, done, err); err != nil {
+		log.ZWarn(ctx, "finishResume err", err, "userID", client.UserID)
+	}
+}
+
+func (ws *WsServer) resumeMessages(ctx context.Context, client *Client) (*gatewayext.ResumeDone, error) {
+	maxSeqs, err := ws.GetMaxSeqs(ctx, client.UserID)
+	if err != nil {
+		return nil, err
+	}
+	done := &gatewayext.ResumeDone{}
+	var seqRanges []*sdkws.SeqRange
+	// only conversations known to the client are resumed, new ones are found by its normal sync
+	for conversationID, seq := range client.resumeSeqs {
+		maxSeq := maxSeqs[conversationID]
+		if maxSeq <= seq {
+			continue
+		}
+		begin := seq + 1
+		if ws.resumeMaxMsgNum > 0 && maxSeq-begin+1 > ws.resumeMaxMsgNum {
+			begin = maxSeq - ws.resumeMaxMsgNum + 1
+			done.IncompleteConversationIDs = append(done.IncompleteConversationIDs, conversationID)
+		}
+		seqRanges = append(seqRanges, &sdkws.SeqRange{
+			ConversationID: conversationID,
+			Begin:          begin,
+			End:            maxSeq,
+			Num:            maxSeq - begin + 1,
+		})
+	}
+	for i := 0; i < len(seqRanges); i += resumeBatchSize {
+		end := i + resumeBatchSize
+		if end > len(seqRanges) {
+			end = len(seqRanges)
+		}
+		resp, err := ws.PullMessageBySeqRanges(ctx, client.UserID, seqRanges[i:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, msgs := range resp.Msgs {
+			done.MsgNum += int64(len(msgs.Msgs))
+		}
+		for _, msgs := range resp.NotificationMsgs {
+			done.MsgNum += int64(len(msgs.Msgs))
+		}
+		if err := client.resumeMessage(ctx, &sdkws.PushMessages{Msgs: resp.Msgs, NotificationMsgs: resp.NotificationMsgs}); err != nil {
+			return nil, err
+		}
+	}
+	return done, nil
+}
+
+func (c *Client) resumeMessage(ctx context.Context, msg *sdkws.PushMessages) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return c.writeMsg(Resp{
+		ReqIdentifier: WSPushMsg,
+		OperationID:   mcontext.GetOperationID(ctx),
+		Data:          data,
+	})
+}
+
+// finishResume tells the client that resume is over, a failed resume is reported in ErrCode and the client
+// falls back to pulling by itself, then writes the held live pushes in order.
+func (c *Client) finishResume(ctx context.Context, done *gatewayext.ResumeDone, resumeErr error) error {
+	resp := Resp{
+		ReqIdentifier: WsResumeDoneMsg,
+		OperationID:   mcontext.GetOperationID(ctx),
+	}
+	if resumeErr != nil {
+		errResp := apiresp.ParseError(resumeErr)
+		resp.ErrCode = errResp.ErrCode
+		resp.ErrMsg = errResp.ErrMsg
+	} else {
+		data, err := proto.Marshal(done)
+		if err != nil {
+			return err
+		}
+		resp.Data = data
+	}
+	c.w.Lock()
+	defer c.w.Unlock()
+	pending := c.pendingPush
+	c.resuming = false
+	c.pendingPush = nil
+	if err := c.write(resp); err != nil {
+		return err
+	}
+	for _, p := range pending {
+		if err := c.write(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
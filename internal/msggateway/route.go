@@ -0,0 +1,95 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msggateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+// routeBatchSize limits the number of users refreshed in one redis pipeline.
+const routeBatchSize = 1000
+
+func (ws *WsServer) routeEnabled() bool {
+	return ws.routeAddr != "" && ws.routeExpire > 0
+}
+
+func (ws *WsServer) addUserRoute(client *Client) {
+	if !ws.routeEnabled() {
+		return
+	}
+	if err := ws.cache.SetUserGatewayRoutes(client.ctx, ws.routeAddr, []string{client.UserID}, ws.routeExpire); err != nil {
+		log.ZWarn(client.ctx, "SetUserGatewayRoutes err", err, "userID", client.UserID, "gateway", ws.routeAddr)
+	}
+}
+
+func (ws *WsServer) delUserRoute(client *Client) {
+	if !ws.routeEnabled() {
+		return
+	}
+	// the user still has other connections on this gateway
+	if _, ok := ws.clients.GetAll(client.UserID); ok {
+		return
+	}
+	if err := ws.cache.DelUserGatewayRoute(client.ctx, ws.routeAddr, client.UserID); err != nil {
+		log.ZWarn(client.ctx, "DelUserGatewayRoute err", err, "userID", client.UserID, "gateway", ws.routeAddr)
+	}
+}
+
+// refreshRoutes periodically renews the alive key of this gateway and the route entries of all online users,
+// entries of a crashed gateway are not renewed and expire on their own.
+func (ws *WsServer) refreshRoutes() {
+	if !ws.routeEnabled() {
+		return
+	}
+	interval := ws.routeExpire / 3
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// a draining gateway has deregistered, its entries are left to expire
+		if ws.isDraining() {
+			return
+		}
+		ctx := mcontext.NewCtx(utils.GetSelfFuncName() + "-" + utils.OperationIDGenerator())
+		ws.refreshRoutesOnce(ctx)
+		<-ticker.C
+	}
+}
+
+func (ws *WsServer) refreshRoutesOnce(ctx context.Context) {
+	userIDs := ws.clients.UserIDs()
+	for i := 0; i < len(userIDs); i += routeBatchSize {
+		end := i + routeBatchSize
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+		if err := ws.cache.SetUserGatewayRoutes(ctx, ws.routeAddr, userIDs[i:end], ws.routeExpire); err != nil {
+			log.ZWarn(ctx, "refresh user gateway routes err", err, "gateway", ws.routeAddr)
+			return
+		}
+	}
+	// the alive key is set last so that the pusher never trusts a gateway whose entries are not written yet
+	if err := ws.cache.SetGatewayAlive(ctx, ws.routeAddr, ws.routeExpire); err != nil {
+		log.ZWarn(ctx, "SetGatewayAlive err", err, "gateway", ws.routeAddr)
+	}
+	log.ZDebug(ctx, "refresh user gateway routes", "gateway", ws.routeAddr, "userNum", len(userIDs))
+}
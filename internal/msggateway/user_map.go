@@ -115,6 +115,24 @@ func (u *UserMap) deleteClients(key string, clients []*Client) (isDeleteUser boo
 	return existed
 }
 
+func (u *UserMap) UserIDs() []string {
+	var userIDs []string
+	u.m.Range(func(key, value any) bool {
+		userIDs = append(userIDs, key.(string))
+		return true
+	})
+	return userIDs
+}
+
+func (u *UserMap) Clients() []*Client {
+	var clients []*Client
+	u.m.Range(func(key, value any) bool {
+		clients = append(clients, value.([]*Client)...)
+		return true
+	})
+	return clients
+}
+
 func (u *UserMap) DeleteAll(key string) {
 	u.m.Delete(key)
 }
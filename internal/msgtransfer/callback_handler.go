@@ -0,0 +1,89 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgtransfer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/http"
+	kfk "github.com/OpenIMSDK/Open-IM-Server/pkg/common/kafka"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+)
+
+// CallbackConsumerHandler sends the after callbacks queued by the other services,
+// a url that keeps failing gets its own copy of the task dead-lettered.
+type CallbackConsumerHandler struct {
+	callbackConsumerGroup *kfk.MConsumerGroup
+	retry                 kfk.RetryPolicy
+	deadLetter            *kfk.DeadLetterProducer
+}
+
+func NewCallbackConsumerHandler(deadLetter *kfk.DeadLetterProducer) *CallbackConsumerHandler {
+	return &CallbackConsumerHandler{
+		callbackConsumerGroup: kfk.NewMConsumerGroup(&kfk.MConsumerGroupConfig{
+			KafkaVersion:   sarama.V2_0_0_0,
+			OffsetsInitial: sarama.OffsetNewest, IsReturnErr: false,
+		}, []string{config.Config.Kafka.Callback.Topic},
+			config.Config.Kafka.Addr, config.Config.Kafka.ConsumerGroupID.Callback),
+		retry:      kfk.NewRetryPolicy(config.Config.Kafka.Retry.Callback),
+		deadLetter: deadLetter,
+	}
+}
+
+func (c *CallbackConsumerHandler) handleCallback(cMsg *sarama.ConsumerMessage) {
+	var task http.CallbackTask
+	if err := json.Unmarshal(cMsg.Value, &task); err != nil {
+		ctx := mcontext.NewCtx(string(cMsg.Key))
+		log.ZError(ctx, "unmarshal callback task failed", err, "key", string(cMsg.Key), "len", len(cMsg.Value))
+		c.deadLetter.Send(ctx, kfk.NewConsumedDeadLetter(cMsg, config.Config.Kafka.ConsumerGroupID.Callback, 1, err))
+		return
+	}
+	ctx := mcontext.NewCtx(task.OperationID)
+	for _, url := range task.URLs {
+		attempts, err := c.retry.Do(ctx, "callback "+task.Command, func() error {
+			return http.PostCallbackTask(ctx, &task, url)
+		})
+		if err == nil {
+			continue
+		}
+		log.ZError(ctx, "callback failed", err, "command", task.Command, "url", url, "attempts", attempts)
+		failed := task
+		failed.URLs = []string{url}
+		value, _ := json.Marshal(&failed)
+		dl := kfk.NewConsumedDeadLetter(cMsg, config.Config.Kafka.ConsumerGroupID.Callback, attempts, err)
+		dl.Value = value
+		c.deadLetter.Send(ctx, dl)
+	}
+}
+
+func (CallbackConsumerHandler) Setup(_ sarama.ConsumerGroupSession) error   { return nil }
+func (CallbackConsumerHandler) Cleanup(_ sarama.ConsumerGroupSession) error { return nil }
+
+func (c *CallbackConsumerHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	log.ZDebug(context.Background(), "callback new session msg come", "highWaterMarkOffset",
+		claim.HighWaterMarkOffset(), "topic", claim.Topic(), "partition", claim.Partition())
+	for msg := range claim.Messages() {
+		if len(msg.Value) != 0 {
+			c.handleCallback(msg)
+		}
+		sess.MarkMessage(msg, "")
+	}
+	return nil
+}
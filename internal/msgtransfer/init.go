@@ -28,6 +28,7 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/relation"
 	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/kafka"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/prome"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 	openKeeper "github.com/OpenIMSDK/tools/discoveryregistry/zookeeper"
@@ -39,6 +40,7 @@ type MsgTransfer struct {
 	persistentCH   *PersistentConsumerHandler         // 聊天记录持久化到mysql的消费者 订阅的topic: ws2ms_chat
 	historyCH      *OnlineHistoryRedisConsumerHandler // 这个消费者聚合消息, 订阅的topic：ws2ms_chat, 修改通知发往msg_to_modify topic, 消息存入redis后Incr Redis, 再发消息到ms2pschat topic推送， 发消息到msg_to_mongo topic持久化
 	historyMongoCH *OnlineHistoryMongoConsumerHandler // mongoDB批量插入, 成功后删除redis中消息，以及处理删除通知消息删除的 订阅的topic: msg_to_mongo
+	callbackCH     *CallbackConsumerHandler           // 发送异步的after回调, 订阅的topic: callback
 	// modifyCH       *ModifyMsgConsumerHandler          // 负责消费修改消息通知的consumer, 订阅的topic: msg_to_modify
 }
 
@@ -61,6 +63,9 @@ func StartTransfer(prometheusPort int) error {
 	if err := mongo.CreateMsgIndex(); err != nil {
 		return err
 	}
+	if err := mongo.CreateMsgSearchIndex(); err != nil {
+		return err
+	}
 	client, err := openKeeper.NewClient(config.Config.Zookeeper.ZkAddr, config.Config.Zookeeper.Schema,
 		openKeeper.WithFreq(time.Hour), openKeeper.WithRoundRobin(), openKeeper.WithUserNameAndPassword(config.Config.Zookeeper.Username,
 			config.Config.Zookeeper.Password), openKeeper.WithTimeout(10), openKeeper.WithLogger(log.NewZkLogger()))
@@ -78,19 +83,25 @@ func StartTransfer(prometheusPort int) error {
 	msgDatabase := controller.NewCommonMsgDatabase(msgDocModel, msgModel)
 	conversationRpcClient := rpcclient.NewConversationRpcClient(client)
 	groupRpcClient := rpcclient.NewGroupRpcClient(client)
-	msgTransfer := NewMsgTransfer(chatLogDatabase, msgDatabase, &conversationRpcClient, &groupRpcClient)
+	msgSearchDatabase := controller.NewMsgSearchDatabase(unrelation.NewMsgSearchMongoDriver(mongo.GetDatabase()))
+	msgTransfer := NewMsgTransfer(chatLogDatabase, msgDatabase, msgSearchDatabase, &conversationRpcClient, &groupRpcClient)
 	msgTransfer.initPrometheus()
 	return msgTransfer.Start(prometheusPort)
 }
 
 func NewMsgTransfer(chatLogDatabase controller.ChatLogDatabase,
-	msgDatabase controller.CommonMsgDatabase,
+	msgDatabase controller.CommonMsgDatabase, msgSearchDatabase controller.MsgSearchDatabase,
 	conversationRpcClient *rpcclient.ConversationRpcClient, groupRpcClient *rpcclient.GroupRpcClient,
 ) *MsgTransfer {
-	return &MsgTransfer{
-		persistentCH: NewPersistentConsumerHandler(chatLogDatabase), historyCH: NewOnlineHistoryRedisConsumerHandler(msgDatabase, conversationRpcClient, groupRpcClient),
-		historyMongoCH: NewOnlineHistoryMongoConsumerHandler(msgDatabase),
+	deadLetter := kafka.NewDeadLetterProducer()
+	msgTransfer := &MsgTransfer{
+		persistentCH: NewPersistentConsumerHandler(chatLogDatabase, deadLetter), historyCH: NewOnlineHistoryRedisConsumerHandler(msgDatabase, conversationRpcClient, groupRpcClient, deadLetter),
+		historyMongoCH: NewOnlineHistoryMongoConsumerHandler(msgDatabase, msgSearchDatabase, deadLetter),
 	}
+	if config.Config.Kafka.Callback.Topic != "" {
+		msgTransfer.callbackCH = NewCallbackConsumerHandler(deadLetter)
+	}
+	return msgTransfer
 }
 
 func (m *MsgTransfer) initPrometheus() {
@@ -102,6 +113,7 @@ func (m *MsgTransfer) initPrometheus() {
 	prome.NewMsgInsertRedisFailedCounter()
 	prome.NewMsgInsertMongoSuccessCounter()
 	prome.NewMsgInsertMongoFailedCounter()
+	prome.NewDeadLetterCounter()
 }
 
 func (m *MsgTransfer) Start(prometheusPort int) error {
@@ -115,6 +127,9 @@ func (m *MsgTransfer) Start(prometheusPort int) error {
 	}
 	go m.historyCH.historyConsumerGroup.RegisterHandleAndConsumer(m.historyCH)
 	go m.historyMongoCH.historyConsumerGroup.RegisterHandleAndConsumer(m.historyMongoCH)
+	if m.callbackCH != nil {
+		go m.callbackCH.callbackConsumerGroup.RegisterHandleAndConsumer(m.callbackCH)
+	}
 	// go m.modifyCH.modifyMsgConsumerGroup.RegisterHandleAndConsumer(m.modifyCH)
 	err := prome.StartPrometheusSrv(prometheusPort)
 	if err != nil {
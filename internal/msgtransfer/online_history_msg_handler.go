@@ -33,6 +33,7 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/kafka"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 	"github.com/OpenIMSDK/protocol/constant"
+	pbMsg "github.com/OpenIMSDK/protocol/msg"
 	"github.com/OpenIMSDK/protocol/sdkws"
 	"github.com/OpenIMSDK/tools/log"
 	"github.com/OpenIMSDK/tools/mcontext"
@@ -50,11 +51,40 @@ type MsgChannelValue struct {
 	uniqueKey  string
 	ctx        context.Context
 	ctxMsgList []*ContextMsg
+	batch      *consumerBatch
 }
 
 type TriggerChannelValue struct {
 	ctx      context.Context
 	cMsgList []*sarama.ConsumerMessage
+	batch    *consumerBatch
+}
+
+// consumerBatch tracks the kafka messages flushed together, their offsets are only marked
+// once every message is in redis or parked in the dead letter topic.
+type consumerBatch struct {
+	msgs []*sarama.ConsumerMessage
+	wg   sync.WaitGroup
+	lock sync.Mutex
+	err  error
+}
+
+func (b *consumerBatch) done(err error) {
+	if err != nil {
+		b.lock.Lock()
+		if b.err == nil {
+			b.err = err
+		}
+		b.lock.Unlock()
+	}
+	b.wg.Done()
+}
+
+func (b *consumerBatch) wait() error {
+	b.wg.Wait()
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.err
 }
 
 type Cmd2Value struct {
@@ -79,15 +109,20 @@ type OnlineHistoryRedisConsumerHandler struct {
 	msgDatabase           controller.CommonMsgDatabase
 	conversationRpcClient *rpcclient.ConversationRpcClient
 	groupRpcClient        *rpcclient.GroupRpcClient
+	retry                 kafka.RetryPolicy
+	deadLetter            *kafka.DeadLetterProducer
 }
 
 func NewOnlineHistoryRedisConsumerHandler(
 	database controller.CommonMsgDatabase,
 	conversationRpcClient *rpcclient.ConversationRpcClient,
 	groupRpcClient *rpcclient.GroupRpcClient,
+	deadLetter *kafka.DeadLetterProducer,
 ) *OnlineHistoryRedisConsumerHandler {
 	var och OnlineHistoryRedisConsumerHandler
 	och.msgDatabase = database
+	och.retry = kafka.NewRetryPolicy(config.Config.Kafka.Retry.MsgToRedis)
+	och.deadLetter = deadLetter
 	och.msgDistributionCh = make(chan Cmd2Value) // no buffer channel
 	go och.MessagesDistributionHandle()
 	for i := 0; i < ChannelNum; i++ {
@@ -144,14 +179,17 @@ func (och *OnlineHistoryRedisConsumerHandler) Run(channelID int) {
 				)
 				conversationIDMsg := msgprocessor.GetChatConversationIDByMsg(ctxMsgList[0].message)
 				conversationIDNotification := msgprocessor.GetNotificationConversationID(ctxMsgList[0].message)
-				och.handleMsg(ctx, msgChannelValue.uniqueKey, conversationIDMsg, storageMsgList, notStorageMsgList)
-				och.handleNotification(
+				err := och.handleMsg(ctx, msgChannelValue.uniqueKey, conversationIDMsg, storageMsgList, notStorageMsgList)
+				if notificationErr := och.handleNotification(
 					ctx,
 					msgChannelValue.uniqueKey,
 					conversationIDNotification,
 					storageNotificationList,
 					notStorageNotificationList,
-				)
+				); err == nil {
+					err = notificationErr
+				}
+				msgChannelValue.batch.done(err)
 				if err := och.msgDatabase.MsgToModifyMQ(ctx, msgChannelValue.uniqueKey, conversationIDNotification, modifyMsgList); err != nil {
 					log.ZError(
 						ctx,
@@ -233,52 +271,128 @@ func (och *OnlineHistoryRedisConsumerHandler) handleNotification(
 	ctx context.Context,
 	key, conversationID string,
 	storageList, notStorageList []*sdkws.MsgData,
-) {
-	och.toPushTopic(ctx, key, conversationID, notStorageList)
+) error {
+	pushErr := och.toPushTopic(ctx, key, conversationID, notStorageList)
 	if len(storageList) > 0 {
-		lastSeq, _, err := och.msgDatabase.BatchInsertChat2Cache(ctx, conversationID, storageList)
+		lastSeq, _, stored, err := och.batchInsertChat2Cache(ctx, key, conversationID, storageList)
 		if err != nil {
-			log.ZError(
-				ctx,
-				"notification batch insert to redis error",
-				err,
-				"conversationID",
-				conversationID,
-				"storageList",
-				storageList,
-			)
-			return
+			return err
+		}
+		if !stored {
+			return pushErr
 		}
 		log.ZDebug(ctx, "success to next topic", "conversationID", conversationID)
-		och.msgDatabase.MsgToMongoMQ(ctx, key, conversationID, storageList, lastSeq)
-		och.toPushTopic(ctx, key, conversationID, storageList)
+		if err := och.toMongoTopic(ctx, key, conversationID, storageList, lastSeq); err != nil {
+			return err
+		}
+		if err := och.toPushTopic(ctx, key, conversationID, storageList); err != nil {
+			return err
+		}
 	}
+	return pushErr
+}
+
+// batchInsertChat2Cache retries the insert, the messages are dead-lettered to be consumed again once it still fails.
+// stored is false when they were dead-lettered instead, err is only returned when they are neither stored nor parked.
+func (och *OnlineHistoryRedisConsumerHandler) batchInsertChat2Cache(
+	ctx context.Context,
+	key, conversationID string,
+	msgs []*sdkws.MsgData,
+) (lastSeq int64, isNewConversation bool, stored bool, err error) {
+	attempts, insertErr := och.retry.Do(ctx, "BatchInsertChat2Cache", func() error {
+		var err error
+		lastSeq, isNewConversation, err = och.msgDatabase.BatchInsertChat2Cache(ctx, conversationID, msgs)
+		if err != nil && errs.Unwrap(err) == redis.Nil {
+			return nil
+		}
+		return err
+	})
+	if insertErr == nil {
+		return lastSeq, isNewConversation, true, nil
+	}
+	log.ZError(ctx, "batch insert to redis failed", insertErr, "conversationID", conversationID, "msgs", msgs)
+	for _, msg := range msgs {
+		value, marshalErr := proto.Marshal(msg)
+		if marshalErr != nil {
+			log.ZError(ctx, "marshal dead letter failed", marshalErr, "msg", msg)
+			return 0, false, false, marshalErr
+		}
+		if err := och.deadLetter.Send(ctx, kafka.NewProducedDeadLetter(ctx, config.Config.Kafka.LatestMsgToRedis.Topic, key, value,
+			config.Config.Kafka.ConsumerGroupID.MsgToRedis, attempts, insertErr)); err != nil {
+			return 0, false, false, err
+		}
+	}
+	return 0, false, false, nil
+}
+
+func (och *OnlineHistoryRedisConsumerHandler) toMongoTopic(
+	ctx context.Context,
+	key, conversationID string,
+	msgs []*sdkws.MsgData,
+	lastSeq int64,
+) error {
+	attempts, err := och.retry.Do(ctx, "MsgToMongoMQ", func() error {
+		return och.msgDatabase.MsgToMongoMQ(ctx, key, conversationID, msgs, lastSeq)
+	})
+	if err == nil {
+		return nil
+	}
+	value, marshalErr := proto.Marshal(&pbMsg.MsgDataToMongoByMQ{LastSeq: lastSeq, ConversationID: conversationID, MsgData: msgs})
+	if marshalErr != nil {
+		log.ZError(ctx, "marshal dead letter failed", marshalErr, "conversationID", conversationID, "msgs", msgs)
+		return marshalErr
+	}
+	return och.deadLetter.Send(ctx, kafka.NewProducedDeadLetter(ctx, config.Config.Kafka.MsgToMongo.Topic, key, value,
+		config.Config.Kafka.ConsumerGroupID.MsgToRedis, attempts, err))
 }
 
 func (och *OnlineHistoryRedisConsumerHandler) toPushTopic(
 	ctx context.Context,
 	key, conversationID string,
 	msgs []*sdkws.MsgData,
-) {
+) error {
+	// every message is tried, the first one that could not be parked is returned
+	var lostErr error
 	for _, v := range msgs {
-		och.msgDatabase.MsgToPushMQ(ctx, key, conversationID, v)
+		attempts, err := och.retry.Do(ctx, "MsgToPushMQ", func() error {
+			_, _, err := och.msgDatabase.MsgToPushMQ(ctx, key, conversationID, v)
+			return err
+		})
+		if err == nil {
+			continue
+		}
+		value, marshalErr := proto.Marshal(&pbMsg.PushMsgDataToMQ{MsgData: v, ConversationID: conversationID})
+		if marshalErr != nil {
+			log.ZError(ctx, "marshal dead letter failed", marshalErr, "conversationID", conversationID, "msg", v)
+			if lostErr == nil {
+				lostErr = marshalErr
+			}
+			continue
+		}
+		if err := och.deadLetter.Send(ctx, kafka.NewProducedDeadLetter(ctx, config.Config.Kafka.MsgToPush.Topic, key, value,
+			config.Config.Kafka.ConsumerGroupID.MsgToRedis, attempts, err)); err != nil && lostErr == nil {
+			lostErr = err
+		}
 	}
+	return lostErr
 }
 
 func (och *OnlineHistoryRedisConsumerHandler) handleMsg(
 	ctx context.Context,
 	key, conversationID string,
 	storageList, notStorageList []*sdkws.MsgData,
-) {
-	och.toPushTopic(ctx, key, conversationID, notStorageList)
+) error {
+	pushErr := och.toPushTopic(ctx, key, conversationID, notStorageList)
 	if len(storageList) > 0 {
-		lastSeq, isNewConversation, err := och.msgDatabase.BatchInsertChat2Cache(ctx, conversationID, storageList)
-		if err != nil && errs.Unwrap(err) != redis.Nil {
-			log.ZError(ctx, "batch data insert to redis err", err, "storageMsgList", storageList)
+		lastSeq, isNewConversation, stored, err := och.batchInsertChat2Cache(ctx, key, conversationID, storageList)
+		if err != nil || !stored {
 			och.singleMsgFailedCountMutex.Lock()
 			och.singleMsgFailedCount += uint64(len(storageList))
 			och.singleMsgFailedCountMutex.Unlock()
-			return
+			if err != nil {
+				return err
+			}
+			return pushErr
 		}
 		if isNewConversation {
 			if storageList[0].SessionType == constant.SuperGroupChatType {
@@ -302,9 +416,14 @@ func (och *OnlineHistoryRedisConsumerHandler) handleMsg(
 		och.singleMsgSuccessCountMutex.Lock()
 		och.singleMsgSuccessCount += uint64(len(storageList))
 		och.singleMsgSuccessCountMutex.Unlock()
-		och.msgDatabase.MsgToMongoMQ(ctx, key, conversationID, storageList, lastSeq)
-		och.toPushTopic(ctx, key, conversationID, storageList)
+		if err := och.toMongoTopic(ctx, key, conversationID, storageList, lastSeq); err != nil {
+			return err
+		}
+		if err := och.toPushTopic(ctx, key, conversationID, storageList); err != nil {
+			return err
+		}
 	}
+	return pushErr
 }
 
 func (och *OnlineHistoryRedisConsumerHandler) MessagesDistributionHandle() {
@@ -317,6 +436,7 @@ func (och *OnlineHistoryRedisConsumerHandler) MessagesDistributionHandle() {
 				triggerChannelValue := cmd.Value.(TriggerChannelValue)
 				ctx := triggerChannelValue.ctx
 				consumerMessages := triggerChannelValue.cMsgList
+				batch := triggerChannelValue.batch
 				// Aggregation map[userid]message list
 				log.ZDebug(ctx, "batch messages come to distribution center", "length", len(consumerMessages))
 				for i := 0; i < len(consumerMessages); i++ {
@@ -325,6 +445,10 @@ func (och *OnlineHistoryRedisConsumerHandler) MessagesDistributionHandle() {
 					err := proto.Unmarshal(consumerMessages[i].Value, msgFromMQ)
 					if err != nil {
 						log.ZError(ctx, "msg_transfer Unmarshal msg err", err, string(consumerMessages[i].Value))
+						// not retried, the payload itself is broken
+						batch.wg.Add(1)
+						batch.done(och.deadLetter.Send(ctx, kafka.NewConsumedDeadLetter(consumerMessages[i],
+							config.Config.Kafka.ConsumerGroupID.MsgToRedis, 1, err)))
 						continue
 					}
 					var arr []string
@@ -361,6 +485,7 @@ func (och *OnlineHistoryRedisConsumerHandler) MessagesDistributionHandle() {
 					}
 				}
 				log.ZDebug(ctx, "generate map list users len", "length", len(aggregationMsgs))
+				batch.wg.Add(len(aggregationMsgs))
 				for uniqueKey, v := range aggregationMsgs {
 					if len(v) >= 0 {
 						hashCode := utils.GetHashCode(uniqueKey)
@@ -376,9 +501,11 @@ func (och *OnlineHistoryRedisConsumerHandler) MessagesDistributionHandle() {
 							"uniqueKey",
 							uniqueKey,
 						)
-						och.chArrays[channelID] <- Cmd2Value{Cmd: SourceMessages, Value: MsgChannelValue{uniqueKey: uniqueKey, ctxMsgList: v, ctx: newCtx}}
+						och.chArrays[channelID] <- Cmd2Value{Cmd: SourceMessages, Value: MsgChannelValue{uniqueKey: uniqueKey, ctxMsgList: v, ctx: newCtx, batch: batch}}
 					}
 				}
+				// the split itself, added when it was sent
+				batch.done(nil)
 			}
 		}
 	}
@@ -415,49 +542,67 @@ func (och *OnlineHistoryRedisConsumerHandler) ConsumeClaim(
 			break
 		}
 	}
-	rwLock := new(sync.RWMutex)
 	log.ZDebug(context.Background(), "online new session msg come", "highWaterMarkOffset",
 		claim.HighWaterMarkOffset(), "topic", claim.Topic(), "partition", claim.Partition())
 	cMsg := make([]*sarama.ConsumerMessage, 0, 1000)
-	t := time.NewTicker(time.Millisecond * 100)
+	// batches are marked in order, a batch with a message that was neither stored nor dead-lettered
+	// stops the marking so that it is consumed again after the next rebalance
+	batches := make(chan *consumerBatch, 100)
 	go func() {
-		for {
-			select {
-			case <-t.C:
-				if len(cMsg) > 0 {
-					rwLock.Lock()
-					ccMsg := make([]*sarama.ConsumerMessage, 0, 1000)
-					for _, v := range cMsg {
-						ccMsg = append(ccMsg, v)
-					}
-					cMsg = make([]*sarama.ConsumerMessage, 0, 1000)
-					rwLock.Unlock()
-					split := 1000
-					ctx := mcontext.WithTriggerIDContext(context.Background(), utils.OperationIDGenerator())
-					log.ZDebug(ctx, "timer trigger msg consumer start", "length", len(ccMsg))
-					for i := 0; i < len(ccMsg)/split; i++ {
-						// log.Debug()
-						och.msgDistributionCh <- Cmd2Value{Cmd: ConsumerMsgs, Value: TriggerChannelValue{
-							ctx: ctx, cMsgList: ccMsg[i*split : (i+1)*split],
-						}}
-					}
-					if (len(ccMsg) % split) > 0 {
-						och.msgDistributionCh <- Cmd2Value{Cmd: ConsumerMsgs, Value: TriggerChannelValue{
-							ctx: ctx, cMsgList: ccMsg[split*(len(ccMsg)/split):],
-						}}
-					}
-					log.ZDebug(ctx, "timer trigger msg consumer end", "length", len(ccMsg))
+		for batch := range batches {
+			if err := batch.wait(); err != nil {
+				log.ZError(context.Background(), "msg neither stored nor dead-lettered, offsets not marked", err,
+					"partition", claim.Partition(), "offset", batch.msgs[0].Offset)
+				for range batches {
 				}
+				return
+			}
+			for _, msg := range batch.msgs {
+				sess.MarkMessage(msg, "")
 			}
 		}
 	}()
-	for msg := range claim.Messages() {
-		rwLock.Lock()
-		if len(msg.Value) != 0 {
-			cMsg = append(cMsg, msg)
+	defer close(batches)
+	t := time.NewTicker(time.Millisecond * 100)
+	defer t.Stop()
+	flush := func() {
+		ccMsg := cMsg
+		cMsg = make([]*sarama.ConsumerMessage, 0, 1000)
+		if len(ccMsg) == 0 {
+			return
+		}
+		split := 1000
+		ctx := mcontext.WithTriggerIDContext(context.Background(), utils.OperationIDGenerator())
+		log.ZDebug(ctx, "timer trigger msg consumer start", "length", len(ccMsg))
+		batch := &consumerBatch{msgs: ccMsg}
+		for i := 0; i < len(ccMsg); i += split {
+			end := i + split
+			if end > len(ccMsg) {
+				end = len(ccMsg)
+			}
+			batch.wg.Add(1)
+			och.msgDistributionCh <- Cmd2Value{Cmd: ConsumerMsgs, Value: TriggerChannelValue{
+				ctx: ctx, cMsgList: ccMsg[i:end], batch: batch,
+			}}
+		}
+		batches <- batch
+		log.ZDebug(ctx, "timer trigger msg consumer end", "length", len(ccMsg))
+	}
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				flush()
+				return nil
+			}
+			// empty messages are marked with the batch that follows them
+			if len(msg.Value) != 0 {
+				cMsg = append(cMsg, msg)
+			}
+		case <-t.C:
+			flush()
+		case <-sess.Context().Done():
+			return nil
 		}
-		rwLock.Unlock()
-		sess.MarkMessage(msg, "")
 	}
-	return nil
 }
@@ -16,6 +16,7 @@ package msgtransfer
 
 import (
 	"context"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"google.golang.org/protobuf/proto"
@@ -30,16 +31,26 @@ import (
 type OnlineHistoryMongoConsumerHandler struct {
 	historyConsumerGroup *kfk.MConsumerGroup
 	msgDatabase          controller.CommonMsgDatabase
+	msgSearchDatabase    controller.MsgSearchDatabase
+	retry                kfk.RetryPolicy
+	deadLetter           *kfk.DeadLetterProducer
 }
 
-func NewOnlineHistoryMongoConsumerHandler(database controller.CommonMsgDatabase) *OnlineHistoryMongoConsumerHandler {
+func NewOnlineHistoryMongoConsumerHandler(
+	database controller.CommonMsgDatabase,
+	searchDatabase controller.MsgSearchDatabase,
+	deadLetter *kfk.DeadLetterProducer,
+) *OnlineHistoryMongoConsumerHandler {
 	mc := &OnlineHistoryMongoConsumerHandler{
 		historyConsumerGroup: kfk.NewMConsumerGroup(&kfk.MConsumerGroupConfig{
 			KafkaVersion:   sarama.V2_0_0_0,
-			OffsetsInitial: sarama.OffsetNewest, IsReturnErr: false,
+			OffsetsInitial: sarama.OffsetNewest, IsReturnErr: false, ManualCommit: true,
 		}, []string{config.Config.Kafka.MsgToMongo.Topic},
 			config.Config.Kafka.Addr, config.Config.Kafka.ConsumerGroupID.MsgToMongo),
-		msgDatabase: database,
+		msgDatabase:       database,
+		msgSearchDatabase: searchDatabase,
+		retry:             kfk.NewRetryPolicy(config.Config.Kafka.Retry.MsgToMongo),
+		deadLetter:        deadLetter,
 	}
 	return mc
 }
@@ -49,20 +60,26 @@ func (mc *OnlineHistoryMongoConsumerHandler) handleChatWs2Mongo(
 	cMsg *sarama.ConsumerMessage,
 	key string,
 	session sarama.ConsumerGroupSession,
-) {
+) error {
 	msg := cMsg.Value
 	msgFromMQ := pbMsg.MsgDataToMongoByMQ{}
 	err := proto.Unmarshal(msg, &msgFromMQ)
 	if err != nil {
 		log.ZError(ctx, "unmarshall failed", err, "key", key, "len", len(msg))
-		return
+		return mc.deadLetter.Send(ctx, kfk.NewConsumedDeadLetter(cMsg, config.Config.Kafka.ConsumerGroupID.MsgToMongo, 1, err))
 	}
 	if len(msgFromMQ.MsgData) == 0 {
 		log.ZError(ctx, "msgFromMQ.MsgData is empty", nil, "cMsg", cMsg)
-		return
+		return nil
 	}
 	log.ZInfo(ctx, "mongo consumer recv msg", "msgs", msgFromMQ.MsgData)
-	err = mc.msgDatabase.BatchInsertChat2DB(ctx, msgFromMQ.ConversationID, msgFromMQ.MsgData, msgFromMQ.LastSeq)
+	attempts, err := mc.retry.Do(ctx, "BatchInsertChat2DB", func() error {
+		if err := mc.msgDatabase.BatchInsertChat2DB(ctx, msgFromMQ.ConversationID, msgFromMQ.MsgData, msgFromMQ.LastSeq); err != nil {
+			return err
+		}
+		// both writes are idempotent, a failed index is retried with the insert
+		return mc.msgSearchDatabase.IndexMsgs(ctx, msgFromMQ.ConversationID, msgFromMQ.MsgData)
+	})
 	if err != nil {
 		log.ZError(
 			ctx,
@@ -73,6 +90,8 @@ func (mc *OnlineHistoryMongoConsumerHandler) handleChatWs2Mongo(
 			"conversationID",
 			msgFromMQ.ConversationID,
 		)
+		// the cache keeps the messages until the replayed insert succeeds
+		return mc.deadLetter.Send(ctx, kfk.NewConsumedDeadLetter(cMsg, config.Config.Kafka.ConsumerGroupID.MsgToMongo, attempts, err))
 	}
 	var seqs []int64
 	for _, msg := range msgFromMQ.MsgData {
@@ -91,8 +110,12 @@ func (mc *OnlineHistoryMongoConsumerHandler) handleChatWs2Mongo(
 		)
 	}
 	mc.msgDatabase.DelUserDeleteMsgsList(ctx, msgFromMQ.ConversationID, seqs)
+	return nil
 }
 
+// mongoCommitBatchSize is the most stored messages whose offsets are committed together.
+const mongoCommitBatchSize = 100
+
 func (OnlineHistoryMongoConsumerHandler) Setup(_ sarama.ConsumerGroupSession) error   { return nil }
 func (OnlineHistoryMongoConsumerHandler) Cleanup(_ sarama.ConsumerGroupSession) error { return nil }
 
@@ -102,14 +125,37 @@ func (mc *OnlineHistoryMongoConsumerHandler) ConsumeClaim(
 ) error { // a instance in the consumer group
 	log.ZDebug(context.Background(), "online new session msg come", "highWaterMarkOffset",
 		claim.HighWaterMarkOffset(), "topic", claim.Topic(), "partition", claim.Partition())
+	var uncommitted int
+	defer func() {
+		// a failed commit only re-delivers batches that are already stored
+		if uncommitted > 0 {
+			sess.Commit()
+		}
+	}()
 	for msg := range claim.Messages() {
 		ctx := mc.historyConsumerGroup.GetContextFromMsg(msg)
 		if len(msg.Value) != 0 {
-			mc.handleChatWs2Mongo(ctx, msg, string(msg.Key), sess)
+			// the offset is only committed once the batch is in mongo or parked in the dead letter topic,
+			// a batch re-delivered after a rebalance is a no-op for the seqs that are already stored.
+			for mc.handleChatWs2Mongo(ctx, msg, string(msg.Key), sess) != nil {
+				select {
+				case <-sess.Context().Done():
+					log.ZWarn(ctx, "session closed before msg was stored, not committed", nil,
+						"partition", msg.Partition, "offset", msg.Offset)
+					return nil
+				case <-time.After(time.Second):
+				}
+			}
 		} else {
 			log.ZError(ctx, "mongo msg get from kafka but is nil", nil, "conversationID", msg.Key)
 		}
 		sess.MarkMessage(msg, "")
+		// commit once per batch, the batch ends when no more messages are buffered
+		uncommitted++
+		if uncommitted >= mongoCommitBatchSize || len(claim.Messages()) == 0 {
+			sess.Commit()
+			uncommitted = 0
+		}
 	}
 	return nil
 }
@@ -32,9 +32,14 @@ import (
 type PersistentConsumerHandler struct {
 	persistentConsumerGroup *kfk.MConsumerGroup
 	chatLogDatabase         controller.ChatLogDatabase
+	retry                   kfk.RetryPolicy
+	deadLetter              *kfk.DeadLetterProducer
 }
 
-func NewPersistentConsumerHandler(database controller.ChatLogDatabase) *PersistentConsumerHandler {
+func NewPersistentConsumerHandler(
+	database controller.ChatLogDatabase,
+	deadLetter *kfk.DeadLetterProducer,
+) *PersistentConsumerHandler {
 	return &PersistentConsumerHandler{
 		persistentConsumerGroup: kfk.NewMConsumerGroup(&kfk.MConsumerGroupConfig{
 			KafkaVersion:   sarama.V2_0_0_0,
@@ -42,6 +47,8 @@ func NewPersistentConsumerHandler(database controller.ChatLogDatabase) *Persiste
 		}, []string{config.Config.Kafka.LatestMsgToRedis.Topic},
 			config.Config.Kafka.Addr, config.Config.Kafka.ConsumerGroupID.MsgToMySql),
 		chatLogDatabase: database,
+		retry:           kfk.NewRetryPolicy(config.Config.Kafka.Retry.MsgToMySql),
+		deadLetter:      deadLetter,
 	}
 }
 
@@ -57,6 +64,7 @@ func (pc *PersistentConsumerHandler) handleChatWs2Mysql(
 	err := proto.Unmarshal(msg, &msgFromMQ)
 	if err != nil {
 		log.ZError(ctx, "msg_transfer Unmarshal msg err", err)
+		pc.deadLetter.Send(ctx, kfk.NewConsumedDeadLetter(cMsg, config.Config.Kafka.ConsumerGroupID.MsgToMySql, 1, err))
 		return
 	}
 	return
@@ -79,8 +87,12 @@ func (pc *PersistentConsumerHandler) handleChatWs2Mysql(
 		}
 		if tag {
 			log.ZInfo(ctx, "msg_transfer msg persisting", "msg", string(msg))
-			if err = pc.chatLogDatabase.CreateChatLog(&msgFromMQ); err != nil {
+			attempts, err := pc.retry.Do(ctx, "CreateChatLog", func() error {
+				return pc.chatLogDatabase.CreateChatLog(&msgFromMQ)
+			})
+			if err != nil {
 				log.ZError(ctx, "Message insert failed", err, "msg", msgFromMQ.String())
+				pc.deadLetter.Send(ctx, kfk.NewConsumedDeadLetter(cMsg, config.Config.Kafka.ConsumerGroupID.MsgToMySql, attempts, err))
 				return
 			}
 		}
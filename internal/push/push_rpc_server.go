@@ -23,6 +23,7 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/localcache"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/pushext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 	"github.com/OpenIMSDK/protocol/constant"
 	pbPush "github.com/OpenIMSDK/protocol/push"
@@ -62,6 +63,9 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 		pbPush.RegisterPushMsgServiceServer(server, &pushServer{
 			pusher: pusher,
 		})
+		pushext.RegisterPushMsgExtServer(server, &pushServer{
+			pusher: pusher,
+		})
 	}()
 	go func() {
 		defer wg.Done()
@@ -99,3 +103,18 @@ func (r *pushServer) DelUserPushToken(
 	}
 	return &pbPush.DelUserPushTokenResp{}, nil
 }
+
+func (r *pushServer) OfflinePushUnacked(
+	ctx context.Context,
+	req *pushext.OfflinePushUnackedReq,
+) (resp *pushext.OfflinePushUnackedResp, err error) {
+	for _, msg := range req.Msgs {
+		if err := r.pusher.OfflinePushUnacked(ctx, req.UserID, msg); err != nil {
+			if err != errNoOfflinePusher {
+				return nil, err
+			}
+			log.ZWarn(ctx, "offline push failed", err, "userID", req.UserID, "msg", msg.String())
+		}
+	}
+	return &pushext.OfflinePushUnackedResp{}, nil
+}
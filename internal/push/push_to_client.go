@@ -37,6 +37,7 @@ import (
 	"github.com/OpenIMSDK/tools/log"
 	"github.com/OpenIMSDK/tools/mcontext"
 	"github.com/OpenIMSDK/tools/utils"
+	"google.golang.org/grpc"
 )
 
 type Pusher struct {
@@ -125,6 +126,22 @@ func (p *Pusher) Push2User(ctx context.Context, userIDs []string, msg *sdkws.Msg
 	return nil
 }
 
+// OfflinePushUnacked pushes msg offline to userID after the gateway got no ack for its online push,
+// the socket may have died right after the write.
+func (p *Pusher) OfflinePushUnacked(ctx context.Context, userID string, msg *sdkws.MsgData) error {
+	if !utils.GetSwitchFromOptions(msg.Options, constant.IsOfflinePush) || userID == msg.SendID {
+		return nil
+	}
+	if p.offlinePusher == nil {
+		return errNoOfflinePusher
+	}
+	offlinePushUserIDs := []string{userID}
+	if err := callbackOfflinePush(ctx, offlinePushUserIDs, msg, &offlinePushUserIDs); err != nil {
+		return err
+	}
+	return p.offlinePushMsg(ctx, msgprocessor.GetConversationIDByMsg(msg), msg, offlinePushUserIDs)
+}
+
 func (p *Pusher) UnmarshalNotificationElem(bytes []byte, t interface{}) error {
 	var notificationElem struct {
 		Detail string `json:"detail,omitempty"`
@@ -256,10 +273,18 @@ func (p *Pusher) GetConnsAndOnlinePush(ctx context.Context, msg *sdkws.MsgData,
 	if err != nil {
 		return nil, err
 	}
+	routes, ok := p.routeOnlinePush(ctx, conns, pushToUserIDs)
+	if !ok {
+		// route table missing or stale, broadcast to every gateway
+		routes = make(map[grpc.ClientConnInterface][]string, len(conns))
+		for _, v := range conns {
+			routes[v] = pushToUserIDs
+		}
+	}
 	// Online push message
-	for _, v := range conns {
+	for v, userIDs := range routes {
 		msgClient := msggateway.NewMsgGatewayClient(v)
-		reply, err := msgClient.SuperGroupOnlineBatchPushOneMsg(ctx, &msggateway.OnlineBatchPushOneMsgReq{MsgData: msg, PushToUserIDs: pushToUserIDs})
+		reply, err := msgClient.SuperGroupOnlineBatchPushOneMsg(ctx, &msggateway.OnlineBatchPushOneMsgReq{MsgData: msg, PushToUserIDs: userIDs})
 		if err != nil {
 			continue
 		}
@@ -271,6 +296,48 @@ func (p *Pusher) GetConnsAndOnlinePush(ctx context.Context, msg *sdkws.MsgData,
 	return wsResults, nil
 }
 
+// routeOnlinePush groups pushToUserIDs by the gateways holding their connections.
+// ok is false when the route table can not be trusted and the caller should broadcast instead.
+func (p *Pusher) routeOnlinePush(ctx context.Context, conns []grpc.ClientConnInterface, pushToUserIDs []string) (routes map[grpc.ClientConnInterface][]string, ok bool) {
+	if !config.Config.LongConnSvr.GatewayRoute.Enable {
+		return nil, false
+	}
+	addrConns := make(map[string]grpc.ClientConnInterface, len(conns))
+	for _, v := range conns {
+		cc, ok := v.(*grpc.ClientConn)
+		if !ok {
+			return nil, false
+		}
+		addrConns[cc.Target()] = v
+	}
+	aliveAddrs, err := p.database.GetAliveGateways(ctx, utils.Keys(addrConns))
+	if err != nil {
+		log.ZWarn(ctx, "GetAliveGateways failed, broadcast online push", err)
+		return nil, false
+	}
+	if len(aliveAddrs) != len(addrConns) {
+		// some gateways do not maintain the route table or stopped refreshing it
+		log.ZDebug(ctx, "gateway route table stale, broadcast online push", "alive", aliveAddrs, "gatewayNum", len(addrConns))
+		return nil, false
+	}
+	userAddrs, err := p.database.GetUserGatewayRoutes(ctx, pushToUserIDs)
+	if err != nil {
+		log.ZWarn(ctx, "GetUserGatewayRoutes failed, broadcast online push", err)
+		return nil, false
+	}
+	routes = make(map[grpc.ClientConnInterface][]string)
+	for userID, addrs := range userAddrs {
+		for _, addr := range addrs {
+			// the gateway has left the cluster, its entries expire on their own
+			if conn, ok := addrConns[addr]; ok {
+				routes[conn] = append(routes[conn], userID)
+			}
+		}
+	}
+	log.ZDebug(ctx, "route online push", "userNum", len(pushToUserIDs), "onlineUserNum", len(userAddrs), "gatewayNum", len(routes))
+	return routes, true
+}
+
 func (p *Pusher) offlinePushMsg(ctx context.Context, conversationID string, msg *sdkws.MsgData, offlinePushUserIDs []string) error {
 	title, content, opts, err := p.getOfflinePushInfos(conversationID, msg)
 	if err != nil {
@@ -0,0 +1,197 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/authext"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+func (s *authServer) CreateAppCredential(
+	ctx context.Context,
+	req *authext.CreateAppCredentialReq,
+) (*authext.CreateAppCredentialResp, error) {
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, errs.ErrArgs.Wrap("name is empty")
+	}
+	if len(req.Scopes) == 0 {
+		return nil, errs.ErrArgs.Wrap("scopes is empty")
+	}
+	for _, scope := range req.Scopes {
+		if !utils.IsContain(scope, relation.AppCredentialScopes) {
+			return nil, errs.ErrArgs.Wrap("unknown scope " + scope)
+		}
+	}
+	if req.OpUserID == "" {
+		if len(config.Config.Manager.UserID) == 0 {
+			return nil, errs.ErrArgs.Wrap("no app manager configured")
+		}
+		req.OpUserID = config.Config.Manager.UserID[0]
+	} else if !authverify.IsManagerUserID(req.OpUserID) {
+		return nil, errs.ErrArgs.Wrap("opUserID is not an app manager")
+	}
+	if req.PublicKey != "" {
+		if _, err := jwt.ParseRSAPublicKeyFromPEM([]byte(req.PublicKey)); err != nil {
+			if _, err := jwt.ParseEdPublicKeyFromPEM([]byte(req.PublicKey)); err != nil {
+				return nil, errs.ErrArgs.Wrap("publicKey is neither a rsa nor an ed25519 pem")
+			}
+		}
+	}
+	credential := &relation.AppCredentialModel{
+		Name:      req.Name,
+		PublicKey: req.PublicKey,
+		Scopes:    strings.Join(utils.Distinct(req.Scopes), ","),
+		OpUserID:  req.OpUserID,
+	}
+	secret, err := s.appCredentialDatabase.CreateCredential(ctx, credential)
+	if err != nil {
+		return nil, err
+	}
+	return &authext.CreateAppCredentialResp{ClientID: credential.ClientID, ClientSecret: secret}, nil
+}
+
+func (s *authServer) RevokeAppCredential(
+	ctx context.Context,
+	req *authext.RevokeAppCredentialReq,
+) (*authext.RevokeAppCredentialResp, error) {
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := s.appCredentialDatabase.TakeCredential(ctx, req.ClientID); err != nil {
+		return nil, err
+	}
+	if err := s.appCredentialDatabase.RevokeCredential(ctx, req.ClientID); err != nil {
+		return nil, err
+	}
+	return &authext.RevokeAppCredentialResp{}, nil
+}
+
+func (s *authServer) GetAppCredentials(
+	ctx context.Context,
+	req *authext.GetAppCredentialsReq,
+) (*authext.GetAppCredentialsResp, error) {
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	var pageNumber, showNumber int32
+	if req.Pagination != nil {
+		pageNumber = req.Pagination.PageNumber
+		showNumber = req.Pagination.ShowNumber
+	}
+	credentials, total, err := s.appCredentialDatabase.PageCredentials(ctx, pageNumber, showNumber)
+	if err != nil {
+		return nil, err
+	}
+	resp := &authext.GetAppCredentialsResp{Total: uint32(total)}
+	for _, credential := range credentials {
+		pb := &authext.AppCredential{
+			ClientID:   credential.ClientID,
+			Name:       credential.Name,
+			Scopes:     controller.SplitAppCredentialScopes(credential.Scopes),
+			OpUserID:   credential.OpUserID,
+			PublicKey:  credential.PublicKey,
+			Status:     credential.Status,
+			CreateTime: credential.CreateTime.UnixMilli(),
+		}
+		if credential.Status == relation.AppCredentialRevoked {
+			pb.RevokeTime = credential.RevokeTime.UnixMilli()
+		}
+		resp.Credentials = append(resp.Credentials, pb)
+	}
+	return resp, nil
+}
+
+func (s *authServer) VerifyAppCredential(
+	ctx context.Context,
+	req *authext.VerifyAppCredentialReq,
+) (*authext.VerifyAppCredentialResp, error) {
+	credential, err := s.appCredentialDatabase.VerifyCredential(
+		ctx,
+		req.ClientID,
+		req.ClientSecret,
+		req.ClientAssertion,
+		req.Scope,
+	)
+	result := "ok"
+	if err != nil {
+		result = errs.Unwrap(err).Error()
+		if len(result) > 255 {
+			result = result[:255]
+		}
+	}
+	auditLog := &relation.AppCredentialLogModel{
+		ClientID:    req.ClientID,
+		Scope:       req.Scope,
+		Path:        req.Path,
+		IP:          req.Ip,
+		OperationID: mcontext.GetOperationID(ctx),
+		Result:      result,
+		CreateTime:  time.Now(),
+	}
+	if err := s.appCredentialDatabase.CreateLog(ctx, auditLog); err != nil {
+		log.ZWarn(ctx, "create app credential log failed", err, "log", auditLog)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &authext.VerifyAppCredentialResp{OpUserID: credential.OpUserID}, nil
+}
+
+func (s *authServer) GetAppCredentialLogs(
+	ctx context.Context,
+	req *authext.GetAppCredentialLogsReq,
+) (*authext.GetAppCredentialLogsResp, error) {
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	var pageNumber, showNumber int32
+	if req.Pagination != nil {
+		pageNumber = req.Pagination.PageNumber
+		showNumber = req.Pagination.ShowNumber
+	}
+	logs, total, err := s.appCredentialDatabase.PageLogs(ctx, req.ClientID, pageNumber, showNumber)
+	if err != nil {
+		return nil, err
+	}
+	resp := &authext.GetAppCredentialLogsResp{Total: uint32(total)}
+	for _, l := range logs {
+		resp.Logs = append(resp.Logs, &authext.AppCredentialLog{
+			ClientID:    l.ClientID,
+			Scope:       l.Scope,
+			Path:        l.Path,
+			Ip:          l.IP,
+			OperationID: l.OperationID,
+			Result:      l.Result,
+			CreateTime:  l.CreateTime.UnixMilli(),
+		})
+	}
+	return resp, nil
+}
@@ -16,13 +16,18 @@ package auth
 
 import (
 	"context"
-	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"time"
 
 	"google.golang.org/grpc"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/relation"
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/loginpolicy"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/authext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 	pbAuth "github.com/OpenIMSDK/protocol/auth"
 	"github.com/OpenIMSDK/protocol/constant"
@@ -36,9 +41,10 @@ import (
 )
 
 type authServer struct {
-	authDatabase   controller.AuthDatabase
-	userRpcClient  *rpcclient.UserRpcClient
-	RegisterCenter discoveryregistry.SvcDiscoveryRegistry
+	authDatabase          controller.AuthDatabase
+	appCredentialDatabase controller.AppCredentialDatabase
+	userRpcClient         *rpcclient.UserRpcClient
+	RegisterCenter        discoveryregistry.SvcDiscoveryRegistry
 }
 
 func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) error {
@@ -46,38 +52,109 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 	if err != nil {
 		return err
 	}
+	if err := authverify.LoadSigningKeys(); err != nil {
+		return err
+	}
+	if _, err := loginpolicy.Default(); err != nil {
+		return err
+	}
+	db, err := relation.NewGormDB()
+	if err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&relationTb.AppCredentialModel{}, &relationTb.AppCredentialLogModel{}); err != nil {
+		return err
+	}
 	userRpcClient := rpcclient.NewUserRpcClient(client)
-	pbAuth.RegisterAuthServer(server, &authServer{
+	s := &authServer{
 		userRpcClient:  &userRpcClient,
 		RegisterCenter: client,
 		authDatabase: controller.NewAuthDatabase(
 			cache.NewMsgCacheModel(rdb),
-			config.Config.Secret,
-			config.Config.TokenPolicy.Expire,
+			authverify.AccessTokenExpire(),
+			authverify.RefreshTokenExpire(),
+		),
+		appCredentialDatabase: controller.NewAppCredentialDatabase(
+			relation.NewAppCredential(db),
+			relation.NewAppCredentialLog(db),
+			cache.NewMsgCacheModel(rdb),
 		),
-	})
+	}
+	pbAuth.RegisterAuthServer(server, s)
+	authext.RegisterAuthExtServer(server, s)
 	return nil
 }
 
 func (s *authServer) UserToken(ctx context.Context, req *pbAuth.UserTokenReq) (*pbAuth.UserTokenResp, error) {
 	resp := pbAuth.UserTokenResp{}
-	if req.Secret != config.Config.Secret {
+	if req.Secret != config.Config.Secret && !authverify.IsAppManagerUid(ctx) {
 		return nil, errs.ErrNoPermission.Wrap("secret invalid")
 	}
 	if _, err := s.userRpcClient.GetUserInfo(ctx, req.UserID); err != nil {
 		return nil, err
 	}
-	token, err := s.authDatabase.CreateToken(ctx, req.UserID, int(req.PlatformID))
+	login, err := s.authDatabase.CreateToken(ctx, req.UserID, int(req.PlatformID))
 	if err != nil {
 		return nil, err
 	}
-	resp.Token = token
-	resp.ExpireTimeSeconds = config.Config.TokenPolicy.Expire * 24 * 60 * 60
+	s.closeKickedSessions(ctx, req.UserID, req.PlatformID, login)
+	resp.Token = login.Token
+	resp.ExpireTimeSeconds = int64(authverify.AccessTokenExpire() / time.Second)
 	return &resp, nil
 }
 
+func (s *authServer) GetUserTokens(ctx context.Context, req *authext.GetUserTokensReq) (*authext.GetUserTokensResp, error) {
+	if req.Secret != config.Config.Secret && !authverify.IsAppManagerUid(ctx) {
+		return nil, errs.ErrNoPermission.Wrap("secret invalid")
+	}
+	if _, err := s.userRpcClient.GetUserInfo(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	login, err := s.authDatabase.CreateTokens(ctx, req.UserID, int(req.PlatformID))
+	if err != nil {
+		return nil, err
+	}
+	s.closeKickedSessions(ctx, req.UserID, req.PlatformID, login)
+	resp := &authext.GetUserTokensResp{
+		Token:             login.Token,
+		ExpireTimeSeconds: int64(authverify.AccessTokenExpire() / time.Second),
+		RefreshToken:      login.RefreshToken,
+	}
+	if login.RefreshToken != "" {
+		resp.RefreshExpireTimeSeconds = int64(authverify.RefreshTokenExpire() / time.Second)
+	}
+	return resp, nil
+}
+
+func (s *authServer) RefreshToken(ctx context.Context, req *authext.RefreshTokenReq) (*authext.RefreshTokenResp, error) {
+	if authverify.RefreshTokenExpire() <= 0 {
+		return nil, errs.ErrTokenInvalid.Wrap("refresh token disabled")
+	}
+	claims, err := authverify.VerifyRefreshToken(req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.userRpcClient.GetUserInfo(ctx, claims.UserID); err != nil {
+		return nil, err
+	}
+	token, refreshToken, err := s.authDatabase.RefreshToken(ctx, claims, req.RefreshToken)
+	if err != nil {
+		if errs.Unwrap(err) == controller.ErrRefreshTokenReused {
+			// 刷新token被重复使用, 只关闭已被吊销的token族的连接
+			s.closeInvalidConns(ctx, claims.UserID, int32(claims.PlatformID), "")
+		}
+		return nil, err
+	}
+	return &authext.RefreshTokenResp{
+		Token:                    token,
+		ExpireTimeSeconds:        int64(authverify.AccessTokenExpire() / time.Second),
+		RefreshToken:             refreshToken,
+		RefreshExpireTimeSeconds: int64(authverify.RefreshTokenExpire() / time.Second),
+	}, nil
+}
+
 func (s *authServer) parseToken(ctx context.Context, tokensString string) (claims *tokenverify.Claims, err error) {
-	claims, err = tokenverify.GetClaimFromToken(tokensString, authverify.Secret())
+	claims, err = authverify.VerifyAccessToken(tokensString)
 	if err != nil {
 		return nil, utils.Wrap(err, "")
 	}
@@ -89,14 +166,10 @@ func (s *authServer) parseToken(ctx context.Context, tokensString string) (claim
 		return nil, errs.ErrTokenNotExist.Wrap()
 	}
 	if v, ok := m[tokensString]; ok {
-		switch v {
-		case constant.NormalToken:
-			return claims, nil
-		case constant.KickedToken:
-			return nil, errs.ErrTokenKicked.Wrap()
-		default:
-			return nil, utils.Wrap(errs.ErrTokenUnknown, "")
+		if err := authverify.CheckTokenStatus(v); err != nil {
+			return nil, err
 		}
+		return claims, nil
 	}
 	return nil, errs.ErrTokenNotExist.Wrap()
 }
@@ -0,0 +1,189 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/authext"
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/msggateway"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+)
+
+func (s *authServer) GetSessions(ctx context.Context, req *authext.GetSessionsReq) (*authext.GetSessionsResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	sessions, err := s.authDatabase.GetSessions(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	onlineTokens := s.getOnlineTokens(ctx, req.UserID)
+	resp := &authext.GetSessionsResp{}
+	for _, session := range sessions {
+		_, current := session.Tokens[req.Token]
+		resp.Sessions = append(resp.Sessions, &authext.Session{
+			SessionID:    session.SessionID,
+			PlatformID:   int32(session.PlatformID),
+			Platform:     constant.PlatformIDToName(session.PlatformID),
+			LoginTime:    session.LoginTime,
+			LastSeenTime: session.LastSeenTime,
+			Ip:           session.IP,
+			Online:       isSessionOnline(session, onlineTokens),
+			Current:      current,
+		})
+	}
+	return resp, nil
+}
+
+func (s *authServer) RevokeSession(ctx context.Context, req *authext.RevokeSessionReq) (*authext.RevokeSessionResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	sessions, err := s.authDatabase.GetSessions(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if session.SessionID != req.SessionID {
+			continue
+		}
+		online := isSessionOnline(session, s.getOnlineTokens(ctx, req.UserID))
+		if err := s.authDatabase.KickSession(ctx, session); err != nil {
+			return nil, err
+		}
+		if online {
+			s.closeInvalidConns(ctx, req.UserID, int32(session.PlatformID), "")
+		}
+		return &authext.RevokeSessionResp{}, nil
+	}
+	return nil, errs.ErrRecordNotFound.Wrap("session not found")
+}
+
+// RevokeOtherSessions 吊销当前会话以外的所有会话(包括同平台的), 只关闭被吊销会话的连接.
+func (s *authServer) RevokeOtherSessions(
+	ctx context.Context,
+	req *authext.RevokeOtherSessionsReq,
+) (*authext.RevokeOtherSessionsResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	sessions, err := s.authDatabase.GetSessions(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	var current *controller.Session
+	for _, session := range sessions {
+		if _, ok := session.Tokens[req.Token]; ok {
+			current = session
+			break
+		}
+	}
+	if current == nil {
+		return nil, errs.ErrArgs.Wrap("token is not a session of the user")
+	}
+	onlineTokens := s.getOnlineTokens(ctx, req.UserID)
+	var (
+		revokedNum int32
+		online     bool
+	)
+	for _, session := range sessions {
+		if session == current {
+			continue
+		}
+		if err := s.authDatabase.KickSession(ctx, session); err != nil {
+			return nil, err
+		}
+		revokedNum++
+		if isSessionOnline(session, onlineTokens) {
+			online = true
+		}
+	}
+	// 网关按token状态关闭连接, 与平台无关, 通知一次即可
+	if online {
+		s.closeInvalidConns(ctx, req.UserID, int32(current.PlatformID), req.Token)
+	}
+	return &authext.RevokeOtherSessionsResp{RevokedNum: revokedNum}, nil
+}
+
+// closeKickedSessions 按多端登录策略被踢的会话可能在任意网关上有连接, 通知所有网关关闭.
+func (s *authServer) closeKickedSessions(ctx context.Context, userID string, platformID int32, login *controller.LoginTokens) {
+	if len(login.KickedSessions) == 0 {
+		return
+	}
+	s.closeInvalidConns(ctx, userID, platformID, login.Token)
+}
+
+// closeInvalidConns 通知所有网关关闭用户token已不可用(被踢或被吊销)的连接, 其他会话的连接不受影响, 失败只记录日志.
+func (s *authServer) closeInvalidConns(ctx context.Context, userID string, platformID int32, token string) {
+	conns, err := s.RegisterCenter.GetConns(ctx, config.Config.RpcRegisterName.OpenImMessageGatewayName)
+	if err != nil {
+		log.ZWarn(ctx, "get gateway conns failed", err)
+		return
+	}
+	req := &msggateway.MultiTerminalLoginCheckReq{
+		UserID:      userID,
+		PlatformID:  platformID,
+		Token:       token,
+		OperationID: mcontext.GetOperationID(ctx),
+	}
+	for _, v := range conns {
+		client := msggateway.NewMsgGatewayClient(v)
+		if _, err := client.MultiTerminalLoginCheck(ctx, req); err != nil {
+			log.ZWarn(ctx, "MultiTerminalLoginCheck failed", err, "gateway", v.(*grpc.ClientConn).Target())
+		}
+	}
+}
+
+// getOnlineTokens 用户在所有网关上的连接使用的token, 网关不可用时只记录日志.
+func (s *authServer) getOnlineTokens(ctx context.Context, userID string) map[string]struct{} {
+	tokens := make(map[string]struct{})
+	conns, err := s.RegisterCenter.GetConns(ctx, config.Config.RpcRegisterName.OpenImMessageGatewayName)
+	if err != nil {
+		log.ZWarn(ctx, "get gateway conns failed", err)
+		return tokens
+	}
+	for _, v := range conns {
+		client := msggateway.NewMsgGatewayClient(v)
+		resp, err := client.GetUsersOnlineStatus(ctx, &msggateway.GetUsersOnlineStatusReq{UserIDs: []string{userID}})
+		if err != nil {
+			log.ZWarn(ctx, "GetUsersOnlineStatus failed", err, "gateway", v.(*grpc.ClientConn).Target())
+			continue
+		}
+		for _, result := range resp.SuccessResult {
+			for _, detail := range result.DetailPlatformStatus {
+				tokens[detail.Token] = struct{}{}
+			}
+		}
+	}
+	return tokens
+}
+
+func isSessionOnline(session *controller.Session, onlineTokens map[string]struct{}) bool {
+	for token := range session.Tokens {
+		if _, ok := onlineTokens[token]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -71,6 +71,13 @@ func callbackBeforeSendSingleMsg(ctx context.Context, msg *pbChat.SendMsgReq) er
 	if !config.Config.Callback.CallbackBeforeSendSingleMsg.Enable {
 		return nil
 	}
+	if config.Config.Callback.CallbackBeforeSendSingleMsg.Transport == callbackTransportGrpc {
+		_, err := callbackBeforeSendGrpc(ctx, constant.CallbackBeforeSendSingleMsgCommand, msg.MsgData, config.Config.Callback.CallbackBeforeSendSingleMsg)
+		if err == errs.ErrCallbackContinue {
+			return nil
+		}
+		return err
+	}
 	req := &cbapi.CallbackBeforeSendSingleMsgReq{
 		CommonCallbackReq: toCommonCallback(ctx, msg, constant.CallbackBeforeSendSingleMsgCommand),
 		RecvID:            msg.MsgData.RecvID,
@@ -104,9 +111,16 @@ func callbackAfterSendSingleMsg(ctx context.Context, msg *pbChat.SendMsgReq) err
 }
 
 func callbackBeforeSendGroupMsg(ctx context.Context, msg *pbChat.SendMsgReq) error {
-	if !config.Config.Callback.CallbackAfterSendSingleMsg.Enable {
+	if !config.Config.Callback.CallbackBeforeSendGroupMsg.Enable {
 		return nil
 	}
+	if config.Config.Callback.CallbackBeforeSendGroupMsg.Transport == callbackTransportGrpc {
+		_, err := callbackBeforeSendGrpc(ctx, constant.CallbackBeforeSendGroupMsgCommand, msg.MsgData, config.Config.Callback.CallbackBeforeSendGroupMsg)
+		if err == errs.ErrCallbackContinue {
+			return nil
+		}
+		return err
+	}
 	req := &cbapi.CallbackAfterSendGroupMsgReq{
 		CommonCallbackReq: toCommonCallback(ctx, msg, constant.CallbackBeforeSendGroupMsgCommand),
 		GroupID:           msg.MsgData.GroupID,
@@ -143,6 +157,20 @@ func callbackMsgModify(ctx context.Context, msg *pbChat.SendMsgReq) error {
 	if !config.Config.Callback.CallbackMsgModify.Enable || msg.MsgData.ContentType != constant.Text {
 		return nil
 	}
+	if config.Config.Callback.CallbackMsgModify.Transport == callbackTransportGrpc {
+		resp, err := callbackBeforeSendGrpc(ctx, constant.CallbackMsgModifyCommand, msg.MsgData, config.Config.Callback.CallbackMsgModify)
+		if err != nil {
+			if err == errs.ErrCallbackContinue {
+				return nil
+			}
+			return err
+		}
+		if resp.MsgData != nil {
+			modifyMsgData(msg.MsgData, resp.MsgData)
+		}
+		log.ZDebug(ctx, "callbackMsgModify", "msg", msg.MsgData)
+		return nil
+	}
 	req := &cbapi.CallbackMsgModifyCommandReq{
 		CommonCallbackReq: toCommonCallback(ctx, msg, constant.CallbackMsgModifyCommand),
 	}
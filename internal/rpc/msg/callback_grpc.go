@@ -0,0 +1,144 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	cbapi "github.com/OpenIMSDK/Open-IM-Server/pkg/callbackstruct"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/kafka"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/callbackext"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+)
+
+const callbackTransportGrpc = "grpc"
+
+// grpcAddr -> *grpc.ClientConn, kept for the life of the process
+var callbackConns sync.Map
+
+func callbackGrpcConn(addr string) (*grpc.ClientConn, error) {
+	if conn, ok := callbackConns.Load(addr); ok {
+		return conn.(*grpc.ClientConn), nil
+	}
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	if actual, loaded := callbackConns.LoadOrStore(addr, conn); loaded {
+		conn.Close()
+		return actual.(*grpc.ClientConn), nil
+	}
+	return conn, nil
+}
+
+// callbackBeforeSendGrpc is the grpc transport of the before send callbacks, it returns errs.ErrCallbackContinue
+// like http.CallBackPostReturn when the call failed and failedContinue is set.
+func callbackBeforeSendGrpc(
+	ctx context.Context,
+	command string,
+	msgData *sdkws.MsgData,
+	callbackConfig config.CallBackConfig,
+) (*callbackext.BeforeSendMsgResp, error) {
+	conn, err := callbackGrpcConn(callbackConfig.GrpcAddr)
+	if err != nil {
+		return nil, errs.ErrNetwork.Wrap(err.Error())
+	}
+	client := callbackext.NewMsgCallbackClient(conn)
+	req := &callbackext.BeforeSendMsgReq{
+		Command:     command,
+		OperationID: mcontext.GetOperationID(ctx),
+		MsgData:     msgData,
+	}
+	var resp *callbackext.BeforeSendMsgResp
+	_, err = kafka.NewRetryPolicy(callbackConfig.Retry).Do(ctx, "callback "+command, func() error {
+		callCtx := ctx
+		if callbackConfig.CallbackTimeOut > 0 {
+			var cancel func()
+			callCtx, cancel = context.WithTimeout(ctx, time.Second*time.Duration(callbackConfig.CallbackTimeOut))
+			defer cancel()
+		}
+		var err error
+		resp, err = client.BeforeSendMsg(callCtx, req)
+		return err
+	})
+	if err != nil {
+		if callbackConfig.CallbackFailedContinue != nil && *callbackConfig.CallbackFailedContinue {
+			log.ZWarn(ctx, "callback failed but continue", err, "grpcAddr", callbackConfig.GrpcAddr)
+			return nil, errs.ErrCallbackContinue
+		}
+		return nil, errs.ErrNetwork.Wrap(err.Error())
+	}
+	commonResp := cbapi.CommonCallbackResp{
+		ActionCode: int(resp.ActionCode),
+		ErrCode:    resp.ErrCode,
+		ErrMsg:     resp.ErrMsg,
+		ErrDlt:     resp.ErrDlt,
+	}
+	if err := commonResp.Parse(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// modifyMsgData copies the fields a msgModify callback may change, like utils.NotNilReplace on the http
+// transport only the fields the callback sets (non-zero in protobuf) replace the message's.
+func modifyMsgData(msgData, modified *sdkws.MsgData) {
+	if len(modified.Content) != 0 {
+		msgData.Content = modified.Content
+	}
+	replaceString(&msgData.RecvID, modified.RecvID)
+	replaceString(&msgData.GroupID, modified.GroupID)
+	replaceString(&msgData.ClientMsgID, modified.ClientMsgID)
+	replaceString(&msgData.ServerMsgID, modified.ServerMsgID)
+	replaceInt32(&msgData.SenderPlatformID, modified.SenderPlatformID)
+	replaceString(&msgData.SenderNickname, modified.SenderNickname)
+	replaceString(&msgData.SenderFaceURL, modified.SenderFaceURL)
+	replaceInt32(&msgData.SessionType, modified.SessionType)
+	replaceInt32(&msgData.MsgFrom, modified.MsgFrom)
+	replaceInt32(&msgData.ContentType, modified.ContentType)
+	replaceInt32(&msgData.Status, modified.Status)
+	if len(modified.Options) != 0 {
+		msgData.Options = modified.Options
+	}
+	if modified.OfflinePushInfo != nil {
+		msgData.OfflinePushInfo = modified.OfflinePushInfo
+	}
+	if len(modified.AtUserIDList) != 0 {
+		msgData.AtUserIDList = modified.AtUserIDList
+	}
+	replaceString(&msgData.AttachedInfo, modified.AttachedInfo)
+	replaceString(&msgData.Ex, modified.Ex)
+}
+
+func replaceString(dst *string, src string) {
+	if src != "" {
+		*dst = src
+	}
+}
+
+func replaceInt32(dst *int32, src int32) {
+	if src != 0 {
+		*dst = src
+	}
+}
@@ -81,6 +81,9 @@ func (m *msgServer) DeleteMsgs(ctx context.Context, req *msg.DeleteMsgsReq) (*ms
 		if err := m.MsgDatabase.DeleteMsgsPhysicalBySeqs(ctx, req.ConversationID, req.Seqs); err != nil {
 			return nil, err
 		}
+		if err := m.MsgSearchDatabase.DeleteMsgs(ctx, req.ConversationID, req.Seqs); err != nil {
+			log.ZWarn(ctx, "delete msgs from search index failed", err, "conversationID", req.ConversationID, "seqs", req.Seqs)
+		}
 		conversations, err := m.Conversation.GetConversationsByConversationID(ctx, []string{req.ConversationID})
 		if err != nil {
 			return nil, err
@@ -98,6 +101,9 @@ func (m *msgServer) DeleteMsgs(ctx context.Context, req *msg.DeleteMsgsReq) (*ms
 		if err := m.MsgDatabase.DeleteUserMsgsBySeqs(ctx, req.UserID, req.ConversationID, req.Seqs); err != nil {
 			return nil, err
 		}
+		if err := m.MsgSearchDatabase.DeleteUserMsgs(ctx, req.UserID, req.ConversationID, req.Seqs); err != nil {
+			log.ZWarn(ctx, "delete user msgs from search index failed", err, "userID", req.UserID, "conversationID", req.ConversationID, "seqs", req.Seqs)
+		}
 		if isSyncSelf {
 			tips := &sdkws.DeleteMsgsTips{UserID: req.UserID, ConversationID: req.ConversationID, Seqs: req.Seqs}
 			m.notificationSender.NotificationWithSesstionType(ctx, req.UserID, req.UserID, constant.DeleteMsgsNotification, constant.SingleChatType, tips)
@@ -114,6 +120,9 @@ func (m *msgServer) DeleteMsgPhysicalBySeq(
 	if err != nil {
 		return nil, err
 	}
+	if err := m.MsgSearchDatabase.DeleteMsgs(ctx, req.ConversationID, req.Seqs); err != nil {
+		log.ZWarn(ctx, "delete msgs from search index failed", err, "conversationID", req.ConversationID, "seqs", req.Seqs)
+	}
 	return &msg.DeleteMsgPhysicalBySeqResp{}, nil
 }
 
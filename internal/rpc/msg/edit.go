@@ -0,0 +1,107 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+)
+
+func (m *msgServer) EditMsg(ctx context.Context, req *msgext.EditMsgReq) (*msgext.EditMsgResp, error) {
+	defer log.ZDebug(ctx, "EditMsg return line")
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.Wrap("user_id is empty")
+	}
+	if req.ConversationID == "" {
+		return nil, errs.ErrArgs.Wrap("conversation_id is empty")
+	}
+	if req.Seq <= 0 {
+		return nil, errs.ErrArgs.Wrap("seq is invalid")
+	}
+	if req.Content == "" {
+		return nil, errs.ErrArgs.Wrap("content is empty")
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	user, err := m.User.GetUserInfo(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	_, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, req.UserID, req.ConversationID, []int64{req.Seq})
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 || msgs[0] == nil || msgs[0].Status == constant.MsgDeleted {
+		return nil, errs.ErrRecordNotFound.Wrap("msg not found")
+	}
+	if msgs[0].ContentType == constant.MsgRevokeNotification {
+		return nil, errs.ErrMsgAlreadyRevoke.Wrap("msg already revoke")
+	}
+	if msgs[0].ContentType >= constant.NotificationBegin && msgs[0].ContentType <= constant.NotificationEnd {
+		return nil, errs.ErrArgs.Wrap("notification can not be edited")
+	}
+	role, err := m.msgOperatorRole(ctx, req.UserID, user.AppMangerLevel, msgs[0])
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UnixMilli()
+	edited := proto.Clone(msgs[0]).(*sdkws.MsgData)
+	edited.Content = []byte(req.Content)
+	err = m.MsgDatabase.EditMsg(ctx, req.ConversationID, edited, &unRelationTb.EditModel{
+		UserID:      req.UserID,
+		Nickname:    user.Nickname,
+		Role:        role,
+		Time:        now,
+		ContentType: msgs[0].ContentType,
+		Content:     string(msgs[0].Content),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := m.MsgSearchDatabase.IndexMsgs(ctx, req.ConversationID, []*sdkws.MsgData{edited}); err != nil {
+		log.ZWarn(ctx, "index edited msg failed", err, "conversationID", req.ConversationID, "seq", req.Seq)
+	}
+	tips := msgext.MsgEditTips{
+		EditorUserID:   req.UserID,
+		ClientMsgID:    msgs[0].ClientMsgID,
+		EditTime:       now,
+		Seq:            req.Seq,
+		SessionType:    msgs[0].SessionType,
+		ConversationID: req.ConversationID,
+		Content:        req.Content,
+	}
+	var recvID string
+	if msgs[0].SessionType == constant.SuperGroupChatType {
+		recvID = msgs[0].GroupID
+	} else {
+		recvID = msgs[0].RecvID
+	}
+	if err := m.notificationSender.NotificationWithSesstionType(ctx, req.UserID, recvID, msgprocessor.MsgEditNotification, msgs[0].SessionType, &tips); err != nil {
+		return nil, err
+	}
+	return &msgext.EditMsgResp{}, nil
+}
@@ -0,0 +1,203 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+func (m *msgServer) PinMsg(ctx context.Context, req *msgext.PinMsgReq) (*msgext.PinMsgResp, error) {
+	if err := checkPinReq(req.UserID, req.ConversationID, req.Seq); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	_, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, req.UserID, req.ConversationID, []int64{req.Seq})
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 || msgs[0] == nil || msgs[0].ClientMsgID == "" || msgs[0].Status == constant.MsgDeleted {
+		return nil, errs.ErrRecordNotFound.Wrap("msg not found")
+	}
+	if msgs[0].ContentType == constant.MsgRevokeNotification {
+		return nil, errs.ErrMsgAlreadyRevoke.Wrap("msg already revoke")
+	}
+	role, err := m.pinOperatorRole(ctx, req.UserID, msgs[0])
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UnixMilli()
+	pinned, err := m.MsgPinDatabase.PinMsg(ctx, &unRelationTb.MsgPinModel{
+		ConversationID: req.ConversationID,
+		Seq:            req.Seq,
+		ClientMsgID:    msgs[0].ClientMsgID,
+		SessionType:    msgs[0].SessionType,
+		PinnedBy:       req.UserID,
+		Role:           role,
+		PinTime:        now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pinned {
+		if err := m.pinNotification(ctx, req.UserID, req.ConversationID, msgs[0], false, now); err != nil {
+			return nil, err
+		}
+	}
+	return &msgext.PinMsgResp{}, nil
+}
+
+func (m *msgServer) UnpinMsg(ctx context.Context, req *msgext.UnpinMsgReq) (*msgext.UnpinMsgResp, error) {
+	if err := checkPinReq(req.UserID, req.ConversationID, req.Seq); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	_, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, req.UserID, req.ConversationID, []int64{req.Seq})
+	if err != nil {
+		return nil, err
+	}
+	exist := len(msgs) > 0 && msgs[0] != nil && msgs[0].ClientMsgID != ""
+	if exist {
+		if _, err := m.pinOperatorRole(ctx, req.UserID, msgs[0]); err != nil {
+			return nil, err
+		}
+	} else if err := m.checkConversationMember(ctx, req.UserID, req.ConversationID); err != nil {
+		// 消息已被清除, 会话成员都可以取消置顶
+		return nil, err
+	}
+	unpinned, err := m.MsgPinDatabase.UnpinMsg(ctx, req.ConversationID, req.Seq)
+	if err != nil {
+		return nil, err
+	}
+	if unpinned && exist {
+		if err := m.pinNotification(ctx, req.UserID, req.ConversationID, msgs[0], true, time.Now().UnixMilli()); err != nil {
+			return nil, err
+		}
+	}
+	return &msgext.UnpinMsgResp{}, nil
+}
+
+func (m *msgServer) GetPinnedMsgs(ctx context.Context, req *msgext.GetPinnedMsgsReq) (*msgext.GetPinnedMsgsResp, error) {
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.Wrap("user_id is empty")
+	}
+	if req.ConversationID == "" {
+		return nil, errs.ErrArgs.Wrap("conversation_id is empty")
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if err := m.checkConversationMember(ctx, req.UserID, req.ConversationID); err != nil {
+		return nil, err
+	}
+	pins, err := m.MsgPinDatabase.GetPinnedMsgs(ctx, req.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &msgext.GetPinnedMsgsResp{}
+	if len(pins) == 0 {
+		return resp, nil
+	}
+	seqs := utils.Slice(pins, func(pin *unRelationTb.MsgPinModel) int64 { return pin.Seq })
+	_, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, req.UserID, req.ConversationID, seqs)
+	if err != nil {
+		return nil, err
+	}
+	msgMap := make(map[int64]*sdkws.MsgData)
+	for _, msg := range msgs {
+		if msg != nil && msg.ClientMsgID != "" && msg.Status != constant.MsgDeleted {
+			msgMap[msg.Seq] = msg
+		}
+	}
+	for _, pin := range pins {
+		msg, ok := msgMap[pin.Seq]
+		if !ok {
+			continue
+		}
+		resp.Msgs = append(resp.Msgs, &msgext.PinnedMsg{
+			Seq:      pin.Seq,
+			PinnedBy: pin.PinnedBy,
+			PinTime:  pin.PinTime,
+			MsgData:  msg,
+		})
+	}
+	return resp, nil
+}
+
+func checkPinReq(userID, conversationID string, seq int64) error {
+	if userID == "" {
+		return errs.ErrArgs.Wrap("user_id is empty")
+	}
+	if conversationID == "" {
+		return errs.ErrArgs.Wrap("conversation_id is empty")
+	}
+	if seq <= 0 {
+		return errs.ErrArgs.Wrap("seq is invalid")
+	}
+	return nil
+}
+
+// pinOperatorRole checks that userID may pin msgData the same way as RevokeMsg, the receiver of a single chat msg
+// may pin it too. The returned role is stored with the pin.
+func (m *msgServer) pinOperatorRole(ctx context.Context, userID string, msgData *sdkws.MsgData) (int32, error) {
+	if msgData.SessionType == constant.SingleChatType && userID == msgData.RecvID {
+		return 0, nil
+	}
+	user, err := m.User.GetUserInfo(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return m.msgOperatorRole(ctx, userID, user.AppMangerLevel, msgData)
+}
+
+// checkConversationMember checks that userID is in conversationID.
+func (m *msgServer) checkConversationMember(ctx context.Context, userID string, conversationID string) error {
+	if groupID, ok := msgprocessor.ParseSuperGroupConversationID(conversationID); ok {
+		return m.checkGroupMember(ctx, userID, groupID)
+	}
+	if authverify.IsAppManagerUid(ctx) {
+		return nil
+	}
+	if _, err := m.Conversation.GetConversation(ctx, userID, conversationID); err != nil {
+		return errs.ErrNoPermission.Wrap("not in the conversation")
+	}
+	return nil
+}
+
+func (m *msgServer) pinNotification(ctx context.Context, userID, conversationID string, msgData *sdkws.MsgData, unpinned bool, now int64) error {
+	tips := msgext.MsgPinTips{
+		OperatorUserID: userID,
+		ClientMsgID:    msgData.ClientMsgID,
+		Seq:            msgData.Seq,
+		SessionType:    msgData.SessionType,
+		ConversationID: conversationID,
+		Unpinned:       unpinned,
+		Time:           now,
+	}
+	return m.notificationSender.NotificationWithSesstionType(ctx, userID, notificationRecvID(userID, msgData), msgprocessor.MsgPinNotification, msgData.SessionType, &tips)
+}
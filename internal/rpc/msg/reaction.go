@@ -0,0 +1,212 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+const (
+	maxEmojiLen         = 64
+	maxGetReactionsSeqs = 100
+)
+
+func (m *msgServer) AddReaction(ctx context.Context, req *msgext.AddReactionReq) (*msgext.AddReactionResp, error) {
+	msgData, err := m.getReactionMsg(ctx, req.UserID, req.ConversationID, req.Seq, req.Emoji)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UnixMilli()
+	added, err := m.MsgDatabase.AddMsgReaction(ctx, req.ConversationID, msgData, &unRelationTb.ReactionModel{
+		Emoji:  req.Emoji,
+		UserID: req.UserID,
+		Time:   now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if added {
+		if err := m.reactionNotification(ctx, req.UserID, req.ConversationID, msgData, req.Emoji, false, now); err != nil {
+			return nil, err
+		}
+	}
+	return &msgext.AddReactionResp{}, nil
+}
+
+func (m *msgServer) RemoveReaction(ctx context.Context, req *msgext.RemoveReactionReq) (*msgext.RemoveReactionResp, error) {
+	msgData, err := m.getReactionMsg(ctx, req.UserID, req.ConversationID, req.Seq, req.Emoji)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := m.MsgDatabase.RemoveMsgReaction(ctx, req.ConversationID, msgData, req.Emoji, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if removed {
+		if err := m.reactionNotification(ctx, req.UserID, req.ConversationID, msgData, req.Emoji, true, time.Now().UnixMilli()); err != nil {
+			return nil, err
+		}
+	}
+	return &msgext.RemoveReactionResp{}, nil
+}
+
+func (m *msgServer) GetReactions(ctx context.Context, req *msgext.GetReactionsReq) (*msgext.GetReactionsResp, error) {
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.Wrap("user_id is empty")
+	}
+	if req.ConversationID == "" {
+		return nil, errs.ErrArgs.Wrap("conversation_id is empty")
+	}
+	if len(req.Seqs) == 0 || len(req.Seqs) > maxGetReactionsSeqs {
+		return nil, errs.ErrArgs.Wrap("seqs is empty or too many")
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	_, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, req.UserID, req.ConversationID, utils.Distinct(req.Seqs))
+	if err != nil {
+		return nil, err
+	}
+	resp := &msgext.GetReactionsResp{}
+	checked := false
+	for _, msgData := range msgs {
+		if msgData == nil || msgData.ClientMsgID == "" || msgData.Status == constant.MsgDeleted {
+			continue
+		}
+		if !checked {
+			// 同一会话, 检查一次即可
+			if err := m.checkMsgMember(ctx, req.UserID, msgData); err != nil {
+				return nil, err
+			}
+			checked = true
+		}
+		reactions, err := m.MsgDatabase.GetMsgReactions(ctx, req.ConversationID, msgData)
+		if err != nil {
+			return nil, err
+		}
+		msgReactions := &msgext.MsgReactions{Seq: msgData.Seq}
+		for emoji, userIDs := range reactions {
+			msgReactions.Reactions = append(msgReactions.Reactions, &msgext.ReactionCount{
+				Emoji:       emoji,
+				Count:       int64(len(userIDs)),
+				ReactedByMe: utils.IsContain(req.UserID, userIDs),
+			})
+		}
+		sort.Slice(msgReactions.Reactions, func(i, j int) bool {
+			if msgReactions.Reactions[i].Count != msgReactions.Reactions[j].Count {
+				return msgReactions.Reactions[i].Count > msgReactions.Reactions[j].Count
+			}
+			return msgReactions.Reactions[i].Emoji < msgReactions.Reactions[j].Emoji
+		})
+		resp.Msgs = append(resp.Msgs, msgReactions)
+	}
+	return resp, nil
+}
+
+// getReactionMsg checks a reaction request and returns the msg it is about.
+func (m *msgServer) getReactionMsg(ctx context.Context, userID, conversationID string, seq int64, emoji string) (*sdkws.MsgData, error) {
+	if userID == "" {
+		return nil, errs.ErrArgs.Wrap("user_id is empty")
+	}
+	if conversationID == "" {
+		return nil, errs.ErrArgs.Wrap("conversation_id is empty")
+	}
+	if seq <= 0 {
+		return nil, errs.ErrArgs.Wrap("seq is invalid")
+	}
+	if emoji == "" || len(emoji) > maxEmojiLen {
+		return nil, errs.ErrArgs.Wrap("emoji is empty or too long")
+	}
+	if err := authverify.CheckAccessV3(ctx, userID); err != nil {
+		return nil, err
+	}
+	_, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, userID, conversationID, []int64{seq})
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 || msgs[0] == nil || msgs[0].ClientMsgID == "" || msgs[0].Status == constant.MsgDeleted {
+		return nil, errs.ErrRecordNotFound.Wrap("msg not found")
+	}
+	if msgs[0].ContentType == constant.MsgRevokeNotification {
+		return nil, errs.ErrMsgAlreadyRevoke.Wrap("msg already revoke")
+	}
+	if err := m.checkMsgMember(ctx, userID, msgs[0]); err != nil {
+		return nil, err
+	}
+	return msgs[0], nil
+}
+
+// checkMsgMember checks that userID is in the conversation of msgData.
+func (m *msgServer) checkMsgMember(ctx context.Context, userID string, msgData *sdkws.MsgData) error {
+	if authverify.IsAppManagerUid(ctx) {
+		return nil
+	}
+	switch msgData.SessionType {
+	case constant.SingleChatType:
+		if userID != msgData.SendID && userID != msgData.RecvID {
+			return errs.ErrNoPermission.Wrap("not in the conversation")
+		}
+		return nil
+	case constant.SuperGroupChatType:
+		return m.checkGroupMember(ctx, userID, msgData.GroupID)
+	default:
+		return errs.ErrNoPermission.Wrap("msg sessionType not supported")
+	}
+}
+
+func (m *msgServer) checkGroupMember(ctx context.Context, userID string, groupID string) error {
+	if authverify.IsAppManagerUid(ctx) {
+		return nil
+	}
+	_, err := m.Group.GetGroupMemberInfoMap(ctx, groupID, []string{userID}, true)
+	return err
+}
+
+func (m *msgServer) reactionNotification(ctx context.Context, userID, conversationID string, msgData *sdkws.MsgData, emoji string, removed bool, now int64) error {
+	tips := msgext.ReactionTips{
+		UserID:         userID,
+		ClientMsgID:    msgData.ClientMsgID,
+		Seq:            msgData.Seq,
+		SessionType:    msgData.SessionType,
+		ConversationID: conversationID,
+		Emoji:          emoji,
+		Removed:        removed,
+		Time:           now,
+	}
+	return m.notificationSender.NotificationWithSesstionType(ctx, userID, notificationRecvID(userID, msgData), msgprocessor.MsgReactionNotification, msgData.SessionType, &tips)
+}
+
+// notificationRecvID the group of msgData, or the other side of the single chat of userID.
+func notificationRecvID(userID string, msgData *sdkws.MsgData) string {
+	switch {
+	case msgData.SessionType == constant.SuperGroupChatType:
+		return msgData.GroupID
+	case userID == msgData.SendID:
+		return msgData.RecvID
+	default:
+		return msgData.SendID
+	}
+}
@@ -20,7 +20,9 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
 	"time"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
 	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
 	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/protocol/msg"
 	"github.com/OpenIMSDK/protocol/sdkws"
@@ -57,45 +59,34 @@ func (m *msgServer) RevokeMsg(ctx context.Context, req *msg.RevokeMsgReq) (*msg.
 	if msgs[0].ContentType == constant.MsgRevokeNotification {
 		return nil, errs.ErrMsgAlreadyRevoke.Wrap("msg already revoke")
 	}
+	now := time.Now().UnixMilli()
+	if err := checkRevokeMaxAge(ctx, msgs[0], now); err != nil {
+		return nil, err
+	}
 	data, _ := json.Marshal(msgs[0])
 	log.ZInfo(ctx, "GetMsgBySeqs", "conversationID", req.ConversationID, "seq", req.Seq, "msg", string(data))
-	var role int32
-	if !authverify.IsAppManagerUid(ctx) {
-		switch msgs[0].SessionType {
-		case constant.SingleChatType:
-			if err := authverify.CheckAccessV3(ctx, msgs[0].SendID); err != nil {
-				return nil, err
-			}
-			role = user.AppMangerLevel
-		case constant.SuperGroupChatType:
-			members, err := m.Group.GetGroupMemberInfoMap(
-				ctx,
-				msgs[0].GroupID,
-				utils.Distinct([]string{req.UserID, msgs[0].SendID}),
-				true,
-			)
-			if err != nil {
-				return nil, err
-			}
-			if req.UserID != msgs[0].SendID {
-				switch members[req.UserID].RoleLevel {
-				case constant.GroupOwner:
-				case constant.GroupAdmin:
-					if members[msgs[0].SendID].RoleLevel != constant.GroupOrdinaryUsers {
-						return nil, errs.ErrNoPermission.Wrap("no permission")
-					}
-				default:
-					return nil, errs.ErrNoPermission.Wrap("no permission")
-				}
-			}
-			if member := members[req.UserID]; member != nil {
-				role = member.RoleLevel
-			}
-		default:
-			return nil, errs.ErrInternalServer.Wrap("msg sessionType not supported")
-		}
+	role, err := m.msgOperatorRole(ctx, req.UserID, user.AppMangerLevel, msgs[0])
+	if err != nil {
+		return nil, err
+	}
+	// 先记录审计再撤回, 审计按会话和seq覆盖写入, 撤回失败后重试不会产生重复记录, 也不会有撤回没有审计
+	err = m.RevokeAuditDatabase.CreateRevokeAudit(ctx, &unRelationTb.MsgRevokeAuditModel{
+		ConversationID:  req.ConversationID,
+		Seq:             req.Seq,
+		ClientMsgID:     msgs[0].ClientMsgID,
+		SessionType:     msgs[0].SessionType,
+		SendID:          msgs[0].SendID,
+		SendTime:        msgs[0].SendTime,
+		ContentType:     msgs[0].ContentType,
+		Content:         string(msgs[0].Content),
+		RevokerUserID:   req.UserID,
+		RevokerRole:     role,
+		RevokerNickname: user.Nickname,
+		RevokeTime:      now,
+	})
+	if err != nil {
+		return nil, err
 	}
-	now := time.Now().UnixMilli()
 	err = m.MsgDatabase.RevokeMsg(ctx, req.ConversationID, req.Seq, &unRelationTb.RevokeModel{
 		Role:     role,
 		UserID:   req.UserID,
@@ -105,6 +96,9 @@ func (m *msgServer) RevokeMsg(ctx context.Context, req *msg.RevokeMsgReq) (*msg.
 	if err != nil {
 		return nil, err
 	}
+	if err := m.MsgSearchDatabase.DeleteMsgs(ctx, req.ConversationID, []int64{req.Seq}); err != nil {
+		log.ZWarn(ctx, "delete revoked msg from search index failed", err, "conversationID", req.ConversationID, "seq", req.Seq)
+	}
 	tips := sdkws.RevokeMsgTips{
 		RevokerUserID:  req.UserID,
 		ClientMsgID:    msgs[0].ClientMsgID,
@@ -124,3 +118,96 @@ func (m *msgServer) RevokeMsg(ctx context.Context, req *msg.RevokeMsgReq) (*msg.
 	}
 	return &msg.RevokeMsgResp{}, nil
 }
+
+// msgOperatorRole checks that userID may revoke or edit msgData: its sender, or an owner or admin of the group
+// ranked above the sender. The returned role is stored with the change.
+func (m *msgServer) msgOperatorRole(ctx context.Context, userID string, appMangerLevel int32, msgData *sdkws.MsgData) (int32, error) {
+	if authverify.IsAppManagerUid(ctx) {
+		return 0, nil
+	}
+	switch msgData.SessionType {
+	case constant.SingleChatType:
+		if err := authverify.CheckAccessV3(ctx, msgData.SendID); err != nil {
+			return 0, err
+		}
+		return appMangerLevel, nil
+	case constant.SuperGroupChatType:
+		members, err := m.Group.GetGroupMemberInfoMap(
+			ctx,
+			msgData.GroupID,
+			utils.Distinct([]string{userID, msgData.SendID}),
+			true,
+		)
+		if err != nil {
+			return 0, err
+		}
+		if userID != msgData.SendID {
+			switch members[userID].RoleLevel {
+			case constant.GroupOwner:
+			case constant.GroupAdmin:
+				if members[msgData.SendID].RoleLevel != constant.GroupOrdinaryUsers {
+					return 0, errs.ErrNoPermission.Wrap("no permission")
+				}
+			default:
+				return 0, errs.ErrNoPermission.Wrap("no permission")
+			}
+		}
+		var role int32
+		if member := members[userID]; member != nil {
+			role = member.RoleLevel
+		}
+		return role, nil
+	default:
+		return 0, errs.ErrInternalServer.Wrap("msg sessionType not supported")
+	}
+}
+
+// checkRevokeMaxAge checks the revoke time window of the session type, app managers are not limited.
+func checkRevokeMaxAge(ctx context.Context, msgData *sdkws.MsgData, now int64) error {
+	if authverify.IsAppManagerUid(ctx) {
+		return nil
+	}
+	var maxAge int64
+	switch msgData.SessionType {
+	case constant.SingleChatType:
+		maxAge = config.Config.MsgRevoke.SingleChatMaxAge
+	case constant.GroupChatType, constant.SuperGroupChatType:
+		maxAge = config.Config.MsgRevoke.GroupChatMaxAge
+	}
+	if maxAge > 0 && now-msgData.SendTime > maxAge*1000 {
+		return errs.ErrNoPermission.Wrap("msg is too old to revoke")
+	}
+	return nil
+}
+
+func (m *msgServer) GetRevokeAudits(ctx context.Context, req *msgext.GetRevokeAuditsReq) (*msgext.GetRevokeAuditsResp, error) {
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.StartTime > 0 && req.EndTime > 0 && req.StartTime > req.EndTime {
+		return nil, errs.ErrArgs.Wrap("startTime is after endTime")
+	}
+	pageNumber, showNumber := pageArgs(req.Pagination)
+	total, audits, err := m.RevokeAuditDatabase.SearchRevokeAudits(ctx, req.ConversationID, req.UserID, req.StartTime, req.EndTime, pageNumber, showNumber)
+	if err != nil {
+		return nil, err
+	}
+	resp := &msgext.GetRevokeAuditsResp{Total: total}
+	for _, audit := range audits {
+		resp.Audits = append(resp.Audits, &msgext.RevokeAudit{
+			ConversationID:  audit.ConversationID,
+			Seq:             audit.Seq,
+			ClientMsgID:     audit.ClientMsgID,
+			SessionType:     audit.SessionType,
+			SendID:          audit.SendID,
+			SendTime:        audit.SendTime,
+			ContentType:     audit.ContentType,
+			Content:         audit.Content,
+			RevokerUserID:   audit.RevokerUserID,
+			RevokerRole:     audit.RevokerRole,
+			RevokerNickname: audit.RevokerNickname,
+			RevokeTime:      audit.RevokeTime,
+		})
+	}
+	return resp, nil
+}
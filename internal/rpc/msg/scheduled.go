@@ -0,0 +1,102 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+	"github.com/OpenIMSDK/protocol/constant"
+	pbMsg "github.com/OpenIMSDK/protocol/msg"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+// isScheduledMsg a single or group chat msg explicitly marked scheduled, clients fill sendTime with their own
+// clock for every msg so it alone does not make a msg scheduled.
+func isScheduledMsg(msgData *sdkws.MsgData) bool {
+	if !config.Config.ScheduledMsg.Enable || !msgData.Options[msgprocessor.IsScheduled] {
+		return false
+	}
+	if msgprocessor.IsNotificationByMsg(msgData) {
+		return false
+	}
+	return msgData.SessionType == constant.SingleChatType || msgData.SessionType == constant.SuperGroupChatType
+}
+
+// sendScheduledMsg verifies the msg and keeps it until the crontask sends it again through SendMsg.
+func (m *msgServer) sendScheduledMsg(ctx context.Context, req *pbMsg.SendMsgReq) (*pbMsg.SendMsgResp, error) {
+	now := utils.GetCurrentTimestampByMill()
+	if req.MsgData.SendTime < now+config.Config.ScheduledMsg.MinDelay*1000 {
+		return nil, errs.ErrArgs.Wrap("sendTime of a scheduled msg is too soon")
+	}
+	maxDelay := config.Config.ScheduledMsg.MaxDelay
+	if maxDelay > 0 && req.MsgData.SendTime > now+maxDelay*1000 {
+		return nil, errs.ErrArgs.Wrap("sendTime is too far in the future")
+	}
+	if req.MsgData.ClientMsgID == "" {
+		return nil, errs.ErrArgs.Wrap("clientMsgID is empty")
+	}
+	if err := m.messageVerification(ctx, req); err != nil {
+		return nil, err
+	}
+	// 到期后按普通消息发送
+	delete(req.MsgData.Options, msgprocessor.IsScheduled)
+	if err := m.ScheduledMsgDatabase.CreateScheduledMsg(ctx, req.MsgData); err != nil {
+		return nil, err
+	}
+	log.ZInfo(ctx, "msg scheduled", "clientMsgID", req.MsgData.ClientMsgID, "sendTime", req.MsgData.SendTime)
+	return &pbMsg.SendMsgResp{
+		ClientMsgID: req.MsgData.ClientMsgID,
+		SendTime:    req.MsgData.SendTime,
+	}, nil
+}
+
+func (m *msgServer) CancelScheduledMsg(ctx context.Context, req *msgext.CancelScheduledMsgReq) (*msgext.CancelScheduledMsgResp, error) {
+	if req.UserID == "" || req.ClientMsgID == "" {
+		return nil, errs.ErrArgs.Wrap("userID or clientMsgID is empty")
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	canceled, err := m.ScheduledMsgDatabase.CancelScheduledMsg(ctx, req.UserID, req.ClientMsgID)
+	if err != nil {
+		return nil, err
+	}
+	if !canceled {
+		return nil, errs.ErrRecordNotFound.Wrap("no pending scheduled msg")
+	}
+	return &msgext.CancelScheduledMsgResp{}, nil
+}
+
+func (m *msgServer) GetScheduledMsgs(ctx context.Context, req *msgext.GetScheduledMsgsReq) (*msgext.GetScheduledMsgsResp, error) {
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.Wrap("userID is empty")
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	pageNumber, showNumber := pageArgs(req.Pagination)
+	total, msgs, err := m.ScheduledMsgDatabase.FindPendingScheduledMsgs(ctx, req.UserID, pageNumber, showNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &msgext.GetScheduledMsgsResp{Total: total, Msgs: msgs}, nil
+}
@@ -0,0 +1,193 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/msg"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/protocol/user"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+const (
+	defaultSearchCount = 20
+	maxSearchCount     = 100
+)
+
+func (m *msgServer) SearchMsgs(ctx context.Context, req *msgext.SearchMsgsReq) (*msgext.SearchMsgsResp, error) {
+	cond := &unRelationTb.MsgSearchCond{
+		Keyword:        req.Keyword,
+		SendID:         req.SendID,
+		RecvID:         req.RecvID,
+		GroupID:        req.GroupID,
+		ConversationID: req.ConversationID,
+		SessionType:    req.SessionType,
+		ContentTypes:   req.ContentTypes,
+		StartTime:      req.StartTime,
+		EndTime:        req.EndTime,
+	}
+	if !authverify.IsAppManagerUid(ctx) {
+		if req.UserID == "" || req.ConversationID == "" {
+			return nil, errs.ErrArgs.Wrap("userID and conversationID are required")
+		}
+		if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+			return nil, err
+		}
+		if err := m.checkConversationMember(ctx, req.UserID, req.ConversationID); err != nil {
+			return nil, err
+		}
+		// 与拉取消息相同, 不返回用户删除的和入群前或退群后的消息
+		minSeq, maxSeq, err := m.getUserSeqRange(ctx, req.UserID, req.ConversationID)
+		if err != nil {
+			return nil, err
+		}
+		cond.UserID, cond.MinSeq, cond.MaxSeq = req.UserID, minSeq, maxSeq
+	}
+	count := req.Count
+	if count <= 0 {
+		count = defaultSearchCount
+	} else if count > maxSearchCount {
+		count = maxSearchCount
+	}
+	msgs, nextCursor, err := m.MsgSearchDatabase.SearchMsgs(ctx, cond, req.Cursor, count)
+	if err != nil {
+		return nil, err
+	}
+	chatLogs, err := m.searchChatLogs(ctx, msgs)
+	if err != nil {
+		return nil, err
+	}
+	return &msgext.SearchMsgsResp{ChatLogs: chatLogs, NextCursor: nextCursor}, nil
+}
+
+func (m *msgServer) SearchMessage(ctx context.Context, req *msg.SearchMessageReq) (*msg.SearchMessageResp, error) {
+	cond := &unRelationTb.MsgSearchCond{
+		SendID:      req.SendID,
+		RecvID:      req.RecvID,
+		SessionType: req.SessionType,
+	}
+	if req.MsgType != 0 {
+		cond.ContentTypes = []int32{req.MsgType}
+	}
+	if req.SendTime != "" {
+		day, err := time.Parse("2006-01-02", req.SendTime)
+		if err != nil {
+			return nil, errs.ErrArgs.Wrap("sendTime must be yyyy-mm-dd")
+		}
+		cond.StartTime = day.UnixMilli()
+		cond.EndTime = day.AddDate(0, 0, 1).UnixMilli()
+	}
+	var pageNumber, showNumber int32
+	if req.Pagination != nil {
+		pageNumber, showNumber = req.Pagination.PageNumber, req.Pagination.ShowNumber
+	}
+	total, msgs, err := m.MsgSearchDatabase.SearchMsgsPage(ctx, cond, pageNumber, showNumber)
+	if err != nil {
+		return nil, err
+	}
+	chatLogs, err := m.searchChatLogs(ctx, msgs)
+	if err != nil {
+		return nil, err
+	}
+	return &msg.SearchMessageResp{ChatLogs: chatLogs, ChatLogsNum: int32(total)}, nil
+}
+
+// searchChatLogs fills the user and group infos of the msgs with one call for the users and one for the groups.
+func (m *msgServer) searchChatLogs(ctx context.Context, msgs []*unRelationTb.MsgSearchModel) ([]*msg.ChatLog, error) {
+	var userIDs, groupIDs []string
+	for _, msg := range msgs {
+		if msg.SenderNickname == "" {
+			userIDs = append(userIDs, msg.SendID)
+		}
+		switch msg.SessionType {
+		case constant.SingleChatType:
+			userIDs = append(userIDs, msg.RecvID)
+		case constant.GroupChatType, constant.SuperGroupChatType:
+			groupIDs = append(groupIDs, msg.GroupID)
+		}
+	}
+	users := make(map[string]*sdkws.UserInfo)
+	if userIDs = utils.Distinct(userIDs); len(userIDs) > 0 {
+		// 已注销的用户不影响搜索结果
+		resp, err := m.User.Client.GetDesignateUsers(ctx, &user.GetDesignateUsersReq{UserIDs: userIDs})
+		if err != nil {
+			return nil, err
+		}
+		users = utils.SliceToMap(resp.UsersInfo, func(e *sdkws.UserInfo) string { return e.UserID })
+	}
+	groups := make(map[string]*sdkws.GroupInfo)
+	if groupIDs = utils.Distinct(groupIDs); len(groupIDs) > 0 {
+		var err error
+		if groups, err = m.Group.GetGroupInfoMap(ctx, groupIDs, false); err != nil {
+			return nil, err
+		}
+	}
+	chatLogs := make([]*msg.ChatLog, 0, len(msgs))
+	for _, msg := range msgs {
+		chatLog := searchModelToChatLog(msg)
+		if chatLog.SenderNickname == "" {
+			if sendUser, ok := users[msg.SendID]; ok {
+				chatLog.SenderNickname = sendUser.Nickname
+			}
+		}
+		switch msg.SessionType {
+		case constant.SingleChatType:
+			if recvUser, ok := users[msg.RecvID]; ok {
+				chatLog.RecvNickname = recvUser.Nickname
+			}
+		case constant.GroupChatType, constant.SuperGroupChatType:
+			if group, ok := groups[msg.GroupID]; ok {
+				chatLog.SenderFaceURL = group.FaceURL
+				chatLog.GroupMemberCount = group.MemberCount
+				chatLog.RecvID = group.GroupID
+				chatLog.GroupName = group.GroupName
+				chatLog.GroupOwner = group.OwnerUserID
+				chatLog.GroupType = group.GroupType
+			}
+		}
+		chatLogs = append(chatLogs, chatLog)
+	}
+	return chatLogs, nil
+}
+
+func searchModelToChatLog(model *unRelationTb.MsgSearchModel) *msg.ChatLog {
+	return &msg.ChatLog{
+		ServerMsgID:      model.ServerMsgID,
+		ClientMsgID:      model.ClientMsgID,
+		SendID:           model.SendID,
+		RecvID:           model.RecvID,
+		GroupID:          model.GroupID,
+		SenderPlatformID: model.SenderPlatformID,
+		SenderNickname:   model.SenderNickname,
+		SenderFaceURL:    model.SenderFaceURL,
+		SessionType:      model.SessionType,
+		MsgFrom:          model.MsgFrom,
+		ContentType:      model.ContentType,
+		Content:          model.Content,
+		Status:           model.Status,
+		SendTime:         model.SendTime,
+		CreateTime:       model.CreateTime,
+		Ex:               model.Ex,
+		Seq:              model.Seq,
+	}
+}
@@ -37,6 +37,9 @@ func (m *msgServer) SendMsg(ctx context.Context, req *pbMsg.SendMsgReq) (resp *p
 		if !flag {
 			return nil, errs.ErrMessageHasReadDisable.Wrap()
 		}
+		if isScheduledMsg(req.MsgData) {
+			return m.sendScheduledMsg(ctx, req)
+		}
 		m.encapsulateMsgData(req.MsgData)
 		switch req.MsgData.SessionType {
 		case constant.SingleChatType:
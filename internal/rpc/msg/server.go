@@ -24,6 +24,7 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/localcache"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/prome"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/protocol/conversation"
@@ -44,6 +45,10 @@ type (
 		ConversationLocalCache *localcache.ConversationLocalCache
 		Handlers               MessageInterceptorChain
 		notificationSender     *rpcclient.NotificationSender
+		RevokeAuditDatabase    controller.MsgRevokeAuditDatabase
+		ScheduledMsgDatabase   controller.ScheduledMsgDatabase
+		MsgPinDatabase         controller.MsgPinDatabase
+		MsgSearchDatabase      controller.MsgSearchDatabase
 	}
 )
 
@@ -74,6 +79,21 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 	if err := mongo.CreateMsgIndex(); err != nil {
 		return err
 	}
+	if err := mongo.CreateMsgThreadIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateMsgRevokeAuditIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateScheduledMsgIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateMsgPinIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateMsgSearchIndex(); err != nil {
+		return err
+	}
 	cacheModel := cache.NewMsgCacheModel(rdb)
 	msgDocModel := unrelation.NewMsgMongoDriver(mongo.GetDatabase())
 	conversationClient := rpcclient.NewConversationRpcClient(client)
@@ -90,11 +110,16 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 		GroupLocalCache:        localcache.NewGroupLocalCache(&groupRpcClient),
 		ConversationLocalCache: localcache.NewConversationLocalCache(&conversationClient),
 		friend:                 &friendRpcClient,
+		RevokeAuditDatabase:    controller.NewMsgRevokeAuditDatabase(unrelation.NewMsgRevokeAuditMongoDriver(mongo.GetDatabase())),
+		ScheduledMsgDatabase:   controller.NewScheduledMsgDatabase(unrelation.NewScheduledMsgMongoDriver(mongo.GetDatabase())),
+		MsgPinDatabase:         controller.NewMsgPinDatabase(unrelation.NewMsgPinMongoDriver(mongo.GetDatabase())),
+		MsgSearchDatabase:      controller.NewMsgSearchDatabase(unrelation.NewMsgSearchMongoDriver(mongo.GetDatabase())),
 	}
 	s.notificationSender = rpcclient.NewNotificationSender(rpcclient.WithLocalSendMsg(s.SendMsg))
 	s.addInterceptorHandler(MessageHasReadEnabled)
 	s.initPrometheus()
 	msg.RegisterMsgServer(server, s)
+	msgext.RegisterMsgExtServer(server, s)
 	return nil
 }
 
@@ -19,12 +19,11 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
 
-	"github.com/OpenIMSDK/protocol/constant"
-	"github.com/OpenIMSDK/protocol/msg"
-
 	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
 	"github.com/OpenIMSDK/tools/log"
 	"github.com/OpenIMSDK/tools/utils"
+	"github.com/redis/go-redis/v9"
 )
 
 func (m *msgServer) PullMessageBySeqs(
@@ -36,10 +35,20 @@ func (m *msgServer) PullMessageBySeqs(
 	resp.NotificationMsgs = make(map[string]*sdkws.PullMsgs)
 	for _, seq := range req.SeqRanges {
 		if !msgprocessor.IsNotification(seq.ConversationID) {
-			conversation, err := m.Conversation.GetConversation(ctx, req.UserID, seq.ConversationID)
-			if err != nil {
-				log.ZError(ctx, "GetConversation error", err, "conversationID", seq.ConversationID)
-				continue
+			var userMaxSeq int64
+			if msgprocessor.IsThread(seq.ConversationID) {
+				// 话题没有会话记录, 按根消息在群会话中是否可见校验
+				if err := m.checkThreadMember(ctx, req.UserID, seq.ConversationID); err != nil {
+					log.ZWarn(ctx, "checkThreadMember error", err, "conversationID", seq.ConversationID)
+					continue
+				}
+			} else {
+				conversation, err := m.Conversation.GetConversation(ctx, req.UserID, seq.ConversationID)
+				if err != nil {
+					log.ZError(ctx, "GetConversation error", err, "conversationID", seq.ConversationID)
+					continue
+				}
+				userMaxSeq = conversation.MaxSeq
 			}
 			minSeq, maxSeq, msgs, err := m.MsgDatabase.GetMsgBySeqsRange(
 				ctx,
@@ -48,7 +57,7 @@ func (m *msgServer) PullMessageBySeqs(
 				seq.Begin,
 				seq.End,
 				seq.Num,
-				conversation.MaxSeq,
+				userMaxSeq,
 			)
 			if err != nil {
 				log.ZWarn(ctx, "GetMsgBySeqsRange error", err, "conversationID", seq.ConversationID, "seq", seq)
@@ -108,48 +117,23 @@ func (m *msgServer) GetMaxSeq(ctx context.Context, req *sdkws.GetMaxSeqReq) (*sd
 	return resp, nil
 }
 
-func (m *msgServer) SearchMessage(ctx context.Context, req *msg.SearchMessageReq) (resp *msg.SearchMessageResp, err error) {
-	var chatLogs []*sdkws.MsgData
-	var total int32
-	resp = &msg.SearchMessageResp{}
-	if total, chatLogs, err = m.MsgDatabase.SearchMessage(ctx, req); err != nil {
-		return nil, err
+// getUserSeqRange returns the seq range of conversationID userID can pull, the same range PullMessageBySeqs applies.
+// maxSeq is 0 when the user is still in the conversation.
+func (m *msgServer) getUserSeqRange(ctx context.Context, userID string, conversationID string) (minSeq int64, maxSeq int64, err error) {
+	conversation, err := m.Conversation.GetConversation(ctx, userID, conversationID)
+	if err != nil {
+		return 0, 0, err
 	}
-	for _, chatLog := range chatLogs {
-		pbChatLog := &msg.ChatLog{}
-		utils.CopyStructFields(pbChatLog, chatLog)
-		pbChatLog.SendTime = chatLog.SendTime
-		pbChatLog.CreateTime = chatLog.CreateTime
-		if chatLog.SenderNickname == "" {
-			sendUser, err := m.User.GetUserInfo(ctx, chatLog.SendID)
-			if err != nil {
-				return nil, err
-			}
-			pbChatLog.SenderNickname = sendUser.Nickname
-		}
-		switch chatLog.SessionType {
-		case constant.SingleChatType:
-			recvUser, err := m.User.GetUserInfo(ctx, chatLog.RecvID)
-			if err != nil {
-				return nil, err
-			}
-			pbChatLog.RecvNickname = recvUser.Nickname
-
-		case constant.GroupChatType, constant.SuperGroupChatType:
-			group, err := m.Group.GetGroupInfo(ctx, chatLog.GroupID)
-			if err != nil {
-				return nil, err
-			}
-			pbChatLog.SenderFaceURL = group.FaceURL
-			pbChatLog.GroupMemberCount = group.MemberCount
-			pbChatLog.RecvID = group.GroupID
-			pbChatLog.GroupName = group.GroupName
-			pbChatLog.GroupOwner = group.OwnerUserID
-			pbChatLog.GroupType = group.GroupType
-		}
-		resp.ChatLogs = append(resp.ChatLogs, pbChatLog)
+	minSeq, err = m.MsgDatabase.GetMinSeq(ctx, conversationID)
+	if err != nil && errs.Unwrap(err) != redis.Nil {
+		return 0, 0, err
 	}
-
-	resp.ChatLogsNum = total
-	return resp, nil
+	userMinSeq, err := m.MsgDatabase.GetConversationUserMinSeq(ctx, conversationID, userID)
+	if err != nil && errs.Unwrap(err) != redis.Nil {
+		return 0, 0, err
+	}
+	if userMinSeq > minSeq {
+		minSeq = userMinSeq
+	}
+	return minSeq, conversation.MaxSeq, nil
 }
@@ -0,0 +1,156 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+	"github.com/OpenIMSDK/protocol/constant"
+	pbMsg "github.com/OpenIMSDK/protocol/msg"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+)
+
+func (m *msgServer) SendThreadMsg(ctx context.Context, req *msgext.SendThreadMsgReq) (*msgext.SendThreadMsgResp, error) {
+	if req.MsgData == nil {
+		return nil, errs.ErrArgs.Wrap("msgData is nil")
+	}
+	if req.RootSeq <= 0 {
+		return nil, errs.ErrArgs.Wrap("rootSeq is invalid")
+	}
+	if req.MsgData.SessionType != constant.SuperGroupChatType {
+		return nil, errs.ErrArgs.Wrap("only super group chat has threads")
+	}
+	if req.ConversationID != msgprocessor.GetChatConversationIDByMsg(req.MsgData) {
+		return nil, errs.ErrArgs.Wrap("conversationID does not match msgData")
+	}
+	if err := authverify.CheckAccessV3(ctx, req.MsgData.SendID); err != nil {
+		return nil, err
+	}
+	_, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, req.MsgData.SendID, req.ConversationID, []int64{req.RootSeq})
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 || msgs[0] == nil || msgs[0].ClientMsgID == "" || msgs[0].Status == constant.MsgDeleted {
+		return nil, errs.ErrRecordNotFound.Wrap("root msg not found")
+	}
+	root := msgs[0]
+	if root.ContentType == constant.MsgRevokeNotification {
+		return nil, errs.ErrMsgAlreadyRevoke.Wrap("root msg already revoke")
+	}
+	sendReq := &pbMsg.SendMsgReq{MsgData: req.MsgData}
+	if err := m.messageVerification(ctx, sendReq); err != nil {
+		return nil, err
+	}
+	if err := callbackBeforeSendGroupMsg(ctx, sendReq); err != nil {
+		return nil, err
+	}
+	if err := callbackMsgModify(ctx, sendReq); err != nil {
+		return nil, err
+	}
+	m.encapsulateMsgData(req.MsgData)
+	threadID := msgprocessor.GetThreadConversationID(req.MsgData.GroupID, req.RootSeq)
+	if err := m.MsgDatabase.SendThreadMsg(ctx, req.ConversationID, root, threadID, req.MsgData); err != nil {
+		return nil, err
+	}
+	tips := msgext.ThreadReplyTips{
+		ThreadID:        threadID,
+		ConversationID:  req.ConversationID,
+		RootSeq:         req.RootSeq,
+		RootClientMsgID: root.ClientMsgID,
+		Seq:             req.MsgData.Seq,
+		ClientMsgID:     req.MsgData.ClientMsgID,
+		SendID:          req.MsgData.SendID,
+		SendTime:        req.MsgData.SendTime,
+	}
+	// 回复已保存, 通知失败不返回错误, 避免客户端重发
+	if err := m.notificationSender.NotificationWithSesstionType(ctx, req.MsgData.SendID, req.MsgData.GroupID, msgprocessor.ThreadReplyNotification, constant.SuperGroupChatType, &tips); err != nil {
+		log.ZWarn(ctx, "thread reply notification failed", err, "threadID", threadID, "seq", req.MsgData.Seq)
+	}
+	return &msgext.SendThreadMsgResp{
+		ThreadID:    threadID,
+		Seq:         req.MsgData.Seq,
+		ServerMsgID: req.MsgData.ServerMsgID,
+		ClientMsgID: req.MsgData.ClientMsgID,
+		SendTime:    req.MsgData.SendTime,
+	}, nil
+}
+
+func (m *msgServer) GetThreads(ctx context.Context, req *msgext.GetThreadsReq) (*msgext.GetThreadsResp, error) {
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.Wrap("user_id is empty")
+	}
+	groupID, ok := msgprocessor.ParseSuperGroupConversationID(req.ConversationID)
+	if !ok {
+		return nil, errs.ErrArgs.Wrap("only super group chat has threads")
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if err := m.checkGroupMember(ctx, req.UserID, groupID); err != nil {
+		return nil, err
+	}
+	minSeq, _, err := m.getUserSeqRange(ctx, req.UserID, req.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	pageNumber, showNumber := pageArgs(req.Pagination)
+	total, msgs, err := m.MsgDatabase.GetThreads(ctx, req.UserID, req.ConversationID, minSeq, pageNumber, showNumber)
+	if err != nil {
+		return nil, err
+	}
+	resp := &msgext.GetThreadsResp{Total: total}
+	for _, msg := range msgs {
+		rootMsg := convert.MsgDB2Pb(msg.Msg)
+		if msg.Revoke != nil {
+			rootMsg.ContentType = constant.MsgRevokeNotification
+			rootMsg.Content = nil
+		}
+		resp.Threads = append(resp.Threads, &msgext.ThreadInfo{
+			ThreadID:             msg.Thread.ConversationID,
+			RootMsg:              rootMsg,
+			ReplyCount:           msg.Thread.ReplyCount,
+			LastReplySeq:         msg.Thread.LastReplySeq,
+			LastReplyUserID:      msg.Thread.LastReplyUserID,
+			LastReplyClientMsgID: msg.Thread.LastReplyClientMsgID,
+			LastReplyTime:        msg.Thread.LastReplyTime,
+		})
+	}
+	return resp, nil
+}
+
+// checkThreadMember checks that userID is in the group of the thread and can see the root msg in the group conversation,
+// so the replies of a root sent before the user joined or after the user left can't be pulled.
+func (m *msgServer) checkThreadMember(ctx context.Context, userID string, threadID string) error {
+	groupID, rootSeq, ok := msgprocessor.ParseThreadConversationID(threadID)
+	if !ok {
+		return errs.ErrArgs.Wrap("invalid thread conversationID " + threadID)
+	}
+	if err := m.checkGroupMember(ctx, userID, groupID); err != nil {
+		return err
+	}
+	minSeq, maxSeq, err := m.getUserSeqRange(ctx, userID, msgprocessor.GetConversationIDBySessionType(constant.SuperGroupChatType, groupID))
+	if err != nil {
+		return err
+	}
+	if rootSeq < minSeq || (maxSeq != 0 && rootSeq > maxSeq) {
+		return errs.ErrNoPermission.Wrap("thread root msg is not visible to user " + userID)
+	}
+	return nil
+}
@@ -50,3 +50,24 @@ func IsNotFound(err error) bool {
 		return false
 	}
 }
+
+const (
+	defaultShowNumber = 20
+	maxShowNumber     = 100
+)
+
+// pageArgs 分页参数, 未指定时取第一页, 每页条数有上限.
+func pageArgs(pagination *sdkws.RequestPagination) (pageNumber int32, showNumber int32) {
+	if pagination != nil {
+		pageNumber, showNumber = pagination.PageNumber, pagination.ShowNumber
+	}
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+	if showNumber <= 0 {
+		showNumber = defaultShowNumber
+	} else if showNumber > maxShowNumber {
+		showNumber = maxShowNumber
+	}
+	return pageNumber, showNumber
+}
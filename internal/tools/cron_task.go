@@ -46,6 +46,14 @@ func StartCronTask() error {
 		fmt.Println("start conversationsDestructMsgs cron failed", err.Error(), config.Config.ChatRecordsClearTime)
 		panic(err)
 	}
+	if config.Config.ScheduledMsg.Enable {
+		log.ZInfo(context.Background(), "start scheduledMsgDispatch cron task", "cron config", config.Config.ScheduledMsg.DispatchTime)
+		_, err = c.AddFunc(config.Config.ScheduledMsg.DispatchTime, msgTool.DispatchScheduledMsgs)
+		if err != nil {
+			fmt.Println("start dispatchScheduledMsgs cron failed", err.Error(), config.Config.ScheduledMsg.DispatchTime)
+			panic(err)
+		}
+	}
 	c.Start()
 	wg.Wait()
 	return nil
@@ -0,0 +1,104 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/kafka"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+)
+
+// deadLetterReadTimeout ends a read once no message arrives for this long, the end of the partition is reached.
+const deadLetterReadTimeout = 3 * time.Second
+
+// DeadLetterRecord is a dead letter with its position in the dead letter topic.
+type DeadLetterRecord struct {
+	Partition int32
+	Offset    int64
+	*kafka.DeadLetter
+}
+
+type DeadLetterTool struct {
+	consumer  *kafka.Consumer
+	producers map[string]*kafka.Producer
+}
+
+func InitDeadLetterTool() *DeadLetterTool {
+	return &DeadLetterTool{
+		consumer:  kafka.NewKafkaConsumer(config.Config.Kafka.Addr, config.Config.Kafka.DeadLetter.Topic),
+		producers: make(map[string]*kafka.Producer),
+	}
+}
+
+// GetDeadLetters reads at most limit dead letters of partition from offset, limit <= 0 reads to the end.
+func (d *DeadLetterTool) GetDeadLetters(ctx context.Context, partition int32, offset int64, limit int) ([]*DeadLetterRecord, error) {
+	pc, err := d.consumer.Consumer.ConsumePartition(d.consumer.Topic, partition, offset)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer pc.Close()
+	var records []*DeadLetterRecord
+	for limit <= 0 || len(records) < limit {
+		select {
+		case msg := <-pc.Messages():
+			records = append(records, &DeadLetterRecord{
+				Partition:  msg.Partition,
+				Offset:     msg.Offset,
+				DeadLetter: kafka.ParseDeadLetter(msg),
+			})
+			if msg.Offset+1 >= pc.HighWaterMarkOffset() {
+				return records, nil
+			}
+		case err := <-pc.Errors():
+			return records, errs.Wrap(err)
+		case <-time.After(deadLetterReadTimeout):
+			log.ZDebug(ctx, "no more dead letters", "partition", partition, "num", len(records))
+			return records, nil
+		}
+	}
+	return records, nil
+}
+
+// ReplayDeadLetters sends the dead letters read like GetDeadLetters back to the topics they failed on,
+// with their original key and headers. It returns the number of replayed messages.
+func (d *DeadLetterTool) ReplayDeadLetters(ctx context.Context, partition int32, offset int64, limit int) (int, error) {
+	records, err := d.GetDeadLetters(ctx, partition, offset, limit)
+	if err != nil {
+		return 0, err
+	}
+	var num int
+	for _, record := range records {
+		if record.Topic == "" {
+			log.ZWarn(ctx, "dead letter without topic, skip", nil, "partition", record.Partition, "offset", record.Offset)
+			continue
+		}
+		producer, ok := d.producers[record.Topic]
+		if !ok {
+			producer = kafka.NewKafkaProducer(config.Config.Kafka.Addr, record.Topic)
+			d.producers[record.Topic] = producer
+		}
+		if _, _, err := producer.SendRawMessage(ctx, record.Key, record.Value, record.Headers); err != nil {
+			return num, err
+		}
+		num++
+		log.ZInfo(ctx, "replay dead letter", "partition", record.Partition, "offset", record.Offset, "topic", record.Topic,
+			"source", record.Source, "reason", record.Reason)
+	}
+	return num, nil
+}
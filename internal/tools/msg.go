@@ -46,10 +46,14 @@ type MsgTool struct {
 	userDatabase          controller.UserDatabase
 	groupDatabase         controller.GroupDatabase
 	msgNotificationSender *notification.MsgNotificationSender
+	scheduledMsgDatabase  controller.ScheduledMsgDatabase
+	msgSearchDatabase     controller.MsgSearchDatabase
+	msgRpcClient          *rpcclient.MessageRpcClient
 }
 
 func NewMsgTool(msgDatabase controller.CommonMsgDatabase, userDatabase controller.UserDatabase,
 	groupDatabase controller.GroupDatabase, conversationDatabase controller.ConversationDatabase, msgNotificationSender *notification.MsgNotificationSender,
+	scheduledMsgDatabase controller.ScheduledMsgDatabase, msgSearchDatabase controller.MsgSearchDatabase, msgRpcClient *rpcclient.MessageRpcClient,
 ) *MsgTool {
 	return &MsgTool{
 		msgDatabase:           msgDatabase,
@@ -57,6 +61,9 @@ func NewMsgTool(msgDatabase controller.CommonMsgDatabase, userDatabase controlle
 		groupDatabase:         groupDatabase,
 		conversationDatabase:  conversationDatabase,
 		msgNotificationSender: msgNotificationSender,
+		scheduledMsgDatabase:  scheduledMsgDatabase,
+		msgSearchDatabase:     msgSearchDatabase,
+		msgRpcClient:          msgRpcClient,
 	}
 }
 
@@ -97,7 +104,10 @@ func InitMsgTool() (*MsgTool, error) {
 	)
 	msgRpcClient := rpcclient.NewMessageRpcClient(discov)
 	msgNotificationSender := notification.NewMsgNotificationSender(rpcclient.WithRpcClient(&msgRpcClient))
-	msgTool := NewMsgTool(msgDatabase, userDatabase, groupDatabase, conversationDatabase, msgNotificationSender)
+	scheduledMsgDatabase := controller.NewScheduledMsgDatabase(unrelation.NewScheduledMsgMongoDriver(mongo.GetDatabase()))
+	msgSearchDatabase := controller.NewMsgSearchDatabase(unrelation.NewMsgSearchMongoDriver(mongo.GetDatabase()))
+	msgTool := NewMsgTool(msgDatabase, userDatabase, groupDatabase, conversationDatabase, msgNotificationSender, scheduledMsgDatabase,
+		msgSearchDatabase, &msgRpcClient)
 	return msgTool, nil
 }
 
@@ -169,3 +179,30 @@ func (c *MsgTool) FixAllSeq(ctx context.Context) error {
 	fmt.Println("fix all seq finished")
 	return nil
 }
+
+// VerifySeqs checks the msgs in mongo against the redis seqs of the conversations, all conversations
+// when conversationIDs is empty. Only the reports with gaps or duplicates are returned.
+func (c *MsgTool) VerifySeqs(ctx context.Context, conversationIDs []string) ([]*controller.MsgSeqReport, error) {
+	if len(conversationIDs) == 0 {
+		var err error
+		conversationIDs, err = c.conversationDatabase.GetAllConversationIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, conversationID := range conversationIDs {
+			conversationIDs = append(conversationIDs, utils.GetNotificationConversationIDByConversationID(conversationID))
+		}
+	}
+	var reports []*controller.MsgSeqReport
+	for _, conversationID := range conversationIDs {
+		report, err := c.msgDatabase.VerifyConversationSeqs(ctx, conversationID)
+		if err != nil {
+			log.ZWarn(ctx, "VerifyConversationSeqs failed", err, "conversationID", conversationID)
+			continue
+		}
+		if !report.OK() {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
@@ -0,0 +1,47 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/tools/log"
+)
+
+// IndexSearchMsgs rebuilds the search index of the msgs stored before the index existed, or indexed before the
+// CJK grams and del lists were kept. Empty conversationIDs means all conversations, it returns the indexed docs.
+func (c *MsgTool) IndexSearchMsgs(ctx context.Context, conversationIDs []string) (int, error) {
+	if len(conversationIDs) == 0 {
+		var err error
+		conversationIDs, err = c.conversationDatabase.GetAllConversationIDs(ctx)
+		if err != nil {
+			return 0, err
+		}
+	}
+	var docs int
+	for _, conversationID := range conversationIDs {
+		err := c.msgDatabase.RangeConversationMsgDocs(ctx, conversationID, func(msgs []*unRelationTb.MsgInfoModel) error {
+			docs++
+			return c.msgSearchDatabase.IndexMsgDocs(ctx, conversationID, msgs)
+		})
+		if err != nil {
+			log.ZWarn(ctx, "index search msgs failed", err, "conversationID", conversationID)
+			continue
+		}
+		log.ZInfo(ctx, "index search msgs", "conversationID", conversationID)
+	}
+	return docs, nil
+}
@@ -0,0 +1,66 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/protocol/msg"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+// DispatchScheduledMsgs sends every due scheduled msg through the msg rpc, which verifies it again.
+// A msg is marked as sent only after SendMsg succeeds, so it may be sent more than once but never lost.
+func (c *MsgTool) DispatchScheduledMsgs() {
+	ctx := mcontext.NewCtx(utils.GetSelfFuncName())
+	for {
+		scheduled, msgData, err := c.scheduledMsgDatabase.TakeDueScheduledMsg(ctx)
+		if err != nil {
+			log.ZError(ctx, "TakeDueScheduledMsg failed", err)
+			return
+		}
+		if scheduled == nil {
+			return
+		}
+		c.dispatchScheduledMsg(ctx, scheduled, msgData)
+	}
+}
+
+func (c *MsgTool) dispatchScheduledMsg(ctx context.Context, scheduled *unRelationTb.ScheduledMsgModel, msgData *sdkws.MsgData) {
+	sendCtx := mcontext.SetOpUserID(mcontext.NewCtx(mcontext.GetOperationID(ctx)+"_"+scheduled.ClientMsgID), scheduled.SendID)
+	status, errMsg := int32(unRelationTb.ScheduledMsgSent), ""
+	if _, err := c.msgRpcClient.SendMsg(sendCtx, &msg.SendMsgReq{MsgData: msgData}); err != nil {
+		log.ZWarn(ctx, "send scheduled msg failed", err, "clientMsgID", scheduled.ClientMsgID, "attempts", scheduled.Attempts)
+		errMsg = err.Error()
+		if scheduled.Attempts < config.Config.ScheduledMsg.MaxAttempts {
+			backoff := time.Duration(config.Config.ScheduledMsg.RetryBackoff) * time.Second << (scheduled.Attempts - 1)
+			if err := c.scheduledMsgDatabase.RetryScheduledMsg(ctx, scheduled.SendID, scheduled.ClientMsgID, backoff, errMsg); err != nil {
+				log.ZError(ctx, "RetryScheduledMsg failed", err, "clientMsgID", scheduled.ClientMsgID)
+			}
+			return
+		}
+		status = unRelationTb.ScheduledMsgFailed
+	}
+	if err := c.scheduledMsgDatabase.SetScheduledMsgStatus(ctx, scheduled.SendID, scheduled.ClientMsgID, status, errMsg); err != nil {
+		// 状态未更新的消息会在lockTimeout后重新发送
+		log.ZError(ctx, "SetScheduledMsgStatus failed", err, "clientMsgID", scheduled.ClientMsgID, "status", status)
+	}
+}
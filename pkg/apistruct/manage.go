@@ -30,6 +30,8 @@ type SendMsg struct {
 	IsOnlineOnly     bool                   `json:"isOnlineOnly"`
 	NotOfflinePush   bool                   `json:"notOfflinePush"`
 	OfflinePushInfo  *sdkws.OfflinePushInfo `json:"offlinePushInfo"`
+	// SendTime in milliseconds, a time in the future sends the msg later
+	SendTime int64 `json:"sendTime"`
 }
 
 type SendMsgReq struct {
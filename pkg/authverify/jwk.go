@@ -0,0 +1,224 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authverify
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/tools/errs"
+)
+
+type signingKey struct {
+	keyID      string
+	method     jwt.SigningMethod
+	privateKey crypto.PrivateKey
+	publicKey  crypto.PublicKey
+}
+
+type keySet struct {
+	method jwt.SigningMethod
+	sign   *signingKey
+	verify map[string]*signingKey
+}
+
+var (
+	keysOnce sync.Once
+	keys     *keySet
+	keysErr  error
+)
+
+// LoadSigningKeys 读取配置中的签名和验签key, 只在第一次调用时读取.
+func LoadSigningKeys() error {
+	keysOnce.Do(func() {
+		keys, keysErr = loadSigningKeys()
+	})
+	return keysErr
+}
+
+func loadSigningKeys() (*keySet, error) {
+	conf := config.Config.TokenPolicy.Signing
+	set := &keySet{verify: make(map[string]*signingKey)}
+	switch conf.Algorithm {
+	case "", jwt.SigningMethodHS256.Alg():
+		set.method = jwt.SigningMethodHS256
+	case jwt.SigningMethodRS256.Alg():
+		set.method = jwt.SigningMethodRS256
+	case jwt.SigningMethodEdDSA.Alg():
+		set.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, errs.ErrArgs.Wrap("unsupported token signing algorithm " + conf.Algorithm)
+	}
+	for _, k := range conf.Keys {
+		if k.KeyID == "" {
+			return nil, errs.ErrArgs.Wrap("token signing key without keyID")
+		}
+		if _, ok := set.verify[k.KeyID]; ok {
+			return nil, errs.ErrArgs.Wrap("duplicate token signing keyID " + k.KeyID)
+		}
+		key, err := loadSigningKey(k.KeyID, k.PrivateKeyFile, k.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		set.verify[k.KeyID] = key
+	}
+	if set.method == jwt.SigningMethodHS256 {
+		return set, nil
+	}
+	set.sign = set.verify[conf.KeyID]
+	if set.sign == nil {
+		return nil, errs.ErrArgs.Wrap("token signing keyID not found " + conf.KeyID)
+	}
+	if set.sign.method != set.method {
+		return nil, errs.ErrArgs.Wrap(fmt.Sprintf("token signing key %s is not a %s key", conf.KeyID, conf.Algorithm))
+	}
+	return set, nil
+}
+
+// loadSigningKey 私钥可以不配置, 只用于验签的服务只需要公钥.
+func loadSigningKey(keyID, privateKeyFile, publicKeyFile string) (*signingKey, error) {
+	key := &signingKey{keyID: keyID}
+	if privateKeyFile != "" {
+		data, err := os.ReadFile(privateKeyFile)
+		if err != nil {
+			return nil, errs.Wrap(err, "read private key "+keyID)
+		}
+		if privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+			key.method, key.privateKey, key.publicKey = jwt.SigningMethodRS256, privateKey, &privateKey.PublicKey
+		} else if privateKey, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+			key.method, key.privateKey = jwt.SigningMethodEdDSA, privateKey
+			key.publicKey = privateKey.(ed25519.PrivateKey).Public()
+		} else {
+			return nil, errs.ErrArgs.Wrap("private key is neither rsa nor ed25519 " + keyID)
+		}
+	}
+	if publicKeyFile != "" {
+		data, err := os.ReadFile(publicKeyFile)
+		if err != nil {
+			return nil, errs.Wrap(err, "read public key "+keyID)
+		}
+		var method jwt.SigningMethod
+		var publicKey crypto.PublicKey
+		if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+			method, publicKey = jwt.SigningMethodRS256, rsaKey
+		} else if edKey, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+			method, publicKey = jwt.SigningMethodEdDSA, edKey
+		} else {
+			return nil, errs.ErrArgs.Wrap("public key is neither rsa nor ed25519 " + keyID)
+		}
+		if key.method != nil && key.method != method {
+			return nil, errs.ErrArgs.Wrap("private key and public key do not match " + keyID)
+		}
+		key.method, key.publicKey = method, publicKey
+	}
+	if key.publicKey == nil {
+		return nil, errs.ErrArgs.Wrap("token signing key without key file " + keyID)
+	}
+	return key, nil
+}
+
+// SignToken 使用配置的算法签名, 非对称签名时header中带上kid.
+func SignToken(claims jwt.Claims) (string, error) {
+	if err := LoadSigningKeys(); err != nil {
+		return "", err
+	}
+	if keys.method == jwt.SigningMethodHS256 {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(config.Config.Secret))
+		return token, errs.Wrap(err)
+	}
+	if keys.sign.privateKey == nil {
+		return "", errs.ErrArgs.Wrap("token signing key without private key " + keys.sign.keyID)
+	}
+	token := jwt.NewWithClaims(keys.method, claims)
+	token.Header["kid"] = keys.sign.keyID
+	tokenString, err := token.SignedString(keys.sign.privateKey)
+	return tokenString, errs.Wrap(err)
+}
+
+// verifyKey 按token header中的kid选择验签key, 算法必须和key一致.
+func verifyKey(token *jwt.Token) (interface{}, error) {
+	if err := LoadSigningKeys(); err != nil {
+		return nil, err
+	}
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, errs.ErrTokenUnknown.Wrap("unexpected signing method " + token.Method.Alg())
+		}
+		if keys.method != jwt.SigningMethodHS256 && !config.Config.TokenPolicy.Signing.AcceptHS256 {
+			return nil, errs.ErrTokenUnknown.Wrap("HS256 token not accepted")
+		}
+		if config.Config.Secret == "" {
+			return nil, errs.ErrTokenUnknown.Wrap("secret not configured")
+		}
+		return []byte(config.Config.Secret), nil
+	}
+	keyID, _ := token.Header["kid"].(string)
+	key, ok := keys.verify[keyID]
+	if !ok {
+		return nil, errs.ErrTokenUnknown.Wrap("unknown token key id " + keyID)
+	}
+	if token.Method != key.method {
+		return nil, errs.ErrTokenUnknown.Wrap("unexpected signing method " + token.Method.Alg())
+	}
+	return key.publicKey, nil
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// GetJWKSet 所有验签公钥, 供其他服务不持有secret也能校验token.
+func GetJWKSet() (*JWKSet, error) {
+	if err := LoadSigningKeys(); err != nil {
+		return nil, err
+	}
+	set := &JWKSet{Keys: make([]JWK, 0, len(keys.verify))}
+	for _, k := range config.Config.TokenPolicy.Signing.Keys {
+		key := keys.verify[k.KeyID]
+		jwk := JWK{Kid: key.keyID, Use: "sig", Alg: key.method.Alg()}
+		switch publicKey := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set, nil
+}
@@ -3,7 +3,10 @@ package authverify
 import (
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/tools/errs"
 	"github.com/OpenIMSDK/tools/mcontext"
 	"github.com/OpenIMSDK/tools/tokenverify"
@@ -11,10 +14,9 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// Secret 验签使用的key, 按token的算法和kid选择.
 func Secret() jwt.Keyfunc {
-	return func(token *jwt.Token) (interface{}, error) {
-		return []byte(config.Config.Secret), nil
-	}
+	return verifyKey
 }
 
 func CheckAccessV3(ctx context.Context, ownerUserID string) (err error) {
@@ -48,7 +50,7 @@ func IsManagerUserID(opUserID string) bool {
 }
 
 func WsVerifyToken(token, userID string, platformID int) error {
-	claim, err := tokenverify.GetClaimFromToken(token, Secret())
+	claim, err := VerifyAccessToken(token)
 	if err != nil {
 		return err
 	}
@@ -60,3 +62,143 @@ func WsVerifyToken(token, userID string, platformID int) error {
 	}
 	return nil
 }
+
+// token在redis中除constant.NormalToken等之外的状态.
+const (
+	RefreshToken        = 4 // 未使用的刷新token
+	RotatedRefreshToken = 5 // 已换取过新token的刷新token, 再次使用视为泄露
+	RevokedToken        = 6 // 所属的token族已被吊销
+)
+
+// token的类型, 访问token和刷新token使用同一个key签名, 验签时必须检查类型.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// TokenClaims 在tokenverify.Claims的基础上带有token族和类型, 同一次登录及其后刷新得到的token属于同一个族.
+// 没有类型的旧token是访问token.
+type TokenClaims struct {
+	UserID     string
+	PlatformID int
+	FamilyID   string `json:",omitempty"`
+	Type       string `json:"typ,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (c *TokenClaims) IsRefresh() bool {
+	return c.Type == TokenTypeRefresh
+}
+
+func BuildTokenClaims(userID string, platformID int, familyID string, tokenType string, ttl time.Duration) TokenClaims {
+	now := time.Now()
+	return TokenClaims{
+		UserID:     userID,
+		PlatformID: platformID,
+		FamilyID:   familyID,
+		Type:       tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now.Add(-time.Minute * 5)),
+		},
+	}
+}
+
+// GetTokenClaims 只验签一次, 错误与tokenverify.GetClaimFromToken一致.
+func GetTokenClaims(token string) (*TokenClaims, error) {
+	claims := &TokenClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, Secret())
+	if err != nil {
+		if ve, ok := err.(*jwt.ValidationError); ok {
+			switch {
+			case ve.Errors&jwt.ValidationErrorMalformed != 0:
+				return nil, utils.Wrap(errs.ErrTokenMalformed, "")
+			case ve.Errors&jwt.ValidationErrorExpired != 0:
+				return nil, utils.Wrap(errs.ErrTokenExpired, "")
+			case ve.Errors&jwt.ValidationErrorNotValidYet != 0:
+				return nil, utils.Wrap(errs.ErrTokenNotValidYet, "")
+			}
+		}
+		return nil, utils.Wrap(errs.ErrTokenUnknown, "")
+	}
+	if !parsed.Valid {
+		return nil, utils.Wrap(errs.ErrTokenUnknown, "")
+	}
+	return claims, nil
+}
+
+// defaultAccessExpire 启用刷新token且未配置accessExpire时访问token的有效期.
+const defaultAccessExpire = 2 * time.Hour
+
+// VerifyAccessToken 验签并检查是访问token, 刷新token不能用于访问.
+func VerifyAccessToken(token string) (*tokenverify.Claims, error) {
+	claims, err := GetTokenClaims(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != "" && claims.Type != TokenTypeAccess {
+		return nil, errs.ErrTokenInvalid.Wrap("not an access token")
+	}
+	return &tokenverify.Claims{UserID: claims.UserID, PlatformID: claims.PlatformID, RegisteredClaims: claims.RegisteredClaims}, nil
+}
+
+// VerifyRefreshToken 验签并检查是刷新token.
+func VerifyRefreshToken(token string) (*TokenClaims, error) {
+	claims, err := GetTokenClaims(token)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.IsRefresh() {
+		return nil, errs.ErrTokenInvalid.Wrap("not a refresh token")
+	}
+	return claims, nil
+}
+
+// AccessTokenExpire 访问token有效期, 未配置accessExpire时, 启用刷新token为defaultAccessExpire, 否则沿用expire.
+func AccessTokenExpire() time.Duration {
+	if config.Config.TokenPolicy.AccessExpire > 0 {
+		return time.Duration(config.Config.TokenPolicy.AccessExpire) * time.Minute
+	}
+	if config.Config.TokenPolicy.RefreshExpire > 0 {
+		return defaultAccessExpire
+	}
+	return time.Duration(config.Config.TokenPolicy.Expire) * 24 * time.Hour
+}
+
+// RefreshTokenExpire 刷新token有效期, 为0时不签发刷新token.
+func RefreshTokenExpire() time.Duration {
+	return time.Duration(config.Config.TokenPolicy.RefreshExpire) * 24 * time.Hour
+}
+
+// SessionExpire 一次登录最长的有效期, 刷新token每次刷新后重新计算.
+func SessionExpire() time.Duration {
+	if expire := RefreshTokenExpire(); expire > 0 {
+		return expire
+	}
+	return AccessTokenExpire()
+}
+
+// SessionID 登录会话即token族, 没有token族的旧token单独作为一个会话.
+func SessionID(token string, claims *TokenClaims) string {
+	if claims.FamilyID != "" {
+		return claims.FamilyID
+	}
+	return utils.Md5(token)
+}
+
+// CheckTokenStatus 访问token在redis中的状态是否可用.
+func CheckTokenStatus(status int) error {
+	switch status {
+	case constant.NormalToken:
+		return nil
+	case constant.KickedToken:
+		return errs.ErrTokenKicked.Wrap()
+	case RevokedToken:
+		return errs.ErrTokenInvalid.Wrap("token revoked")
+	case RefreshToken, RotatedRefreshToken:
+		return errs.ErrTokenInvalid.Wrap("refresh token can not be used as access token")
+	default:
+		return errs.ErrTokenUnknown.Wrap()
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package callbacksign signs the callbacks openIM posts to the business server and lets the receiver verify them.
+//
+// Every callback carries OpenIM-Timestamp and OpenIM-Signature headers. The signature is an HMAC-SHA256 over
+// "timestamp\ncommand\nbody" with each active secret, written as keyID=hex pairs joined by ",", so that a
+// secret can be rotated by configuring both the old and the new one until every receiver knows the new one.
+package callbacksign
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderTimestamp = "OpenIM-Timestamp"
+	HeaderSignature = "OpenIM-Signature"
+
+	// CommandQuery is the url query parameter holding the callback command.
+	CommandQuery = "command"
+
+	// DefaultTolerance is how far the timestamp may be from the receiver clock.
+	DefaultTolerance = 5 * time.Minute
+)
+
+var (
+	ErrNoSignature       = errors.New("callback signature missing")
+	ErrInvalidTimestamp  = errors.New("callback timestamp invalid")
+	ErrTimestampExpired  = errors.New("callback timestamp out of tolerance")
+	ErrUnknownKey        = errors.New("callback signed with unknown key")
+	ErrSignatureMismatch = errors.New("callback signature mismatch")
+)
+
+// Secret is a shared secret, KeyID tells the receiver which secret a signature was made with.
+type Secret struct {
+	KeyID  string
+	Secret string
+}
+
+// Sign returns the hex HMAC-SHA256 of the timestamp, command and body with secret.
+func Sign(secret string, timestamp int64, command string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	h.Write([]byte("\n"))
+	h.Write([]byte(command))
+	h.Write([]byte("\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Header returns the signing headers of a callback sent at now with all secrets.
+func Header(secrets []Secret, now time.Time, command string, body []byte) map[string]string {
+	timestamp := now.Unix()
+	signatures := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		signatures = append(signatures, secret.KeyID+"="+Sign(secret.Secret, timestamp, command, body))
+	}
+	return map[string]string{
+		HeaderTimestamp: strconv.FormatInt(timestamp, 10),
+		HeaderSignature: strings.Join(signatures, ","),
+	}
+}
+
+// Verifier checks callbacks on the receiver side.
+type Verifier struct {
+	secrets   map[string]string
+	tolerance time.Duration
+	now       func() time.Time
+}
+
+// NewVerifier accepts signatures made with any of secrets, tolerance <= 0 means DefaultTolerance.
+func NewVerifier(secrets []Secret, tolerance time.Duration) *Verifier {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	v := &Verifier{secrets: make(map[string]string), tolerance: tolerance, now: time.Now}
+	for _, secret := range secrets {
+		v.secrets[secret.KeyID] = secret.Secret
+	}
+	return v
+}
+
+// Verify checks the signing headers against command and body. Requests older or newer than the tolerance
+// are rejected, so a captured callback can not be replayed later.
+func (v *Verifier) Verify(header http.Header, command string, body []byte) error {
+	timestampStr, signature := header.Get(HeaderTimestamp), header.Get(HeaderSignature)
+	if timestampStr == "" || signature == "" {
+		return ErrNoSignature
+	}
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	if diff := v.now().Sub(time.Unix(timestamp, 0)); diff > v.tolerance || diff < -v.tolerance {
+		return ErrTimestampExpired
+	}
+	known := false
+	for _, pair := range strings.Split(signature, ",") {
+		keyID, sign, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		secret, ok := v.secrets[keyID]
+		if !ok {
+			continue
+		}
+		known = true
+		if hmac.Equal([]byte(sign), []byte(Sign(secret, timestamp, command, body))) {
+			return nil
+		}
+	}
+	if !known {
+		return ErrUnknownKey
+	}
+	return ErrSignatureMismatch
+}
+
+// VerifyRequest reads and verifies the body of a callback request, the body is left readable for the handler.
+func (v *Verifier) VerifyRequest(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err := v.Verify(r.Header, r.URL.Query().Get(CommandQuery), body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
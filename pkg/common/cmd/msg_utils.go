@@ -15,9 +15,18 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
 	"github.com/spf13/cobra"
 
 	"github.com/OpenIMSDK/Open-IM-Server/internal/tools"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
 )
 
 type MsgUtilsCmd struct {
@@ -61,6 +70,15 @@ func (m *MsgUtilsCmd) getSuperGroupIDFlag(cmdLines *cobra.Command) string {
 	return superGroupID
 }
 
+func (m *MsgUtilsCmd) AddConversationIDFlag() {
+	m.Command.PersistentFlags().String("conversationID", "", "openIM conversationID")
+}
+
+func (m *MsgUtilsCmd) getConversationIDFlag(cmdLines *cobra.Command) string {
+	conversationID, _ := cmdLines.Flags().GetString("conversationID")
+	return conversationID
+}
+
 func (m *MsgUtilsCmd) AddBeginSeqFlag() {
 	m.Command.PersistentFlags().Int64P("beginSeq", "b", 0, "openIM beginSeq")
 }
@@ -79,6 +97,33 @@ func (m *MsgUtilsCmd) getLimitFlag(cmdLines *cobra.Command) int64 {
 	return limit
 }
 
+func (m *MsgUtilsCmd) AddPartitionFlag() {
+	m.Command.PersistentFlags().Int32P("partition", "p", 0, "kafka partition")
+}
+
+func (m *MsgUtilsCmd) getPartitionFlag(cmdLines *cobra.Command) int32 {
+	partition, _ := cmdLines.Flags().GetInt32("partition")
+	return partition
+}
+
+func (m *MsgUtilsCmd) AddOffsetFlag() {
+	m.Command.PersistentFlags().Int64P("offset", "o", sarama.OffsetOldest, "kafka offset, -2 is the oldest")
+}
+
+func (m *MsgUtilsCmd) getOffsetFlag(cmdLines *cobra.Command) int64 {
+	offset, _ := cmdLines.Flags().GetInt64("offset")
+	return offset
+}
+
+func (m *MsgUtilsCmd) AddConfFlag() {
+	m.Command.PersistentFlags().String(constant.FlagConf, "", "Path to config file folder")
+}
+
+func (m *MsgUtilsCmd) initConfig(cmdLines *cobra.Command) error {
+	configFolderPath, _ := cmdLines.Flags().GetString(constant.FlagConf)
+	return config.InitConfig(configFolderPath)
+}
+
 func (m *MsgUtilsCmd) Execute() error {
 	return m.Command.Execute()
 }
@@ -123,6 +168,36 @@ func NewClearCmd() *ClearCmd {
 	}
 }
 
+type ReplayCmd struct {
+	*MsgUtilsCmd
+}
+
+func NewReplayCmd() *ReplayCmd {
+	return &ReplayCmd{
+		NewMsgUtilsCmd("replay [resource]", "replay action", cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs)),
+	}
+}
+
+type VerifyCmd struct {
+	*MsgUtilsCmd
+}
+
+func NewVerifyCmd() *VerifyCmd {
+	return &VerifyCmd{
+		NewMsgUtilsCmd("verify [resource]", "verify action", cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs)),
+	}
+}
+
+type IndexCmd struct {
+	*MsgUtilsCmd
+}
+
+func NewIndexCmd() *IndexCmd {
+	return &IndexCmd{
+		NewMsgUtilsCmd("index [resource]", "index action", cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs)),
+	}
+}
+
 type SeqCmd struct {
 	*MsgUtilsCmd
 }
@@ -165,6 +240,37 @@ func (s *SeqCmd) FixSeqCmd() *cobra.Command {
 	return &s.Command
 }
 
+// VerifySeqCmd prints the conversations whose msgs in mongo have gaps or duplicates as json lines.
+func (s *SeqCmd) VerifySeqCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "seq",
+		Short: "check the msgs in mongo against the max seq in redis",
+		Run: func(cmdLines *cobra.Command, args []string) {
+			if err := s.initConfig(cmdLines); err != nil {
+				panic(err)
+			}
+			msgTool, err := tools.InitMsgTool()
+			if err != nil {
+				panic(err)
+			}
+			var conversationIDs []string
+			if conversationID := s.getConversationIDFlag(cmdLines); conversationID != "" {
+				conversationIDs = append(conversationIDs, conversationID)
+			}
+			ctx := mcontext.NewCtx(utils.GetSelfFuncName())
+			reports, err := msgTool.VerifySeqs(ctx, conversationIDs)
+			if err != nil {
+				panic(err)
+			}
+			for _, report := range reports {
+				data, _ := json.Marshal(report)
+				fmt.Println(string(data))
+			}
+			fmt.Println("conversations with seq errors", len(reports))
+		},
+	}
+}
+
 type MsgCmd struct {
 	*MsgUtilsCmd
 }
@@ -183,3 +289,114 @@ func (m *MsgCmd) GetMsgCmd() *cobra.Command {
 func (m *MsgCmd) ClearMsgCmd() *cobra.Command {
 	return &m.Command
 }
+
+// IndexMsgCmd rebuilds the search index of the msgs already in mongo.
+func (m *MsgCmd) IndexMsgCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "msg",
+		Short: "rebuild the search index of the msgs in mongo",
+		Run: func(cmdLines *cobra.Command, args []string) {
+			if err := m.initConfig(cmdLines); err != nil {
+				panic(err)
+			}
+			msgTool, err := tools.InitMsgTool()
+			if err != nil {
+				panic(err)
+			}
+			var conversationIDs []string
+			if conversationID := m.getConversationIDFlag(cmdLines); conversationID != "" {
+				conversationIDs = append(conversationIDs, conversationID)
+			}
+			ctx := mcontext.NewCtx(utils.GetSelfFuncName())
+			docs, err := msgTool.IndexSearchMsgs(ctx, conversationIDs)
+			if err != nil {
+				panic(err)
+			}
+			fmt.Println("indexed msg docs", docs)
+		},
+	}
+}
+
+type DeadLetterCmd struct {
+	*MsgUtilsCmd
+}
+
+func NewDeadLetterCmd() *DeadLetterCmd {
+	return &DeadLetterCmd{
+		NewMsgUtilsCmd("deadLetter", "msg_transfer dead letters", nil),
+	}
+}
+
+// deadLetterView is the printed form of a dead letter, the value is base64 in json.
+type deadLetterView struct {
+	Partition       int32             `json:"partition"`
+	Offset          int64             `json:"offset"`
+	Topic           string            `json:"topic"`
+	SourcePartition int32             `json:"sourcePartition"`
+	SourceOffset    int64             `json:"sourceOffset"`
+	Source          string            `json:"source"`
+	Reason          string            `json:"reason"`
+	Attempts        int               `json:"attempts"`
+	Time            time.Time         `json:"time"`
+	Key             string            `json:"key"`
+	Headers         map[string]string `json:"headers"`
+	Value           []byte            `json:"value"`
+}
+
+func (d *DeadLetterCmd) GetDeadLetterCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "deadLetter",
+		Short: "print dead letters as json lines",
+		Run: func(cmdLines *cobra.Command, args []string) {
+			if err := d.initConfig(cmdLines); err != nil {
+				panic(err)
+			}
+			ctx := mcontext.NewCtx(utils.GetSelfFuncName())
+			records, err := tools.InitDeadLetterTool().GetDeadLetters(ctx, d.getPartitionFlag(cmdLines),
+				d.getOffsetFlag(cmdLines), int(d.getLimitFlag(cmdLines)))
+			for _, record := range records {
+				view := deadLetterView{
+					Partition:       record.Partition,
+					Offset:          record.Offset,
+					Topic:           record.Topic,
+					SourcePartition: record.DeadLetter.Partition,
+					SourceOffset:    record.DeadLetter.Offset,
+					Source:          record.Source,
+					Reason:          record.Reason,
+					Attempts:        record.Attempts,
+					Time:            record.Time,
+					Key:             record.Key,
+					Headers:         make(map[string]string),
+					Value:           record.Value,
+				}
+				for _, h := range record.Headers {
+					view.Headers[string(h.Key)] = string(h.Value)
+				}
+				data, _ := json.Marshal(view)
+				fmt.Println(string(data))
+			}
+			if err != nil {
+				panic(err)
+			}
+		},
+	}
+}
+
+func (d *DeadLetterCmd) ReplayDeadLetterCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "deadLetter",
+		Short: "send dead letters back to the topics they failed on",
+		Run: func(cmdLines *cobra.Command, args []string) {
+			if err := d.initConfig(cmdLines); err != nil {
+				panic(err)
+			}
+			ctx := mcontext.NewCtx(utils.GetSelfFuncName())
+			num, err := tools.InitDeadLetterTool().ReplayDeadLetters(ctx, d.getPartitionFlag(cmdLines),
+				d.getOffsetFlag(cmdLines), int(d.getLimitFlag(cmdLines)))
+			fmt.Println("replayed", num)
+			if err != nil {
+				panic(err)
+			}
+		},
+	}
+}
@@ -25,9 +25,14 @@ var Config configStruct
 const ConfKey = "conf"
 
 type CallBackConfig struct {
-	Enable                 bool  `yaml:"enable"`
-	CallbackTimeOut        int   `yaml:"timeout"`
-	CallbackFailedContinue *bool `yaml:"failedContinue"`
+	Enable                 bool       `yaml:"enable"`
+	CallbackTimeOut        int        `yaml:"timeout"`
+	CallbackFailedContinue *bool      `yaml:"failedContinue"`
+	URLs                   []string   `yaml:"urls"`      // 为空时使用callback.url
+	Retry                  KafkaRetry `yaml:"retry"`     // 请求失败重试
+	Async                  bool       `yaml:"async"`     // 写入kafka异步回调, 只用于after回调
+	Transport              string     `yaml:"transport"` // http(默认)或grpc, grpc只用于before send回调
+	GrpcAddr               string     `yaml:"grpcAddr"`  // transport为grpc时的地址
 }
 
 type NotificationConf struct {
@@ -37,6 +42,17 @@ type NotificationConf struct {
 	OfflinePush      POfflinePush `yaml:"offlinePush"`
 }
 
+type CallbackSecret struct {
+	KeyID  string `yaml:"keyID"`
+	Secret string `yaml:"secret"`
+}
+
+type KafkaRetry struct {
+	MaxAttempts int `yaml:"maxAttempts"`
+	Backoff     int `yaml:"backoff"`
+	MaxBackoff  int `yaml:"maxBackoff"`
+}
+
 type POfflinePush struct {
 	Enable bool   `yaml:"enable"`
 	Title  string `yaml:"title"`
@@ -97,7 +113,20 @@ type configStruct struct {
 			MsgToMongo string `yaml:"msgToMongo"`
 			MsgToMySql string `yaml:"msgToMySql"`
 			MsgToPush  string `yaml:"msgToPush"`
+			Callback   string `yaml:"callback"`
 		} `yaml:"consumerGroupID"`
+		DeadLetter struct {
+			Topic string `yaml:"topic"`
+		} `yaml:"deadLetter"`
+		Callback struct {
+			Topic string `yaml:"topic"`
+		} `yaml:"callback"`
+		Retry struct {
+			MsgToRedis KafkaRetry `yaml:"msgToRedis"`
+			MsgToMongo KafkaRetry `yaml:"msgToMongo"`
+			MsgToMySql KafkaRetry `yaml:"msgToMySql"`
+			Callback   KafkaRetry `yaml:"callback"`
+		} `yaml:"retry"`
 	} `yaml:"kafka"`
 
 	Rpc struct {
@@ -176,6 +205,38 @@ type configStruct struct {
 		WebsocketMaxConnNum int   `yaml:"websocketMaxConnNum"`
 		WebsocketMaxMsgLen  int   `yaml:"websocketMaxMsgLen"`
 		WebsocketTimeout    int   `yaml:"websocketTimeout"`
+		GatewayRoute        struct {
+			Enable bool `yaml:"enable"`
+			Expire int  `yaml:"expire"`
+		} `yaml:"gatewayRoute"`
+		Compression struct {
+			MinSize           int  `yaml:"minSize"`
+			PermessageDeflate bool `yaml:"permessageDeflate"`
+		} `yaml:"compression"`
+		Drain struct {
+			Window  int `yaml:"window"`
+			Timeout int `yaml:"timeout"`
+		} `yaml:"drain"`
+		RateLimit struct {
+			Enable        bool `yaml:"enable"`
+			KickThreshold int  `yaml:"kickThreshold"`
+			Limits        []struct {
+				ReqIdentifier int32   `yaml:"reqIdentifier"`
+				ConnRate      float64 `yaml:"connRate"`
+				ConnBurst     int     `yaml:"connBurst"`
+				UserRate      float64 `yaml:"userRate"`
+				UserBurst     int     `yaml:"userBurst"`
+			} `yaml:"limits"`
+		} `yaml:"rateLimit"`
+		Resume struct {
+			MaxMsgNum int64 `yaml:"maxMsgNum"`
+		} `yaml:"resume"`
+		PushAck struct {
+			Enable        bool `yaml:"enable"`
+			Window        int  `yaml:"window"`
+			RetryInterval int  `yaml:"retryInterval"`
+		} `yaml:"pushAck"`
+		TrustedProxies []string `yaml:"trustedProxies"`
 	} `yaml:"longConnSvr"`
 
 	Push struct {
@@ -202,6 +263,13 @@ type configStruct struct {
 		UserID   []string `yaml:"userID"`
 		Nickname []string `yaml:"nickname"`
 	} `yaml:"manager"`
+	MultiLoginRules []struct {
+		Name        string `yaml:"name"`
+		Class       string `yaml:"class"`
+		PlatformIDs []int  `yaml:"platformIDs"`
+		MaxSessions int    `yaml:"maxSessions"`
+		OnExceed    string `yaml:"onExceed"`
+	} `yaml:"multiLoginRules"`
 
 	MultiLoginPolicy                  int    `yaml:"multiLoginPolicy"`
 	ChatPersistenceMysql              bool   `yaml:"chatPersistenceMysql"`
@@ -213,11 +281,42 @@ type configStruct struct {
 	MsgDestructTime                   string `yaml:"msgDestructTime"`
 	Secret                            string `yaml:"secret"`
 	TokenPolicy                       struct {
-		Expire int64 `yaml:"expire"`
+		Expire        int64 `yaml:"expire"`
+		AccessExpire  int64 `yaml:"accessExpire"`
+		RefreshExpire int64 `yaml:"refreshExpire"`
+		Signing       struct {
+			Algorithm string `yaml:"algorithm"` // HS256(使用secret), RS256, EdDSA
+			KeyID     string `yaml:"keyID"`     // 签名使用的key
+			Keys      []struct {
+				KeyID          string `yaml:"keyID"`
+				PrivateKeyFile string `yaml:"privateKeyFile"`
+				PublicKeyFile  string `yaml:"publicKeyFile"`
+			} `yaml:"keys"`
+			AcceptHS256 bool `yaml:"acceptHS256"` // 使用非对称签名时是否仍接受secret签名的token
+		} `yaml:"signing"`
 	} `yaml:"tokenPolicy"`
 	MessageVerify struct {
 		FriendVerify *bool `yaml:"friendVerify"`
 	} `yaml:"messageVerify"`
+	// 撤回时限(秒), 超过发送时间该时长后不可撤回, 0为不限制, app管理员不受限制
+	MsgRevoke struct {
+		SingleChatMaxAge int64 `yaml:"singleChatMaxAge"`
+		GroupChatMaxAge  int64 `yaml:"groupChatMaxAge"`
+	} `yaml:"msgRevoke"`
+	// 定时消息, sendTime晚于当前时间minDelay秒以上时暂存, 由crontask按dispatchTime投递到期的消息
+	ScheduledMsg struct {
+		Enable       bool   `yaml:"enable"`
+		MinDelay     int64  `yaml:"minDelay"`
+		MaxDelay     int64  `yaml:"maxDelay"`
+		DispatchTime string `yaml:"dispatchTime"`
+		MaxAttempts  int32  `yaml:"maxAttempts"`
+		RetryBackoff int64  `yaml:"retryBackoff"`
+		LockTimeout  int64  `yaml:"lockTimeout"`
+	} `yaml:"scheduledMsg"`
+	// 消息置顶, 每个会话最多置顶maxNum条, 0为不限制
+	MsgPin struct {
+		MaxNum int64 `yaml:"maxNum"`
+	} `yaml:"msgPin"`
 
 	IOSPush struct {
 		PushSound  string `yaml:"pushSound"`
@@ -241,6 +340,11 @@ type configStruct struct {
 		CallbackBeforeCreateGroup          CallBackConfig `yaml:"beforeCreateGroup"`
 		CallbackBeforeMemberJoinGroup      CallBackConfig `yaml:"beforeMemberJoinGroup"`
 		CallbackBeforeSetGroupMemberInfo   CallBackConfig `yaml:"beforeSetGroupMemberInfo"`
+		// 回调签名, 配置多个secret时每个都签名, 用于轮换
+		Sign struct {
+			Enable  bool             `yaml:"enable"`
+			Secrets []CallbackSecret `yaml:"secrets"`
+		} `yaml:"sign"`
 	} `yaml:"callback"`
 
 	Prometheus struct {
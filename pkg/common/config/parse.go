@@ -16,6 +16,7 @@ package config
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
 	"os"
@@ -95,5 +96,48 @@ func InitConfig(configFolderPath string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkCallbackSign(); err != nil {
+		return err
+	}
+	return checkCallbackAsync()
+}
+
+// checkCallbackSign 开启回调签名时每个secret都必须配置, 不使用默认值.
+func checkCallbackSign() error {
+	sign := Config.Callback.Sign
+	if !sign.Enable {
+		return nil
+	}
+	if len(sign.Secrets) == 0 {
+		return errors.New("callback.sign is enabled but no secret is set")
+	}
+	for _, secret := range sign.Secrets {
+		if secret.Secret == "" {
+			return fmt.Errorf("callback.sign secret %q is empty", secret.KeyID)
+		}
+	}
+	return nil
+}
+
+// checkCallbackAsync 异步回调不等待响应, before回调的拒绝和修改会失效, 只有after回调可以配置async.
+func checkCallbackAsync() error {
+	callback := Config.Callback
+	beforeCallbacks := map[string]CallBackConfig{
+		"beforeSendSingleMsg":      callback.CallbackBeforeSendSingleMsg,
+		"beforeSendGroupMsg":       callback.CallbackBeforeSendGroupMsg,
+		"msgModify":                callback.CallbackMsgModify,
+		"offlinePush":              callback.CallbackOfflinePush,
+		"onlinePush":               callback.CallbackOnlinePush,
+		"superGroupOnlinePush":     callback.CallbackBeforeSuperGroupOnlinePush,
+		"beforeAddFriend":          callback.CallbackBeforeAddFriend,
+		"beforeCreateGroup":        callback.CallbackBeforeCreateGroup,
+		"beforeMemberJoinGroup":    callback.CallbackBeforeMemberJoinGroup,
+		"beforeSetGroupMemberInfo": callback.CallbackBeforeSetGroupMemberInfo,
+	}
+	for name, conf := range beforeCallbacks {
+		if conf.Async {
+			return fmt.Errorf("callback.%s: async is only supported by after callbacks", name)
+		}
+	}
 	return nil
 }
@@ -55,13 +55,20 @@ const (
 	sendMsgFailedFlag       = "SEND_MSG_FAILED_FLAG:"
 	userBadgeUnreadCountSum = "USER_BADGE_UNREAD_COUNT_SUM:"
 	exTypeKeyLocker         = "EX_LOCK:"
+	exReactionUpdated       = "EX_REACTION_UPDATED:"
 	uidPidToken             = "UID_PID_TOKEN_STATUS:"
+	tokenUsed               = "TOKEN_USED:"
+	userSession             = "USER_SESSION:"
+	userGatewayRoute        = "USER_GATEWAY_ROUTE:"
+	gatewayAlive            = "GATEWAY_ALIVE:"
 )
 
 type SeqCache interface {
 	SetMaxSeq(ctx context.Context, conversationID string, maxSeq int64) error
 	GetMaxSeqs(ctx context.Context, conversationIDs []string) (map[string]int64, error)
 	GetMaxSeq(ctx context.Context, conversationID string) (int64, error)
+	// IncrMaxSeq 原子分配size个seq, 返回分配后的最大seq
+	IncrMaxSeq(ctx context.Context, conversationID string, size int64) (int64, error)
 	SetMinSeq(ctx context.Context, conversationID string, minSeq int64) error
 	SetMinSeqs(ctx context.Context, seqs map[string]int64) error
 	GetMinSeqs(ctx context.Context, conversationIDs []string) (map[string]int64, error)
@@ -96,13 +103,33 @@ type thirdCache interface {
 	GetGetuiTaskID(ctx context.Context) (string, error)
 }
 
+// routeCache records which msggateway instances hold connections of a user.
+// every entry carries its own deadline, a gateway that stops refreshing is treated as stale.
+type routeCache interface {
+	SetUserGatewayRoutes(ctx context.Context, gatewayAddr string, userIDs []string, expire time.Duration) error
+	DelUserGatewayRoute(ctx context.Context, gatewayAddr string, userID string) error
+	// k: userID, v: alive gateway addrs
+	GetUserGatewayRoutes(ctx context.Context, userIDs []string) (map[string][]string, error)
+	SetGatewayAlive(ctx context.Context, gatewayAddr string, expire time.Duration) error
+	DelGatewayAlive(ctx context.Context, gatewayAddr string) error
+	// return the gateway addrs whose alive key has not expired
+	GetAliveGateways(ctx context.Context, gatewayAddrs []string) ([]string, error)
+}
+
 type MsgModel interface {
 	SeqCache
 	thirdCache
+	routeCache
 	AddTokenFlag(ctx context.Context, userID string, platformID int, token string, flag int) error
 	GetTokensWithoutError(ctx context.Context, userID string, platformID int) (map[string]int, error)
 	SetTokenMapByUidPid(ctx context.Context, userID string, platformID int, m map[string]int) error
 	DeleteTokenByUidPid(ctx context.Context, userID string, platformID int, fields []string) error
+	// 标记token已被使用, 只有第一次调用返回true
+	MarkTokenUsed(ctx context.Context, token string, expire time.Duration) (bool, error)
+	// 登录会话的信息, 按字段更新
+	SetSessionInfo(ctx context.Context, userID, sessionID string, info map[string]any, expire time.Duration) error
+	GetSessionInfo(ctx context.Context, userID, sessionID string) (map[string]string, error)
+	DelSessionInfo(ctx context.Context, userID string, sessionIDs ...string) error
 	GetMessagesBySeq(
 		ctx context.Context,
 		conversationID string,
@@ -120,6 +147,10 @@ type MsgModel interface {
 	JudgeMessageReactionExist(ctx context.Context, clientMsgID string, sessionType int32) (bool, error)
 	GetOneMessageAllReactionList(ctx context.Context, clientMsgID string, sessionType int32) (map[string]string, error)
 	DeleteOneMessageKey(ctx context.Context, clientMsgID string, sessionType int32, subKey string) error
+	DeleteMessageReaction(ctx context.Context, clientMsgID string, sessionType int32) error
+	// 标记消息的回应已修改并删除回应缓存, 标记存在期间读到旧数据的缓存回填会被撤销
+	MarkMessageReactionUpdated(ctx context.Context, clientMsgID string, sessionType int32, expire time.Duration) error
+	JudgeMessageReactionUpdated(ctx context.Context, clientMsgID string, sessionType int32) (bool, error)
 	SetMessageReactionExpire(
 		ctx context.Context,
 		clientMsgID string,
@@ -214,6 +245,10 @@ func (c *msgCache) GetMaxSeq(ctx context.Context, conversationID string) (int64,
 	return c.getSeq(ctx, conversationID, c.getMaxSeqKey)
 }
 
+func (c *msgCache) IncrMaxSeq(ctx context.Context, conversationID string, size int64) (int64, error) {
+	return utils.Wrap2(c.rdb.IncrBy(ctx, c.getMaxSeqKey(conversationID), size).Result())
+}
+
 func (c *msgCache) SetMinSeq(ctx context.Context, conversationID string, minSeq int64) error {
 	return c.setSeq(ctx, conversationID, minSeq, c.getMinSeqKey)
 }
@@ -347,6 +382,141 @@ func (c *msgCache) DeleteTokenByUidPid(ctx context.Context, userID string, platf
 	return errs.Wrap(c.rdb.HDel(ctx, key, fields...).Err())
 }
 
+func (c *msgCache) MarkTokenUsed(ctx context.Context, token string, expire time.Duration) (bool, error) {
+	ok, err := c.rdb.SetNX(ctx, tokenUsed+utils.Md5(token), 1, expire).Result()
+	return ok, errs.Wrap(err)
+}
+
+func (c *msgCache) getSessionKey(userID, sessionID string) string {
+	return userSession + userID + ":" + sessionID
+}
+
+func (c *msgCache) SetSessionInfo(
+	ctx context.Context,
+	userID, sessionID string,
+	info map[string]any,
+	expire time.Duration,
+) error {
+	key := c.getSessionKey(userID, sessionID)
+	pipe := c.rdb.TxPipeline()
+	pipe.HSet(ctx, key, info)
+	pipe.Expire(ctx, key, expire)
+	_, err := pipe.Exec(ctx)
+	return errs.Wrap(err)
+}
+
+func (c *msgCache) GetSessionInfo(ctx context.Context, userID, sessionID string) (map[string]string, error) {
+	return utils.Wrap2(c.rdb.HGetAll(ctx, c.getSessionKey(userID, sessionID)).Result())
+}
+
+func (c *msgCache) DelSessionInfo(ctx context.Context, userID string, sessionIDs ...string) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, c.getSessionKey(userID, sessionID))
+	}
+	return errs.Wrap(c.rdb.Del(ctx, keys...).Err())
+}
+
+func (c *msgCache) getUserGatewayRouteKey(userID string) string {
+	return userGatewayRoute + userID
+}
+
+func (c *msgCache) SetUserGatewayRoutes(
+	ctx context.Context,
+	gatewayAddr string,
+	userIDs []string,
+	expire time.Duration,
+) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	deadline := time.Now().Add(expire).UnixMilli()
+	pipe := c.rdb.Pipeline()
+	for _, userID := range userIDs {
+		key := c.getUserGatewayRouteKey(userID)
+		if err := pipe.HSet(ctx, key, gatewayAddr, deadline).Err(); err != nil {
+			return errs.Wrap(err)
+		}
+		if err := pipe.Expire(ctx, key, expire).Err(); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+	_, err := pipe.Exec(ctx)
+	return errs.Wrap(err)
+}
+
+func (c *msgCache) DelUserGatewayRoute(ctx context.Context, gatewayAddr string, userID string) error {
+	return errs.Wrap(c.rdb.HDel(ctx, c.getUserGatewayRouteKey(userID), gatewayAddr).Err())
+}
+
+func (c *msgCache) GetUserGatewayRoutes(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	if len(userIDs) == 0 {
+		return map[string][]string{}, nil
+	}
+	pipe := c.rdb.Pipeline()
+	for _, userID := range userIDs {
+		if err := pipe.HGetAll(ctx, c.getUserGatewayRouteKey(userID)).Err(); err != nil {
+			return nil, errs.Wrap(err)
+		}
+	}
+	result, err := pipe.Exec(ctx)
+	if err != nil && err != redis.Nil {
+		return nil, errs.Wrap(err)
+	}
+	now := time.Now().UnixMilli()
+	m := make(map[string][]string, len(userIDs))
+	for i, v := range result {
+		cmd := v.(*redis.MapStringStringCmd)
+		if cmd.Err() != nil && cmd.Err() != redis.Nil {
+			return nil, errs.Wrap(cmd.Err())
+		}
+		for addr, deadline := range cmd.Val() {
+			if utils.StringToInt64(deadline) > now {
+				m[userIDs[i]] = append(m[userIDs[i]], addr)
+			}
+		}
+	}
+	return m, nil
+}
+
+func (c *msgCache) SetGatewayAlive(ctx context.Context, gatewayAddr string, expire time.Duration) error {
+	return errs.Wrap(c.rdb.Set(ctx, gatewayAlive+gatewayAddr, time.Now().UnixMilli(), expire).Err())
+}
+
+func (c *msgCache) DelGatewayAlive(ctx context.Context, gatewayAddr string) error {
+	return errs.Wrap(c.rdb.Del(ctx, gatewayAlive+gatewayAddr).Err())
+}
+
+func (c *msgCache) GetAliveGateways(ctx context.Context, gatewayAddrs []string) ([]string, error) {
+	if len(gatewayAddrs) == 0 {
+		return nil, nil
+	}
+	pipe := c.rdb.Pipeline()
+	for _, addr := range gatewayAddrs {
+		if err := pipe.Exists(ctx, gatewayAlive+addr).Err(); err != nil {
+			return nil, errs.Wrap(err)
+		}
+	}
+	result, err := pipe.Exec(ctx)
+	if err != nil && err != redis.Nil {
+		return nil, errs.Wrap(err)
+	}
+	var alive []string
+	for i, v := range result {
+		n, err := v.(*redis.IntCmd).Result()
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		if n > 0 {
+			alive = append(alive, gatewayAddrs[i])
+		}
+	}
+	return alive, nil
+}
+
 func (c *msgCache) getMessageCacheKey(conversationID string, seq int64) string {
 	return messageCache + conversationID + "_" + strconv.Itoa(int(seq))
 }
@@ -681,3 +851,29 @@ func (c *msgCache) DeleteOneMessageKey(
 ) error {
 	return errs.Wrap(c.rdb.HDel(ctx, c.getMessageReactionExPrefix(clientMsgID, sessionType), subKey).Err())
 }
+
+func (c *msgCache) DeleteMessageReaction(ctx context.Context, clientMsgID string, sessionType int32) error {
+	return errs.Wrap(c.rdb.Del(ctx, c.getMessageReactionExPrefix(clientMsgID, sessionType)).Err())
+}
+
+func (c *msgCache) MarkMessageReactionUpdated(
+	ctx context.Context,
+	clientMsgID string,
+	sessionType int32,
+	expire time.Duration,
+) error {
+	key := c.getMessageReactionExPrefix(clientMsgID, sessionType)
+	pipe := c.rdb.TxPipeline()
+	pipe.Set(ctx, exReactionUpdated+key, 1, expire)
+	pipe.Del(ctx, key)
+	_, err := pipe.Exec(ctx)
+	return errs.Wrap(err)
+}
+
+func (c *msgCache) JudgeMessageReactionUpdated(ctx context.Context, clientMsgID string, sessionType int32) (bool, error) {
+	n, err := c.rdb.Exists(ctx, exReactionUpdated+c.getMessageReactionExPrefix(clientMsgID, sessionType)).Result()
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return n > 0, nil
+}
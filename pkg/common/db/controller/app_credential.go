@@ -0,0 +1,202 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+// 签名凭证jwt的最长有效期, 防止长期有效的jwt泄露后被重放.
+const maxAppAssertionExpire = time.Minute * 5
+
+type AppCredentialDatabase interface {
+	// 创建凭证, 返回的secret只有这一次能拿到
+	CreateCredential(ctx context.Context, credential *relation.AppCredentialModel) (secret string, err error)
+	TakeCredential(ctx context.Context, clientID string) (*relation.AppCredentialModel, error)
+	RevokeCredential(ctx context.Context, clientID string) error
+	PageCredentials(ctx context.Context, pageNumber, showNumber int32) ([]*relation.AppCredentialModel, int64, error)
+	// 使用secret或签名的jwt认证凭证, 并检查是否有scope权限
+	VerifyCredential(ctx context.Context, clientID, secret, assertion, scope string) (*relation.AppCredentialModel, error)
+	CreateLog(ctx context.Context, log *relation.AppCredentialLogModel) error
+	PageLogs(ctx context.Context, clientID string, pageNumber, showNumber int32) ([]*relation.AppCredentialLogModel, int64, error)
+}
+
+type appCredentialDatabase struct {
+	credential relation.AppCredentialModelInterface
+	log        relation.AppCredentialLogModelInterface
+	cache      cache.MsgModel
+}
+
+func NewAppCredentialDatabase(
+	credential relation.AppCredentialModelInterface,
+	log relation.AppCredentialLogModelInterface,
+	cache cache.MsgModel,
+) AppCredentialDatabase {
+	return &appCredentialDatabase{credential: credential, log: log, cache: cache}
+}
+
+func (a *appCredentialDatabase) CreateCredential(
+	ctx context.Context,
+	credential *relation.AppCredentialModel,
+) (string, error) {
+	clientID, err := randomHex(8)
+	if err != nil {
+		return "", err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", err
+	}
+	credential.ClientID = "app_" + clientID
+	credential.SecretHash = hashSecret(secret)
+	credential.Status = relation.AppCredentialNormal
+	credential.CreateTime = time.Now()
+	if err := a.credential.Create(ctx, credential); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+func (a *appCredentialDatabase) TakeCredential(ctx context.Context, clientID string) (*relation.AppCredentialModel, error) {
+	return a.credential.Take(ctx, clientID)
+}
+
+func (a *appCredentialDatabase) RevokeCredential(ctx context.Context, clientID string) error {
+	return a.credential.UpdateStatus(ctx, clientID, relation.AppCredentialRevoked, time.Now())
+}
+
+func (a *appCredentialDatabase) PageCredentials(
+	ctx context.Context,
+	pageNumber, showNumber int32,
+) ([]*relation.AppCredentialModel, int64, error) {
+	return a.credential.Page(ctx, pageNumber, showNumber)
+}
+
+func (a *appCredentialDatabase) VerifyCredential(
+	ctx context.Context,
+	clientID, secret, assertion, scope string,
+) (*relation.AppCredentialModel, error) {
+	credential, err := a.credential.Take(ctx, clientID)
+	if err != nil {
+		if relation.IsNotFound(err) {
+			return nil, errs.ErrNoPermission.Wrap("app credential not found")
+		}
+		return nil, err
+	}
+	if credential.Status != relation.AppCredentialNormal {
+		return nil, errs.ErrNoPermission.Wrap("app credential revoked")
+	}
+	switch {
+	case secret != "":
+		if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(credential.SecretHash)) != 1 {
+			return nil, errs.ErrNoPermission.Wrap("app credential secret invalid")
+		}
+	case assertion != "":
+		if err := a.verifyAssertion(ctx, credential, assertion); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errs.ErrArgs.Wrap("app credential secret or assertion is required")
+	}
+	if !utils.IsContain(scope, SplitAppCredentialScopes(credential.Scopes)) {
+		return nil, errs.ErrNoPermission.Wrap("app credential has no scope " + scope)
+	}
+	return credential, nil
+}
+
+// verifyAssertion 凭证的私钥签名的jwt, iss为clientID, 必须带jti且只能使用一次.
+func (a *appCredentialDatabase) verifyAssertion(
+	ctx context.Context,
+	credential *relation.AppCredentialModel,
+	assertion string,
+) error {
+	if credential.PublicKey == "" {
+		return errs.ErrNoPermission.Wrap("app credential has no public key")
+	}
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(assertion, claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA:
+			return jwt.ParseRSAPublicKeyFromPEM([]byte(credential.PublicKey))
+		case *jwt.SigningMethodEd25519:
+			return jwt.ParseEdPublicKeyFromPEM([]byte(credential.PublicKey))
+		default:
+			return nil, errs.ErrNoPermission.Wrap("unexpected signing method " + token.Method.Alg())
+		}
+	})
+	if err != nil {
+		return errs.ErrNoPermission.Wrap("app credential assertion invalid " + err.Error())
+	}
+	if claims.Issuer != credential.ClientID || claims.ID == "" || claims.ExpiresAt == nil {
+		return errs.ErrNoPermission.Wrap("app credential assertion must have iss, jti and exp")
+	}
+	expire := time.Until(claims.ExpiresAt.Time)
+	if expire > maxAppAssertionExpire {
+		return errs.ErrNoPermission.Wrap("app credential assertion expires too late")
+	}
+	first, err := a.cache.MarkTokenUsed(ctx, credential.ClientID+":"+claims.ID, expire)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return errs.ErrNoPermission.Wrap("app credential assertion reused")
+	}
+	return nil
+}
+
+func (a *appCredentialDatabase) CreateLog(ctx context.Context, log *relation.AppCredentialLogModel) error {
+	return a.log.Create(ctx, log)
+}
+
+func (a *appCredentialDatabase) PageLogs(
+	ctx context.Context,
+	clientID string,
+	pageNumber, showNumber int32,
+) ([]*relation.AppCredentialLogModel, int64, error) {
+	return a.log.Page(ctx, clientID, pageNumber, showNumber)
+}
+
+func SplitAppCredentialScopes(scopes string) []string {
+	if scopes == "" {
+		return nil
+	}
+	return strings.Split(scopes, ",")
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", errs.Wrap(err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -16,32 +16,64 @@ package controller
 
 import (
 	"context"
-	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
-
-	"github.com/golang-jwt/jwt/v4"
+	"sort"
+	"time"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/loginpolicy"
 	"github.com/OpenIMSDK/protocol/constant"
-	"github.com/OpenIMSDK/tools/tokenverify"
+	"github.com/OpenIMSDK/tools/errs"
 	"github.com/OpenIMSDK/tools/utils"
 )
 
+// ErrRefreshTokenReused 刷新token被重复使用, 所属token族已被吊销.
+var ErrRefreshTokenReused = errs.NewCodeError(errs.TokenInvalidError, "RefreshTokenReused")
+
 type AuthDatabase interface {
 	// 结果为空 不返回错误
 	GetTokensWithoutError(ctx context.Context, userID string, platformID int) (map[string]int, error)
-	// 创建token
-	CreateToken(ctx context.Context, userID string, platformID int) (string, error)
+	// 创建token, 按多端登录策略踢下线其他会话或拒绝登录
+	CreateToken(ctx context.Context, userID string, platformID int) (*LoginTokens, error)
+	// 创建访问token和刷新token, 刷新token有效期为0时不创建刷新token
+	CreateTokens(ctx context.Context, userID string, platformID int) (*LoginTokens, error)
+	// 使用刷新token换取新的访问token和刷新token, 重复使用刷新token时吊销同一token族的所有token并返回ErrRefreshTokenReused
+	RefreshToken(ctx context.Context, claims *authverify.TokenClaims, refreshToken string) (token string, newRefreshToken string, err error)
+	// 用户所有平台上仍有可用token的登录会话
+	GetSessions(ctx context.Context, userID string) ([]*Session, error)
+	// 会话的所有token标记为被踢
+	KickSession(ctx context.Context, session *Session) error
+	// 会话sessionID的token存入后按多端登录策略踢下线其他会话, 返回被踢的会话, 策略拒绝登录时该会话被踢并返回错误
+	ApplyLoginPolicy(ctx context.Context, userID string, platformID int, sessionID string) ([]*Session, error)
+}
+
+// LoginTokens 一次登录签发的token, 以及因为这次登录被踢下线的会话.
+type LoginTokens struct {
+	Token          string
+	RefreshToken   string
+	KickedSessions []*Session
+}
+
+// Session 一次登录, 由同一token族的token组成.
+type Session struct {
+	SessionID    string
+	UserID       string
+	PlatformID   int
+	LoginTime    int64
+	LastSeenTime int64
+	IP           string
+	Tokens       map[string]int
 }
 
 type authDatabase struct {
 	cache cache.MsgModel
 
-	accessSecret string
-	accessExpire int64
+	accessExpire  time.Duration
+	refreshExpire time.Duration
 }
 
-func NewAuthDatabase(cache cache.MsgModel, accessSecret string, accessExpire int64) AuthDatabase {
-	return &authDatabase{cache: cache, accessSecret: accessSecret, accessExpire: accessExpire}
+func NewAuthDatabase(cache cache.MsgModel, accessExpire time.Duration, refreshExpire time.Duration) AuthDatabase {
+	return &authDatabase{cache: cache, accessExpire: accessExpire, refreshExpire: refreshExpire}
 }
 
 // 结果为空 不返回错误.
@@ -54,29 +86,253 @@ func (a *authDatabase) GetTokensWithoutError(
 }
 
 // 创建token.
-func (a *authDatabase) CreateToken(ctx context.Context, userID string, platformID int) (string, error) {
+func (a *authDatabase) CreateToken(ctx context.Context, userID string, platformID int) (*LoginTokens, error) {
+	return a.createTokens(ctx, userID, platformID, utils.OperationIDGenerator(), true, false)
+}
+
+func (a *authDatabase) CreateTokens(ctx context.Context, userID string, platformID int) (*LoginTokens, error) {
+	return a.createTokens(ctx, userID, platformID, utils.OperationIDGenerator(), true, a.refreshExpire > 0)
+}
+
+func (a *authDatabase) RefreshToken(
+	ctx context.Context,
+	claims *authverify.TokenClaims,
+	refreshToken string,
+) (string, string, error) {
+	if !claims.IsRefresh() || a.refreshExpire <= 0 {
+		return "", "", errs.ErrTokenInvalid.Wrap("not a refresh token")
+	}
+	tokens, err := a.cache.GetTokensWithoutError(ctx, claims.UserID, claims.PlatformID)
+	if err != nil {
+		return "", "", err
+	}
+	status, ok := tokens[refreshToken]
+	if !ok {
+		return "", "", errs.ErrTokenNotExist.Wrap()
+	}
+	switch status {
+	case authverify.RefreshToken:
+	case authverify.RotatedRefreshToken:
+		return "", "", a.revokeFamily(ctx, claims, tokens)
+	default:
+		return "", "", authverify.CheckTokenStatus(status)
+	}
+	// 并发使用同一个刷新token时只有一个能换取成功
+	first, err := a.cache.MarkTokenUsed(ctx, refreshToken, time.Until(claims.ExpiresAt.Time))
+	if err != nil {
+		return "", "", err
+	}
+	if !first {
+		return "", "", a.revokeFamily(ctx, claims, tokens)
+	}
+	if err := a.cache.AddTokenFlag(ctx, claims.UserID, claims.PlatformID, refreshToken, authverify.RotatedRefreshToken); err != nil {
+		return "", "", err
+	}
+	login, err := a.createTokens(ctx, claims.UserID, claims.PlatformID, claims.FamilyID, false, true)
+	if err != nil {
+		return "", "", err
+	}
+	return login.Token, login.RefreshToken, nil
+}
+
+// revokeFamily 刷新token被重复使用, 说明已经泄露, 同一token族的访问token和刷新token全部失效.
+func (a *authDatabase) revokeFamily(ctx context.Context, claims *authverify.TokenClaims, tokens map[string]int) error {
+	revoked := make(map[string]int)
+	for k := range tokens {
+		c, err := authverify.GetTokenClaims(k)
+		if err == nil && c.FamilyID == claims.FamilyID {
+			revoked[k] = authverify.RevokedToken
+		}
+	}
+	if len(revoked) != 0 {
+		if err := a.cache.SetTokenMapByUidPid(ctx, claims.UserID, claims.PlatformID, revoked); err != nil {
+			return err
+		}
+	}
+	if err := a.cache.DelSessionInfo(ctx, claims.UserID, claims.FamilyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused.Wrap("all tokens of the login revoked")
+}
+
+func (a *authDatabase) GetSessions(ctx context.Context, userID string) ([]*Session, error) {
+	var sessions []*Session
+	for platformID := range constant.PlatformID2Name {
+		tokens, err := a.cache.GetTokensWithoutError(ctx, userID, platformID)
+		if err != nil {
+			return nil, err
+		}
+		platformSessions := make(map[string]*Session)
+		for token, status := range tokens {
+			claims, err := authverify.GetTokenClaims(token)
+			if err != nil {
+				continue
+			}
+			sessionID := authverify.SessionID(token, claims)
+			session, ok := platformSessions[sessionID]
+			if !ok {
+				session = &Session{
+					SessionID:  sessionID,
+					UserID:     userID,
+					PlatformID: platformID,
+					Tokens:     make(map[string]int),
+				}
+				platformSessions[sessionID] = session
+			}
+			session.Tokens[token] = status
+			if issuedAt := claims.IssuedAt.UnixMilli(); session.LoginTime == 0 || issuedAt < session.LoginTime {
+				session.LoginTime = issuedAt
+			}
+		}
+		for _, session := range platformSessions {
+			var active bool
+			for _, status := range session.Tokens {
+				if status == constant.NormalToken || status == authverify.RefreshToken {
+					active = true
+					break
+				}
+			}
+			if !active {
+				continue
+			}
+			info, err := a.cache.GetSessionInfo(ctx, userID, session.SessionID)
+			if err != nil {
+				return nil, err
+			}
+			if v, ok := info["loginTime"]; ok {
+				session.LoginTime = utils.StringToInt64(v)
+			}
+			session.LastSeenTime = utils.StringToInt64(info["lastSeenTime"])
+			session.IP = info["ip"]
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenTime > sessions[j].LastSeenTime
+	})
+	return sessions, nil
+}
+
+func (a *authDatabase) KickSession(ctx context.Context, session *Session) error {
+	kicked := make(map[string]int)
+	for token := range session.Tokens {
+		kicked[token] = constant.KickedToken
+	}
+	if err := a.cache.SetTokenMapByUidPid(ctx, session.UserID, session.PlatformID, kicked); err != nil {
+		return err
+	}
+	return a.cache.DelSessionInfo(ctx, session.UserID, session.SessionID)
+}
+
+func (a *authDatabase) ApplyLoginPolicy(
+	ctx context.Context,
+	userID string,
+	platformID int,
+	sessionID string,
+) ([]*Session, error) {
+	policy, err := loginpolicy.Default()
+	if err != nil {
+		return nil, err
+	}
+	if !policy.Limited(platformID) {
+		return nil, nil
+	}
+	sessions, err := a.GetSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var current *Session
+	all := make([]loginpolicy.Session, 0, len(sessions))
+	sessionMap := make(map[string]*Session)
+	for _, session := range sessions {
+		if session.SessionID == sessionID {
+			current = session
+		}
+		all = append(all, loginpolicy.Session{ID: session.SessionID, PlatformID: session.PlatformID, LoginTime: session.LoginTime})
+		sessionMap[session.SessionID] = session
+	}
+	if current == nil {
+		// 会话已没有可用的token, 无需检查
+		return nil, nil
+	}
+	kicks, err := policy.Check(all, loginpolicy.Session{ID: current.SessionID, PlatformID: current.PlatformID, LoginTime: current.LoginTime})
+	if err != nil {
+		if loginpolicy.ErrLoginRejected.Is(err) {
+			if err := a.KickSession(ctx, current); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+	kicked := make([]*Session, 0, len(kicks))
+	for _, kick := range kicks {
+		session := sessionMap[kick.ID]
+		if err := a.KickSession(ctx, session); err != nil {
+			return nil, err
+		}
+		kicked = append(kicked, session)
+	}
+	return kicked, nil
+}
+
+func (a *authDatabase) createTokens(
+	ctx context.Context,
+	userID string,
+	platformID int,
+	familyID string,
+	isLogin bool,
+	refresh bool,
+) (*LoginTokens, error) {
+	login := &LoginTokens{}
 	tokens, err := a.cache.GetTokensWithoutError(ctx, userID, platformID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	var deleteTokenKey []string
 	for k, v := range tokens {
-		_, err = tokenverify.GetClaimFromToken(k, authverify.Secret())
-		if err != nil || v != constant.NormalToken {
+		_, err = authverify.GetTokenClaims(k)
+		// 已使用的刷新token保留到过期, 用于发现重复使用
+		if err != nil || (v != constant.NormalToken && v != authverify.RefreshToken && v != authverify.RotatedRefreshToken) {
 			deleteTokenKey = append(deleteTokenKey, k)
 		}
 	}
 	if len(deleteTokenKey) != 0 {
 		err := a.cache.DeleteTokenByUidPid(ctx, userID, platformID, deleteTokenKey)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 	}
-	claims := tokenverify.BuildClaims(userID, platformID, a.accessExpire)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(a.accessSecret))
+	flags := make(map[string]int)
+	login.Token, err = authverify.SignToken(authverify.BuildTokenClaims(userID, platformID, familyID, authverify.TokenTypeAccess, a.accessExpire))
 	if err != nil {
-		return "", utils.Wrap(err, "")
+		return nil, err
+	}
+	flags[login.Token] = constant.NormalToken
+	if refresh {
+		login.RefreshToken, err = authverify.SignToken(authverify.BuildTokenClaims(userID, platformID, familyID, authverify.TokenTypeRefresh, a.refreshExpire))
+		if err != nil {
+			return nil, err
+		}
+		flags[login.RefreshToken] = authverify.RefreshToken
+	}
+	if err := a.cache.SetTokenMapByUidPid(ctx, userID, platformID, flags); err != nil {
+		return nil, err
+	}
+	now := time.Now().UnixMilli()
+	info := map[string]any{"platformID": platformID, "lastSeenTime": now}
+	if isLogin {
+		info["loginTime"] = now
+	}
+	if err := a.cache.SetSessionInfo(ctx, userID, familyID, info, authverify.SessionExpire()); err != nil {
+		return nil, err
+	}
+	if isLogin {
+		// 先存入新token再检查, 并发登录时能互相看到, 不会超过会话数上限
+		kicked, err := a.ApplyLoginPolicy(ctx, userID, platformID, familyID)
+		if err != nil {
+			return nil, err
+		}
+		login.KickedSessions = kicked
 	}
-	return tokenString, a.cache.AddTokenFlag(ctx, userID, platformID, tokenString, constant.NormalToken)
+	return login, nil
 }
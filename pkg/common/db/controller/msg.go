@@ -16,7 +16,9 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -41,6 +43,7 @@ import (
 const (
 	updateKeyMsg = iota
 	updateKeyRevoke
+	updateKeyEdit
 )
 
 type CommonMsgDatabase interface {
@@ -48,6 +51,16 @@ type CommonMsgDatabase interface {
 	BatchInsertChat2DB(ctx context.Context, conversationID string, msgs []*sdkws.MsgData, currentMaxSeq int64) error
 	// 撤回消息
 	RevokeMsg(ctx context.Context, conversationID string, seq int64, revoke *unRelationTb.RevokeModel) error
+	// 编辑消息, 保留编辑前的版本
+	EditMsg(ctx context.Context, conversationID string, msg *sdkws.MsgData, edit *unRelationTb.EditModel) error
+	// 消息回应, 以mongo为准, redis按emoji缓存回应的userID列表
+	AddMsgReaction(ctx context.Context, conversationID string, msg *sdkws.MsgData, reaction *unRelationTb.ReactionModel) (bool, error)
+	RemoveMsgReaction(ctx context.Context, conversationID string, msg *sdkws.MsgData, emoji string, userID string) (bool, error)
+	GetMsgReactions(ctx context.Context, conversationID string, msg *sdkws.MsgData) (map[string][]string, error)
+	// 话题, 回复在threadID下单独分配seq, 根消息上记录回复数和最后回复
+	SendThreadMsg(ctx context.Context, conversationID string, root *sdkws.MsgData, threadID string, msg *sdkws.MsgData) error
+	// GetThreads 用户可见(根消息seq不小于minSeq)的话题
+	GetThreads(ctx context.Context, userID string, conversationID string, minSeq int64, pageNumber int32, showNumber int32) (int64, []*unRelationTb.MsgInfoModel, error)
 	// mark as read
 	MarkSingleChatMsgsAsRead(ctx context.Context, userID string, conversationID string, seqs []int64) error
 	// 刪除redis中消息缓存
@@ -90,9 +103,12 @@ type CommonMsgDatabase interface {
 
 	GetMongoMaxAndMinSeq(ctx context.Context, conversationID string) (minSeqMongo, maxSeqMongo int64, err error)
 	GetConversationMinMaxSeqInMongoAndCache(ctx context.Context, conversationID string) (minSeqMongo, maxSeqMongo, minSeqCache, maxSeqCache int64, err error)
+	// 校验mongo中会话消息的seq与redis max seq是否一致(缺失/重复)
+	VerifyConversationSeqs(ctx context.Context, conversationID string) (*MsgSeqReport, error)
+	// RangeConversationMsgDocs 按文档遍历会话[minSeq, maxSeq]内mongo中的消息
+	RangeConversationMsgDocs(ctx context.Context, conversationID string, fn func(msgs []*unRelationTb.MsgInfoModel) error) error
 	SetSendMsgStatus(ctx context.Context, id string, status int32) error
 	GetSendMsgStatus(ctx context.Context, id string) (int32, error)
-	SearchMessage(ctx context.Context, req *pbMsg.SearchMessageReq) (total int32, msgData []*sdkws.MsgData, err error)
 
 	// to mq
 	MsgToMQ(ctx context.Context, key string, msg2mq *sdkws.MsgData) error
@@ -185,7 +201,7 @@ func (db *commonMsgDatabase) BatchInsertBlock(ctx context.Context, conversationI
 	for i, field := range fields { // 检查类型
 		var ok bool
 		switch key {
-		case updateKeyMsg:
+		case updateKeyMsg, updateKeyEdit:
 			var msg *unRelationTb.MsgDataModel
 			msg, ok = field.(*unRelationTb.MsgDataModel)
 			if msg != nil && msg.Seq != firstSeq+int64(i) {
@@ -211,9 +227,19 @@ func (db *commonMsgDatabase) BatchInsertBlock(ctx context.Context, conversationI
 		field := fields[i]
 		switch key {
 		case updateKeyMsg:
-			res, err = db.msgDocDatabase.UpdateMsg(ctx, docID, index, "msg", field)
+			// 同一seq重复投递时不覆盖已写入的消息
+			exist, set, err := db.msgDocDatabase.UpdateMsgIfAbsent(ctx, docID, index, "msg", field)
+			if err != nil {
+				return false, err
+			}
+			if exist && !set {
+				log.ZDebug(ctx, "msg already in mongo, skip", "conversationID", conversationID, "seq", seq)
+			}
+			return exist, nil
 		case updateKeyRevoke:
 			res, err = db.msgDocDatabase.UpdateMsg(ctx, docID, index, "revoke", field)
+		case updateKeyEdit:
+			res, err = db.msgDocDatabase.UpdateMsg(ctx, docID, index, "msg", field)
 		}
 		if err != nil {
 			return false, err
@@ -244,7 +270,7 @@ func (db *commonMsgDatabase) BatchInsertBlock(ctx context.Context, conversationI
 			}
 			insert++
 			switch key {
-			case updateKeyMsg:
+			case updateKeyMsg, updateKeyEdit:
 				doc.Msg[db.msg.GetMsgIndex(seq)] = &unRelationTb.MsgInfoModel{
 					Msg: fields[j].(*unRelationTb.MsgDataModel),
 				}
@@ -323,6 +349,136 @@ func (db *commonMsgDatabase) BatchInsertChat2DB(ctx context.Context, conversatio
 	return db.BatchInsertBlock(ctx, conversationID, msgs, updateKeyMsg, msgList[0].Seq)
 }
 
+// EditMsg writes the edited msg and appends the replaced version to its edit history. The whole msg is written
+// because it may not be in mongo yet, the original still on its way through msg_to_mongo is then skipped.
+func (db *commonMsgDatabase) EditMsg(ctx context.Context, conversationID string, msg *sdkws.MsgData, edit *unRelationTb.EditModel) error {
+	if err := db.BatchInsertBlock(ctx, conversationID, []any{convert.MsgPb2DB(msg)}, updateKeyEdit, msg.Seq); err != nil {
+		return err
+	}
+	docID := db.msg.GetDocID(conversationID, msg.Seq)
+	index := db.msg.GetMsgIndex(msg.Seq)
+	if _, err := db.msgDocDatabase.PushUnique(ctx, docID, index, "edits", []*unRelationTb.EditModel{edit}); err != nil {
+		return err
+	}
+	if _, err := db.cache.SetMessageToCache(ctx, conversationID, []*sdkws.MsgData{msg}); err != nil {
+		log.ZWarn(ctx, "set edited msg to cache failed", err, "conversationID", conversationID, "seq", msg.Seq)
+		return db.cache.DeleteMessages(ctx, conversationID, []int64{msg.Seq})
+	}
+	return nil
+}
+
+// AddMsgReaction returns false when the user already put the emoji on msg.
+func (db *commonMsgDatabase) AddMsgReaction(ctx context.Context, conversationID string, msg *sdkws.MsgData, reaction *unRelationTb.ReactionModel) (bool, error) {
+	// 消息可能还未写入mongo, 先写入(已存在时不覆盖)
+	if err := db.BatchInsertBlock(ctx, conversationID, []any{convert.MsgPb2DB(msg)}, updateKeyMsg, msg.Seq); err != nil {
+		return false, err
+	}
+	added, err := db.msgDocDatabase.AddReaction(ctx, db.msg.GetDocID(conversationID, msg.Seq), db.msg.GetMsgIndex(msg.Seq), reaction)
+	if err != nil || !added {
+		return false, err
+	}
+	return true, db.cache.MarkMessageReactionUpdated(ctx, msg.ClientMsgID, msg.SessionType, reactionUpdatedExpire)
+}
+
+// RemoveMsgReaction returns false when the user had not put the emoji on msg.
+func (db *commonMsgDatabase) RemoveMsgReaction(ctx context.Context, conversationID string, msg *sdkws.MsgData, emoji string, userID string) (bool, error) {
+	removed, err := db.msgDocDatabase.RemoveReaction(ctx, db.msg.GetDocID(conversationID, msg.Seq), db.msg.GetMsgIndex(msg.Seq), emoji, userID)
+	if err != nil || !removed {
+		return false, err
+	}
+	return true, db.cache.MarkMessageReactionUpdated(ctx, msg.ClientMsgID, msg.SessionType, reactionUpdatedExpire)
+}
+
+// reactionUpdatedExpire must outlast a refill of the reaction cache, a refill that read mongo before an update
+// and wrote the cache after it sees the mark and deletes what it wrote.
+const reactionUpdatedExpire = time.Minute
+
+// GetMsgReactions returns the userIDs of every emoji on msg in the order they reacted.
+func (db *commonMsgDatabase) GetMsgReactions(ctx context.Context, conversationID string, msg *sdkws.MsgData) (map[string][]string, error) {
+	reactions := make(map[string][]string)
+	exist, err := db.cache.JudgeMessageReactionExist(ctx, msg.ClientMsgID, msg.SessionType)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		values, err := db.cache.GetOneMessageAllReactionList(ctx, msg.ClientMsgID, msg.SessionType)
+		if err != nil {
+			return nil, err
+		}
+		for emoji, value := range values {
+			var userIDs []string
+			if err := json.Unmarshal([]byte(value), &userIDs); err != nil {
+				return nil, errs.Wrap(err)
+			}
+			reactions[emoji] = userIDs
+		}
+		return reactions, nil
+	}
+	models, err := db.msgDocDatabase.GetReactions(ctx, db.msg.GetDocID(conversationID, msg.Seq), db.msg.GetMsgIndex(msg.Seq))
+	if err != nil {
+		return nil, err
+	}
+	for _, model := range models {
+		reactions[model.Emoji] = append(reactions[model.Emoji], model.UserID)
+	}
+	for emoji, userIDs := range reactions {
+		data, _ := json.Marshal(userIDs)
+		if err := db.cache.SetMessageTypeKeyValue(ctx, msg.ClientMsgID, msg.SessionType, emoji, string(data)); err != nil {
+			log.ZWarn(ctx, "set reaction cache failed", err, "clientMsgID", msg.ClientMsgID, "emoji", emoji)
+		}
+	}
+	if len(reactions) > 0 {
+		expire := time.Duration(config.Config.MsgCacheTimeout) * time.Second
+		if _, err := db.cache.SetMessageReactionExpire(ctx, msg.ClientMsgID, msg.SessionType, expire); err != nil {
+			log.ZWarn(ctx, "set reaction cache expire failed", err, "clientMsgID", msg.ClientMsgID)
+		}
+		updated, err := db.cache.JudgeMessageReactionUpdated(ctx, msg.ClientMsgID, msg.SessionType)
+		if err != nil || updated {
+			// 读取mongo后回应可能已修改, 不保留这次回填
+			if err := db.cache.DeleteMessageReaction(ctx, msg.ClientMsgID, msg.SessionType); err != nil {
+				log.ZWarn(ctx, "delete reaction cache failed", err, "clientMsgID", msg.ClientMsgID)
+			}
+		}
+	}
+	return reactions, nil
+}
+
+// SendThreadMsg stores msg as a reply of root, the seq of the reply is allocated atomically so replies can be sent concurrently.
+func (db *commonMsgDatabase) SendThreadMsg(ctx context.Context, conversationID string, root *sdkws.MsgData, threadID string, msg *sdkws.MsgData) error {
+	// 根消息可能还未写入mongo, 先写入(已存在时不覆盖)
+	if err := db.BatchInsertBlock(ctx, conversationID, []any{convert.MsgPb2DB(root)}, updateKeyMsg, root.Seq); err != nil {
+		return err
+	}
+	seq, err := db.cache.IncrMaxSeq(ctx, threadID, 1)
+	if err != nil {
+		prome.Inc(prome.SeqSetFailedCounter)
+		return err
+	}
+	msg.Seq = seq
+	msgs := []*sdkws.MsgData{msg}
+	if failedNum, err := db.cache.SetMessageToCache(ctx, threadID, msgs); err != nil {
+		prome.Add(prome.MsgInsertRedisFailedCounter, failedNum)
+		log.ZError(ctx, "setMessageToCache error", err, "threadID", threadID, "seq", seq)
+	}
+	if err := db.cache.SetHasReadSeq(ctx, msg.SendID, threadID, seq); err != nil {
+		log.ZError(ctx, "SetHasReadSeq error", err, "threadID", threadID, "seq", seq)
+	}
+	if err := db.MsgToMongoMQ(ctx, threadID, threadID, msgs, seq-1); err != nil {
+		return err
+	}
+	return db.msgDocDatabase.UpdateThreadReply(ctx, conversationID, root.Seq, &unRelationTb.ThreadModel{
+		ConversationID:       threadID,
+		LastReplySeq:         msg.Seq,
+		LastReplyUserID:      msg.SendID,
+		LastReplyClientMsgID: msg.ClientMsgID,
+		LastReplyTime:        msg.SendTime,
+	})
+}
+
+func (db *commonMsgDatabase) GetThreads(ctx context.Context, userID string, conversationID string, minSeq int64, pageNumber int32, showNumber int32) (int64, []*unRelationTb.MsgInfoModel, error) {
+	return db.msgDocDatabase.FindThreads(ctx, userID, conversationID, minSeq, pageNumber, showNumber)
+}
+
 func (db *commonMsgDatabase) RevokeMsg(ctx context.Context, conversationID string, seq int64, revoke *unRelationTb.RevokeModel) error {
 	return db.BatchInsertBlock(ctx, conversationID, []any{revoke}, updateKeyRevoke, seq)
 }
@@ -912,6 +1068,109 @@ func (db *commonMsgDatabase) GetConversationMinMaxSeqInMongoAndCache(ctx context
 	return
 }
 
+// MsgSeqReport compares the seqs stored in mongo for a conversation with the seq range in redis.
+type MsgSeqReport struct {
+	ConversationID string  `json:"conversationID"`
+	MinSeq         int64   `json:"minSeq"`
+	MaxSeq         int64   `json:"maxSeq"`
+	Missing        []int64 `json:"missing"`    // seqs in [MinSeq, MaxSeq] with no msg in mongo
+	Duplicated     []int64 `json:"duplicated"` // seqs stored more than once
+	Misplaced      []int64 `json:"misplaced"`  // seqs stored in a slot that belongs to another seq
+	Ahead          []int64 `json:"ahead"`      // seqs in mongo greater than the redis max seq
+}
+
+func (r *MsgSeqReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Duplicated) == 0 && len(r.Misplaced) == 0 && len(r.Ahead) == 0
+}
+
+// VerifyConversationSeqs walks the docs holding [minSeq, maxSeq] of the conversation. Messages still waiting
+// in msg_to_mongo and physically deleted ones are reported as missing too.
+func (db *commonMsgDatabase) VerifyConversationSeqs(ctx context.Context, conversationID string) (*MsgSeqReport, error) {
+	minSeq, err := db.cache.GetMinSeq(ctx, conversationID)
+	if err != nil && errs.Unwrap(err) != redis.Nil {
+		return nil, err
+	}
+	maxSeq, err := db.cache.GetMaxSeq(ctx, conversationID)
+	if err != nil && errs.Unwrap(err) != redis.Nil {
+		return nil, err
+	}
+	if minSeq < 1 {
+		minSeq = 1
+	}
+	report := &MsgSeqReport{ConversationID: conversationID, MinSeq: minSeq, MaxSeq: maxSeq}
+	num := db.msg.GetSingleGocMsgNum()
+	stored := make(map[int64]int)
+	// 多检查一个文档, 发现超出max seq的消息
+	for docSeq := (minSeq-1)/num*num + 1; docSeq <= maxSeq+num; docSeq += num {
+		doc, err := db.msgDocDatabase.FindOneByDocID(ctx, db.msg.GetDocID(conversationID, docSeq))
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				continue
+			}
+			return nil, err
+		}
+		for i, model := range doc.Msg {
+			if model == nil || model.Msg == nil || model.Msg.Seq == 0 {
+				continue
+			}
+			seq := model.Msg.Seq
+			stored[seq]++
+			if seq != docSeq+int64(i) {
+				report.Misplaced = append(report.Misplaced, seq)
+			}
+			if seq > maxSeq {
+				report.Ahead = append(report.Ahead, seq)
+			}
+		}
+	}
+	for seq := minSeq; seq <= maxSeq; seq++ {
+		if stored[seq] == 0 {
+			report.Missing = append(report.Missing, seq)
+		}
+	}
+	for seq, count := range stored {
+		if count > 1 {
+			report.Duplicated = append(report.Duplicated, seq)
+		}
+	}
+	sort.Slice(report.Duplicated, func(i, j int) bool { return report.Duplicated[i] < report.Duplicated[j] })
+	return report, nil
+}
+
+func (db *commonMsgDatabase) RangeConversationMsgDocs(ctx context.Context, conversationID string, fn func(msgs []*unRelationTb.MsgInfoModel) error) error {
+	minSeq, err := db.cache.GetMinSeq(ctx, conversationID)
+	if err != nil && errs.Unwrap(err) != redis.Nil {
+		return err
+	}
+	maxSeq, err := db.cache.GetMaxSeq(ctx, conversationID)
+	if err != nil && errs.Unwrap(err) != redis.Nil {
+		return err
+	}
+	if minSeq < 1 {
+		minSeq = 1
+	}
+	num := db.msg.GetSingleGocMsgNum()
+	for docSeq := (minSeq-1)/num*num + 1; docSeq <= maxSeq; docSeq += num {
+		doc, err := db.msgDocDatabase.FindOneByDocID(ctx, db.msg.GetDocID(conversationID, docSeq))
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				continue
+			}
+			return err
+		}
+		msgs := make([]*unRelationTb.MsgInfoModel, 0, len(doc.Msg))
+		for _, model := range doc.Msg {
+			if model != nil && model.Msg != nil && model.Msg.Seq >= minSeq && model.Msg.Seq <= maxSeq {
+				msgs = append(msgs, model)
+			}
+		}
+		if err := fn(msgs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (db *commonMsgDatabase) GetMongoMaxAndMinSeq(ctx context.Context, conversationID string) (minSeqMongo, maxSeqMongo int64, err error) {
 	return db.GetMinMaxSeqMongo(ctx, conversationID)
 }
@@ -952,15 +1211,3 @@ func (db *commonMsgDatabase) RangeGroupSendCount(
 ) (msgCount int64, userCount int64, groups []*unRelationTb.GroupCount, dateCount map[string]int64, err error) {
 	return db.msgDocDatabase.RangeGroupSendCount(ctx, start, end, ase, pageNumber, showNumber)
 }
-
-func (db *commonMsgDatabase) SearchMessage(ctx context.Context, req *pbMsg.SearchMessageReq) (total int32, msgData []*sdkws.MsgData, err error) {
-	var totalMsgs []*sdkws.MsgData
-	total, msgs, err := db.msgDocDatabase.SearchMessage(ctx, req)
-	if err != nil {
-		return 0, nil, err
-	}
-	for _, msg := range msgs {
-		totalMsgs = append(totalMsgs, convert.MsgDB2Pb(msg.Msg))
-	}
-	return total, totalMsgs, nil
-}
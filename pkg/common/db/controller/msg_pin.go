@@ -0,0 +1,67 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/tools/errs"
+)
+
+type MsgPinDatabase interface {
+	// PinMsg 已置顶时返回false, 超过每个会话的置顶数量上限时返回错误
+	PinMsg(ctx context.Context, pin *unRelationTb.MsgPinModel) (bool, error)
+	UnpinMsg(ctx context.Context, conversationID string, seq int64) (bool, error)
+	GetPinnedMsgs(ctx context.Context, conversationID string) ([]*unRelationTb.MsgPinModel, error)
+}
+
+func NewMsgPinDatabase(msgPin unRelationTb.MsgPinModelInterface) MsgPinDatabase {
+	return &msgPinDatabase{msgPin: msgPin}
+}
+
+type msgPinDatabase struct {
+	msgPin unRelationTb.MsgPinModelInterface
+}
+
+func (m *msgPinDatabase) PinMsg(ctx context.Context, pin *unRelationTb.MsgPinModel) (bool, error) {
+	created, err := m.msgPin.Create(ctx, pin)
+	if err != nil || !created {
+		return created, err
+	}
+	// 先写入再检查数量, 并发置顶时超出上限的都回滚, 不需要加锁
+	if maxNum := config.Config.MsgPin.MaxNum; maxNum > 0 {
+		count, err := m.msgPin.Count(ctx, pin.ConversationID)
+		if err != nil {
+			return false, err
+		}
+		if count > maxNum {
+			if _, err := m.msgPin.Delete(ctx, pin.ConversationID, pin.Seq); err != nil {
+				return false, err
+			}
+			return false, errs.ErrArgs.Wrap("too many pinned msgs in the conversation")
+		}
+	}
+	return true, nil
+}
+
+func (m *msgPinDatabase) UnpinMsg(ctx context.Context, conversationID string, seq int64) (bool, error) {
+	return m.msgPin.Delete(ctx, conversationID, seq)
+}
+
+func (m *msgPinDatabase) GetPinnedMsgs(ctx context.Context, conversationID string) ([]*unRelationTb.MsgPinModel, error) {
+	return m.msgPin.Find(ctx, conversationID)
+}
@@ -0,0 +1,42 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+type MsgRevokeAuditDatabase interface {
+	CreateRevokeAudit(ctx context.Context, audit *unRelationTb.MsgRevokeAuditModel) error
+	SearchRevokeAudits(ctx context.Context, conversationID string, userID string, startTime int64, endTime int64, pageNumber int32, showNumber int32) (int64, []*unRelationTb.MsgRevokeAuditModel, error)
+}
+
+func NewMsgRevokeAuditDatabase(revokeAudit unRelationTb.MsgRevokeAuditModelInterface) MsgRevokeAuditDatabase {
+	return &msgRevokeAuditDatabase{revokeAudit: revokeAudit}
+}
+
+type msgRevokeAuditDatabase struct {
+	revokeAudit unRelationTb.MsgRevokeAuditModelInterface
+}
+
+func (m *msgRevokeAuditDatabase) CreateRevokeAudit(ctx context.Context, audit *unRelationTb.MsgRevokeAuditModel) error {
+	return m.revokeAudit.Create(ctx, audit)
+}
+
+func (m *msgRevokeAuditDatabase) SearchRevokeAudits(ctx context.Context, conversationID string, userID string, startTime int64, endTime int64, pageNumber int32, showNumber int32) (int64, []*unRelationTb.MsgRevokeAuditModel, error) {
+	return m.revokeAudit.Search(ctx, conversationID, userID, startTime, endTime, pageNumber, showNumber)
+}
@@ -0,0 +1,211 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+)
+
+// searchTextKeys the fields of the msg elems that are searchable.
+var searchTextKeys = []string{"content", "text", "fileName", "title", "description"}
+
+type MsgSearchDatabase interface {
+	// IndexMsgs 通知会话的消息不索引
+	IndexMsgs(ctx context.Context, conversationID string, msgs []*sdkws.MsgData) error
+	// IndexMsgDocs 补建已存储消息的索引, 撤回和删除的消息不索引
+	IndexMsgDocs(ctx context.Context, conversationID string, msgs []*unRelationTb.MsgInfoModel) error
+	DeleteMsgs(ctx context.Context, conversationID string, seqs []int64) error
+	// DeleteUserMsgs userID删除的消息不再出现在userID的搜索结果中
+	DeleteUserMsgs(ctx context.Context, userID string, conversationID string, seqs []int64) error
+	SearchMsgs(ctx context.Context, cond *unRelationTb.MsgSearchCond, cursor string, count int32) ([]*unRelationTb.MsgSearchModel, string, error)
+	SearchMsgsPage(ctx context.Context, cond *unRelationTb.MsgSearchCond, pageNumber int32, showNumber int32) (int64, []*unRelationTb.MsgSearchModel, error)
+}
+
+func NewMsgSearchDatabase(msgSearch unRelationTb.MsgSearchModelInterface) MsgSearchDatabase {
+	return &msgSearchDatabase{msgSearch: msgSearch}
+}
+
+type msgSearchDatabase struct {
+	msgSearch unRelationTb.MsgSearchModelInterface
+}
+
+func (m *msgSearchDatabase) IndexMsgs(ctx context.Context, conversationID string, msgs []*sdkws.MsgData) error {
+	if msgprocessor.IsNotification(conversationID) {
+		return nil
+	}
+	models := make([]*unRelationTb.MsgSearchModel, 0, len(msgs))
+	for _, msg := range msgs {
+		models = append(models, newMsgSearchModel(conversationID, msg))
+	}
+	return m.msgSearch.Upsert(ctx, models)
+}
+
+func (m *msgSearchDatabase) IndexMsgDocs(ctx context.Context, conversationID string, msgs []*unRelationTb.MsgInfoModel) error {
+	if msgprocessor.IsNotification(conversationID) {
+		return nil
+	}
+	models := make([]*unRelationTb.MsgSearchModel, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg == nil || msg.Msg == nil || msg.Msg.Seq == 0 || msg.Revoke != nil || msg.Msg.Status == constant.MsgDeleted {
+			continue
+		}
+		model := newMsgSearchModel(conversationID, convert.MsgDB2Pb(msg.Msg))
+		model.DelList = msg.DelList
+		models = append(models, model)
+	}
+	return m.msgSearch.Upsert(ctx, models)
+}
+
+func (m *msgSearchDatabase) DeleteMsgs(ctx context.Context, conversationID string, seqs []int64) error {
+	return m.msgSearch.Delete(ctx, conversationID, seqs)
+}
+
+func (m *msgSearchDatabase) DeleteUserMsgs(ctx context.Context, userID string, conversationID string, seqs []int64) error {
+	return m.msgSearch.AddDelList(ctx, conversationID, seqs, userID)
+}
+
+func (m *msgSearchDatabase) SearchMsgs(ctx context.Context, cond *unRelationTb.MsgSearchCond, cursor string, count int32) ([]*unRelationTb.MsgSearchModel, string, error) {
+	if cond.Keyword != "" {
+		c := *cond
+		c.Keyword = searchKeyword(cond.Keyword)
+		cond = &c
+	}
+	return m.msgSearch.Search(ctx, cond, cursor, count)
+}
+
+func (m *msgSearchDatabase) SearchMsgsPage(ctx context.Context, cond *unRelationTb.MsgSearchCond, pageNumber int32, showNumber int32) (int64, []*unRelationTb.MsgSearchModel, error) {
+	return m.msgSearch.SearchPage(ctx, cond, pageNumber, showNumber)
+}
+
+func newMsgSearchModel(conversationID string, msg *sdkws.MsgData) *unRelationTb.MsgSearchModel {
+	model := &unRelationTb.MsgSearchModel{
+		ConversationID:   conversationID,
+		Seq:              msg.Seq,
+		ServerMsgID:      msg.ServerMsgID,
+		ClientMsgID:      msg.ClientMsgID,
+		SendID:           msg.SendID,
+		RecvID:           msg.RecvID,
+		GroupID:          msg.GroupID,
+		SenderNickname:   msg.SenderNickname,
+		SenderFaceURL:    msg.SenderFaceURL,
+		SenderPlatformID: msg.SenderPlatformID,
+		SessionType:      msg.SessionType,
+		MsgFrom:          msg.MsgFrom,
+		ContentType:      msg.ContentType,
+		Content:          string(msg.Content),
+		Text:             searchText(msg),
+		Status:           msg.Status,
+		SendTime:         msg.SendTime,
+		CreateTime:       msg.CreateTime,
+		Ex:               msg.Ex,
+	}
+	if grams := cjkGrams(model.Text); len(grams) > 0 {
+		model.Text += " " + strings.Join(grams, " ")
+	}
+	if config.Config.RetainChatRecords > 0 {
+		model.ExpireAt = time.UnixMilli(msg.SendTime).AddDate(0, 0, config.Config.RetainChatRecords)
+	}
+	return model
+}
+
+// searchKeyword turns the CJK runs of keyword into quoted bigrams, or a quoted unigram for a single char, so they
+// match the grams added by newMsgSearchModel. Mongo matches only the docs that contain all the phrases, the other
+// words of keyword matter only when it has no CJK chars.
+func searchKeyword(keyword string) string {
+	var terms []string
+	for _, run := range cjkRuns(keyword) {
+		if len(run) == 1 {
+			terms = append(terms, `"`+string(run)+`"`)
+			continue
+		}
+		for i := 0; i+1 < len(run); i++ {
+			terms = append(terms, `"`+string(run[i:i+2])+`"`)
+		}
+	}
+	if len(terms) == 0 {
+		return keyword
+	}
+	words := strings.FieldsFunc(keyword, func(r rune) bool { return unicode.IsSpace(r) || isCJK(r) })
+	return strings.Join(append(terms, words...), " ")
+}
+
+// cjkGrams the unigrams and bigrams of the CJK runs of text.
+func cjkGrams(text string) []string {
+	var grams []string
+	for _, run := range cjkRuns(text) {
+		for i := range run {
+			grams = append(grams, string(run[i]))
+			if i+1 < len(run) {
+				grams = append(grams, string(run[i:i+2]))
+			}
+		}
+	}
+	return grams
+}
+
+func cjkRuns(text string) [][]rune {
+	var (
+		runs [][]rune
+		run  []rune
+	)
+	for _, r := range text {
+		if isCJK(r) {
+			run = append(run, r)
+			continue
+		}
+		if len(run) > 0 {
+			runs = append(runs, run)
+			run = nil
+		}
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// searchText the text fields of the json elem of msg, a plain text content is used as it is.
+func searchText(msg *sdkws.MsgData) string {
+	var elem map[string]any
+	if err := json.Unmarshal(msg.Content, &elem); err != nil {
+		switch msg.ContentType {
+		case constant.Text, constant.AtText:
+			return string(msg.Content)
+		}
+		return ""
+	}
+	var texts []string
+	for _, key := range searchTextKeys {
+		if text, ok := elem[key].(string); ok && text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, " ")
+}
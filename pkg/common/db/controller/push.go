@@ -22,6 +22,9 @@ import (
 
 type PushDatabase interface {
 	DelFcmToken(ctx context.Context, userID string, platformID int) error
+	// GetUserGatewayRoutes k: userID, v: gateway addrs holding the user's connections
+	GetUserGatewayRoutes(ctx context.Context, userIDs []string) (map[string][]string, error)
+	GetAliveGateways(ctx context.Context, gatewayAddrs []string) ([]string, error)
 }
 
 type pushDataBase struct {
@@ -35,3 +38,11 @@ func NewPushDatabase(cache cache.MsgModel) PushDatabase {
 func (p *pushDataBase) DelFcmToken(ctx context.Context, userID string, platformID int) error {
 	return p.cache.DelFcmToken(ctx, userID, platformID)
 }
+
+func (p *pushDataBase) GetUserGatewayRoutes(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	return p.cache.GetUserGatewayRoutes(ctx, userIDs)
+}
+
+func (p *pushDataBase) GetAliveGateways(ctx context.Context, gatewayAddrs []string) ([]string, error) {
+	return p.cache.GetAliveGateways(ctx, gatewayAddrs)
+}
@@ -0,0 +1,115 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+)
+
+type ScheduledMsgDatabase interface {
+	CreateScheduledMsg(ctx context.Context, msg *sdkws.MsgData) error
+	// TakeDueScheduledMsg 取一条到期的消息, 没有时返回nil
+	TakeDueScheduledMsg(ctx context.Context) (*unRelationTb.ScheduledMsgModel, *sdkws.MsgData, error)
+	SetScheduledMsgStatus(ctx context.Context, sendID string, clientMsgID string, status int32, errMsg string) error
+	// RetryScheduledMsg 发送失败的消息在backoff后重试
+	RetryScheduledMsg(ctx context.Context, sendID string, clientMsgID string, backoff time.Duration, errMsg string) error
+	CancelScheduledMsg(ctx context.Context, sendID string, clientMsgID string) (bool, error)
+	// FindPendingScheduledMsgs 按发送时间排序
+	FindPendingScheduledMsgs(ctx context.Context, sendID string, pageNumber int32, showNumber int32) (int64, []*sdkws.MsgData, error)
+}
+
+func NewScheduledMsgDatabase(scheduledMsg unRelationTb.ScheduledMsgModelInterface) ScheduledMsgDatabase {
+	return &scheduledMsgDatabase{scheduledMsg: scheduledMsg}
+}
+
+type scheduledMsgDatabase struct {
+	scheduledMsg unRelationTb.ScheduledMsgModelInterface
+}
+
+func (s *scheduledMsgDatabase) CreateScheduledMsg(ctx context.Context, msg *sdkws.MsgData) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	now := time.Now().UnixMilli()
+	return s.scheduledMsg.Create(ctx, &unRelationTb.ScheduledMsgModel{
+		ClientMsgID: msg.ClientMsgID,
+		SendID:      msg.SendID,
+		SendTime:    msg.SendTime,
+		Msg:         data,
+		Status:      unRelationTb.ScheduledMsgPending,
+		CreateTime:  now,
+		UpdateTime:  now,
+	})
+}
+
+func (s *scheduledMsgDatabase) TakeDueScheduledMsg(ctx context.Context) (*unRelationTb.ScheduledMsgModel, *sdkws.MsgData, error) {
+	lockTimeout := config.Config.ScheduledMsg.LockTimeout * 1000
+	scheduled, err := s.scheduledMsg.Take(ctx, time.Now().UnixMilli(), lockTimeout)
+	if err != nil || scheduled == nil {
+		return nil, nil, err
+	}
+	var msg sdkws.MsgData
+	if err := proto.Unmarshal(scheduled.Msg, &msg); err != nil {
+		// 无法解析的消息不再重试
+		if err := s.scheduledMsg.SetStatus(ctx, scheduled.SendID, scheduled.ClientMsgID, unRelationTb.ScheduledMsgFailed, err.Error()); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errs.Wrap(err)
+	}
+	return scheduled, &msg, nil
+}
+
+func (s *scheduledMsgDatabase) SetScheduledMsgStatus(ctx context.Context, sendID string, clientMsgID string, status int32, errMsg string) error {
+	return s.scheduledMsg.SetStatus(ctx, sendID, clientMsgID, status, errMsg)
+}
+
+func (s *scheduledMsgDatabase) RetryScheduledMsg(
+	ctx context.Context,
+	sendID string,
+	clientMsgID string,
+	backoff time.Duration,
+	errMsg string,
+) error {
+	return s.scheduledMsg.Retry(ctx, sendID, clientMsgID, time.Now().Add(backoff).UnixMilli(), errMsg)
+}
+
+func (s *scheduledMsgDatabase) CancelScheduledMsg(ctx context.Context, sendID string, clientMsgID string) (bool, error) {
+	return s.scheduledMsg.Cancel(ctx, sendID, clientMsgID)
+}
+
+func (s *scheduledMsgDatabase) FindPendingScheduledMsgs(ctx context.Context, sendID string, pageNumber int32, showNumber int32) (int64, []*sdkws.MsgData, error) {
+	total, scheduledMsgs, err := s.scheduledMsg.FindBySendID(ctx, sendID, unRelationTb.ScheduledMsgPending, pageNumber, showNumber)
+	if err != nil {
+		return 0, nil, err
+	}
+	msgs := make([]*sdkws.MsgData, 0, len(scheduledMsgs))
+	for _, scheduled := range scheduledMsgs {
+		var msg sdkws.MsgData
+		if err := proto.Unmarshal(scheduled.Msg, &msg); err != nil {
+			return 0, nil, errs.Wrap(err)
+		}
+		msgs = append(msgs, &msg)
+	}
+	return total, msgs, nil
+}
@@ -0,0 +1,115 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+type AppCredentialGorm struct {
+	*MetaDB
+}
+
+func NewAppCredential(db *gorm.DB) relation.AppCredentialModelInterface {
+	return &AppCredentialGorm{NewMetaDB(db, &relation.AppCredentialModel{})}
+}
+
+func (a *AppCredentialGorm) NewTx(tx any) relation.AppCredentialModelInterface {
+	return &AppCredentialGorm{NewMetaDB(tx.(*gorm.DB), &relation.AppCredentialModel{})}
+}
+
+func (a *AppCredentialGorm) Create(ctx context.Context, credential *relation.AppCredentialModel) error {
+	return utils.Wrap(a.db(ctx).Create(credential).Error, "")
+}
+
+func (a *AppCredentialGorm) Take(ctx context.Context, clientID string) (credential *relation.AppCredentialModel, err error) {
+	credential = &relation.AppCredentialModel{}
+	return credential, utils.Wrap(a.db(ctx).Where("client_id = ?", clientID).Take(credential).Error, "")
+}
+
+func (a *AppCredentialGorm) UpdateStatus(ctx context.Context, clientID string, status int32, revokeTime time.Time) error {
+	return utils.Wrap(
+		a.db(ctx).
+			Where("client_id = ?", clientID).
+			Updates(map[string]any{"status": status, "revoke_time": revokeTime}).
+			Error,
+		"",
+	)
+}
+
+func (a *AppCredentialGorm) Page(
+	ctx context.Context,
+	pageNumber, showNumber int32,
+) (credentials []*relation.AppCredentialModel, count int64, err error) {
+	err = utils.Wrap(a.db(ctx).Count(&count).Error, "")
+	if err != nil {
+		return
+	}
+	err = utils.Wrap(
+		a.db(ctx).
+			Order("create_time DESC").
+			Limit(int(showNumber)).
+			Offset(int((pageNumber-1)*showNumber)).
+			Find(&credentials).
+			Error,
+		"",
+	)
+	return
+}
+
+type AppCredentialLogGorm struct {
+	*MetaDB
+}
+
+func NewAppCredentialLog(db *gorm.DB) relation.AppCredentialLogModelInterface {
+	return &AppCredentialLogGorm{NewMetaDB(db, &relation.AppCredentialLogModel{})}
+}
+
+func (a *AppCredentialLogGorm) Create(ctx context.Context, log *relation.AppCredentialLogModel) error {
+	return utils.Wrap(a.db(ctx).Create(log).Error, "")
+}
+
+func (a *AppCredentialLogGorm) Page(
+	ctx context.Context,
+	clientID string,
+	pageNumber, showNumber int32,
+) (logs []*relation.AppCredentialLogModel, count int64, err error) {
+	query := func() *gorm.DB {
+		if clientID == "" {
+			return a.db(ctx)
+		}
+		return a.db(ctx).Where("client_id = ?", clientID)
+	}
+	err = utils.Wrap(query().Count(&count).Error, "")
+	if err != nil {
+		return
+	}
+	err = utils.Wrap(
+		query().
+			Order("create_time DESC").
+			Limit(int(showNumber)).
+			Offset(int((pageNumber-1)*showNumber)).
+			Find(&logs).
+			Error,
+		"",
+	)
+	return
+}
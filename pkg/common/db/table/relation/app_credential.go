@@ -0,0 +1,92 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	AppCredentialModelTableName    = "app_credentials"
+	AppCredentialLogModelTableName = "app_credential_logs"
+)
+
+const (
+	AppCredentialNormal  = 0
+	AppCredentialRevoked = 1
+)
+
+// 凭证可授予的权限.
+const (
+	AppCredentialScopeTokenIssue = "token-issue" // 签发用户token, 强制下线
+	AppCredentialScopeUserImport = "user-import" // 导入用户和好友
+	AppCredentialScopeSendAs     = "send-as"     // 以任意用户身份发送消息
+	AppCredentialScopeGroupAdmin = "group-admin" // 管理所有群
+)
+
+var AppCredentialScopes = []string{
+	AppCredentialScopeTokenIssue,
+	AppCredentialScopeUserImport,
+	AppCredentialScopeSendAs,
+	AppCredentialScopeGroupAdmin,
+}
+
+// AppCredentialModel 接入的后台服务使用的凭证, 使用secret或者用公钥对应的私钥签名的jwt认证.
+type AppCredentialModel struct {
+	ClientID   string    `gorm:"column:client_id;primary_key;type:varchar(64)"`
+	Name       string    `gorm:"column:name;type:varchar(255)"`
+	SecretHash string    `gorm:"column:secret_hash;type:char(64)"`
+	PublicKey  string    `gorm:"column:public_key;type:text"`
+	Scopes     string    `gorm:"column:scopes;type:varchar(255)"`
+	OpUserID   string    `gorm:"column:op_user_id;type:varchar(64)"`
+	Status     int32     `gorm:"column:status"`
+	CreateTime time.Time `gorm:"column:create_time"`
+	RevokeTime time.Time `gorm:"column:revoke_time"`
+}
+
+func (AppCredentialModel) TableName() string {
+	return AppCredentialModelTableName
+}
+
+type AppCredentialModelInterface interface {
+	NewTx(tx any) AppCredentialModelInterface
+	Create(ctx context.Context, credential *AppCredentialModel) error
+	Take(ctx context.Context, clientID string) (*AppCredentialModel, error)
+	UpdateStatus(ctx context.Context, clientID string, status int32, revokeTime time.Time) error
+	Page(ctx context.Context, pageNumber, showNumber int32) (credentials []*AppCredentialModel, count int64, err error)
+}
+
+// AppCredentialLogModel 凭证每次认证的审计记录, 包括认证失败的.
+type AppCredentialLogModel struct {
+	ID          int64     `gorm:"column:id;primary_key;autoIncrement"`
+	ClientID    string    `gorm:"column:client_id;type:varchar(64);index:client_id_create_time,priority:1"`
+	Scope       string    `gorm:"column:scope;type:varchar(64)"`
+	Path        string    `gorm:"column:path;type:varchar(255)"`
+	IP          string    `gorm:"column:ip;type:varchar(64)"`
+	OperationID string    `gorm:"column:operation_id;type:varchar(128)"`
+	Result      string    `gorm:"column:result;type:varchar(255)"`
+	CreateTime  time.Time `gorm:"column:create_time;index:client_id_create_time,priority:2"`
+}
+
+func (AppCredentialLogModel) TableName() string {
+	return AppCredentialLogModelTableName
+}
+
+type AppCredentialLogModelInterface interface {
+	Create(ctx context.Context, log *AppCredentialLogModel) error
+	// clientID为空时查询所有凭证的记录
+	Page(ctx context.Context, clientID string, pageNumber, showNumber int32) (logs []*AppCredentialLogModel, count int64, err error)
+}
@@ -19,8 +19,6 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/OpenIMSDK/protocol/msg"
-
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/OpenIMSDK/protocol/sdkws"
@@ -45,6 +43,43 @@ type RevokeModel struct {
 	Time     int64  `bson:"time"`
 }
 
+// EditModel is the version of a msg replaced by an edit, with who edited it and when.
+type EditModel struct {
+	UserID      string `bson:"user_id"`
+	Nickname    string `bson:"nickname"`
+	Role        int32  `bson:"role"`
+	Time        int64  `bson:"time"`
+	ContentType int32  `bson:"content_type"`
+	Content     string `bson:"content"`
+}
+
+// ReactionModel is one emoji a user put on a msg.
+type ReactionModel struct {
+	Emoji  string `bson:"emoji"`
+	UserID string `bson:"user_id"`
+	Time   int64  `bson:"time"`
+}
+
+// ThreadModel is kept on the root msg of a thread, the replies have their own seqs under ConversationID.
+type ThreadModel struct {
+	ConversationID       string `bson:"conversation_id"`
+	ReplyCount           int64  `bson:"reply_count"`
+	LastReplySeq         int64  `bson:"last_reply_seq"`
+	LastReplyUserID      string `bson:"last_reply_user_id"`
+	LastReplyClientMsgID string `bson:"last_reply_client_msg_id"`
+	LastReplyTime        int64  `bson:"last_reply_time"`
+}
+
+const MsgThread = "msg_thread"
+
+// MsgThreadModel indexes the thread roots of a conversation by their last reply, so threads can be paged without scanning the msg docs.
+type MsgThreadModel struct {
+	ConversationID string `bson:"conversation_id"`
+	RootSeq        int64  `bson:"root_seq"`
+	ThreadID       string `bson:"thread_id"`
+	LastReplyTime  int64  `bson:"last_reply_time"`
+}
+
 type OfflinePushModel struct {
 	Title         string `bson:"title"`
 	Desc          string `bson:"desc"`
@@ -79,10 +114,13 @@ type MsgDataModel struct {
 }
 
 type MsgInfoModel struct {
-	Msg     *MsgDataModel `bson:"msg"`
-	Revoke  *RevokeModel  `bson:"revoke"`
-	DelList []string      `bson:"del_list"`
-	IsRead  bool          `bson:"is_read"`
+	Msg       *MsgDataModel    `bson:"msg"`
+	Revoke    *RevokeModel     `bson:"revoke"`
+	DelList   []string         `bson:"del_list"`
+	IsRead    bool             `bson:"is_read"`
+	Edits     []*EditModel     `bson:"edits,omitempty"`
+	Reactions []*ReactionModel `bson:"reactions,omitempty"`
+	Thread    *ThreadModel     `bson:"thread,omitempty"`
 }
 
 type UserCount struct {
@@ -99,6 +137,14 @@ type MsgDocModelInterface interface {
 	PushMsgsToDoc(ctx context.Context, docID string, msgsToMongo []MsgInfoModel) error
 	Create(ctx context.Context, model *MsgDocModel) error
 	UpdateMsg(ctx context.Context, docID string, index int64, key string, value any) (*mongo.UpdateResult, error)
+	UpdateMsgIfAbsent(ctx context.Context, docID string, index int64, key string, value any) (exist bool, set bool, err error)
+	AddReaction(ctx context.Context, docID string, index int64, reaction *ReactionModel) (bool, error)
+	RemoveReaction(ctx context.Context, docID string, index int64, emoji string, userID string) (bool, error)
+	GetReactions(ctx context.Context, docID string, index int64) ([]*ReactionModel, error)
+	// UpdateThreadReply 根消息回复数加一并更新最后回复, 同时更新话题索引
+	UpdateThreadReply(ctx context.Context, conversationID string, rootSeq int64, thread *ThreadModel) error
+	// FindThreads 会话中根消息seq不小于minSeq的话题, 按最后回复时间倒序分页
+	FindThreads(ctx context.Context, userID string, conversationID string, minSeq int64, pageNumber int32, showNumber int32) (int64, []*MsgInfoModel, error)
 	PushUnique(ctx context.Context, docID string, index int64, key string, value any) (*mongo.UpdateResult, error)
 	UpdateMsgContent(ctx context.Context, docID string, index int64, msg []byte) error
 	IsExistDocID(ctx context.Context, docID string) (bool, error)
@@ -110,7 +156,6 @@ type MsgDocModelInterface interface {
 	GetMsgDocModelByIndex(ctx context.Context, conversationID string, index, sort int64) (*MsgDocModel, error)
 	DeleteMsgsInOneDocByIndex(ctx context.Context, docID string, indexes []int) error
 	MarkSingleChatMsgsAsRead(ctx context.Context, userID string, docID string, indexes []int64) error
-	SearchMessage(ctx context.Context, req *msg.SearchMessageReq) (int32, []*MsgInfoModel, error)
 	RangeUserSendCount(
 		ctx context.Context,
 		start time.Time,
@@ -0,0 +1,45 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import "context"
+
+const (
+	MsgPin = "msg_pin"
+)
+
+// MsgPinModel a msg pinned in its conversation.
+type MsgPinModel struct {
+	ConversationID string `bson:"conversation_id"`
+	Seq            int64  `bson:"seq"`
+	ClientMsgID    string `bson:"client_msg_id"`
+	SessionType    int32  `bson:"session_type"`
+	PinnedBy       string `bson:"pinned_by"`
+	Role           int32  `bson:"role"`
+	PinTime        int64  `bson:"pin_time"`
+}
+
+func (MsgPinModel) TableName() string {
+	return MsgPin
+}
+
+type MsgPinModelInterface interface {
+	// Create 已置顶时返回false
+	Create(ctx context.Context, pin *MsgPinModel) (bool, error)
+	Delete(ctx context.Context, conversationID string, seq int64) (bool, error)
+	Count(ctx context.Context, conversationID string) (int64, error)
+	// Find 按置顶时间倒序
+	Find(ctx context.Context, conversationID string) ([]*MsgPinModel, error)
+}
@@ -0,0 +1,48 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import "context"
+
+const (
+	MsgRevokeAudit = "msg_revoke_audit"
+)
+
+// MsgRevokeAuditModel keeps the original content of a revoked msg, it is never returned by the pull apis.
+type MsgRevokeAuditModel struct {
+	ConversationID  string `bson:"conversation_id"`
+	Seq             int64  `bson:"seq"`
+	ClientMsgID     string `bson:"client_msg_id"`
+	SessionType     int32  `bson:"session_type"`
+	SendID          string `bson:"send_id"`
+	SendTime        int64  `bson:"send_time"`
+	ContentType     int32  `bson:"content_type"`
+	Content         string `bson:"content"`
+	RevokerUserID   string `bson:"revoker_user_id"`
+	RevokerRole     int32  `bson:"revoker_role"`
+	RevokerNickname string `bson:"revoker_nickname"`
+	RevokeTime      int64  `bson:"revoke_time"`
+}
+
+func (MsgRevokeAuditModel) TableName() string {
+	return MsgRevokeAudit
+}
+
+type MsgRevokeAuditModelInterface interface {
+	// Create 同一条消息重复写入时覆盖
+	Create(ctx context.Context, audit *MsgRevokeAuditModel) error
+	// Search userID为撤回者或发送者, startTime和endTime为0时不限制, 按撤回时间倒序
+	Search(ctx context.Context, conversationID string, userID string, startTime int64, endTime int64, pageNumber int32, showNumber int32) (int64, []*MsgRevokeAuditModel, error)
+}
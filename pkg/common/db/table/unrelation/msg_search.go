@@ -0,0 +1,87 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	MsgSearch = "msg_search"
+)
+
+// MsgSearchModel one msg flattened for searching, Text is the searchable text of Content with the CJK chars
+// also split into unigrams and bigrams, as the text index doesn't split them. DelList are the users who deleted the msg,
+// an empty DelList doesn't overwrite the stored one.
+type MsgSearchModel struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty"`
+	ConversationID   string             `bson:"conversation_id"`
+	Seq              int64              `bson:"seq"`
+	ServerMsgID      string             `bson:"server_msg_id"`
+	ClientMsgID      string             `bson:"client_msg_id"`
+	SendID           string             `bson:"send_id"`
+	RecvID           string             `bson:"recv_id"`
+	GroupID          string             `bson:"group_id"`
+	SenderNickname   string             `bson:"sender_nickname"`
+	SenderFaceURL    string             `bson:"sender_face_url"`
+	SenderPlatformID int32              `bson:"sender_platform_id"`
+	SessionType      int32              `bson:"session_type"`
+	MsgFrom          int32              `bson:"msg_from"`
+	ContentType      int32              `bson:"content_type"`
+	Content          string             `bson:"content"`
+	Text             string             `bson:"text"`
+	Status           int32              `bson:"status"`
+	SendTime         int64              `bson:"send_time"`
+	CreateTime       int64              `bson:"create_time"`
+	Ex               string             `bson:"ex"`
+	ExpireAt         time.Time          `bson:"expire_at,omitempty"`
+	DelList          []string           `bson:"del_list,omitempty"`
+}
+
+func (MsgSearchModel) TableName() string {
+	return MsgSearch
+}
+
+// MsgSearchCond empty fields are not filtered, StartTime and EndTime are send times in ms.
+// UserID drops the msgs the user deleted, MinSeq and MaxSeq limit the seqs of ConversationID.
+type MsgSearchCond struct {
+	Keyword        string
+	UserID         string
+	SendID         string
+	RecvID         string
+	GroupID        string
+	ConversationID string
+	SessionType    int32
+	ContentTypes   []int32
+	StartTime      int64
+	EndTime        int64
+	MinSeq         int64
+	MaxSeq         int64
+}
+
+// MsgSearchModelInterface the search index of msgs, results are ordered by send time desc.
+type MsgSearchModelInterface interface {
+	// Upsert 按conversationID和seq覆盖
+	Upsert(ctx context.Context, msgs []*MsgSearchModel) error
+	Delete(ctx context.Context, conversationID string, seqs []int64) error
+	// AddDelList userID删除了这些消息
+	AddDelList(ctx context.Context, conversationID string, seqs []int64, userID string) error
+	// Search cursor为空时从最新的消息开始, 返回下一页的cursor, 没有更多时为空
+	Search(ctx context.Context, cond *MsgSearchCond, cursor string, count int32) ([]*MsgSearchModel, string, error)
+	SearchPage(ctx context.Context, cond *MsgSearchCond, pageNumber int32, showNumber int32) (int64, []*MsgSearchModel, error)
+}
@@ -0,0 +1,60 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import "context"
+
+const (
+	ScheduledMsg = "scheduled_msg"
+)
+
+const (
+	ScheduledMsgPending  = 1
+	ScheduledMsgSending  = 2
+	ScheduledMsgSent     = 3
+	ScheduledMsgCanceled = 4
+	ScheduledMsgFailed   = 5
+)
+
+// ScheduledMsgModel a msg waiting to be sent at SendTime.
+type ScheduledMsgModel struct {
+	ClientMsgID string `bson:"client_msg_id"`
+	SendID      string `bson:"send_id"`
+	SendTime    int64  `bson:"send_time"`
+	Msg         []byte `bson:"msg"` // proto编码的sdkws.MsgData
+	Status      int32  `bson:"status"`
+	Attempts    int32  `bson:"attempts"`
+	LockTime    int64  `bson:"lock_time"`
+	RetryTime   int64  `bson:"retry_time"` // 发送失败后不早于此时间重试
+	ErrMsg      string `bson:"err_msg"`
+	CreateTime  int64  `bson:"create_time"`
+	UpdateTime  int64  `bson:"update_time"`
+}
+
+func (ScheduledMsgModel) TableName() string {
+	return ScheduledMsg
+}
+
+type ScheduledMsgModelInterface interface {
+	Create(ctx context.Context, msg *ScheduledMsgModel) error
+	// Take 取一条到期的消息并置为发送中, 发送中超过lockTimeout(毫秒)的消息会被重新取出, 没有时返回nil
+	Take(ctx context.Context, now int64, lockTimeout int64) (*ScheduledMsgModel, error)
+	SetStatus(ctx context.Context, sendID string, clientMsgID string, status int32, errMsg string) error
+	// Retry 发送失败的消息重新置为待发送, retryTime前不会被取出
+	Retry(ctx context.Context, sendID string, clientMsgID string, retryTime int64, errMsg string) error
+	// Cancel 只能取消待发送的消息
+	Cancel(ctx context.Context, sendID string, clientMsgID string) (bool, error)
+	FindBySendID(ctx context.Context, sendID string, status int32, pageNumber int32, showNumber int32) (int64, []*ScheduledMsgModel, error)
+}
@@ -97,6 +97,68 @@ func (m *Mongo) CreateMsgIndex() error {
 	return m.createMongoIndex(unrelation.Msg, true, "doc_id")
 }
 
+func (m *Mongo) CreateMsgThreadIndex() error {
+	if err := m.createMongoIndex(unrelation.MsgThread, true, "conversation_id", "root_seq"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.MsgThread, false, "conversation_id", "-last_reply_time", "-root_seq"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Mongo) CreateMsgRevokeAuditIndex() error {
+	if err := m.createMongoIndex(unrelation.MsgRevokeAudit, true, "conversation_id", "seq"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.MsgRevokeAudit, false, "-revoke_time"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Mongo) CreateScheduledMsgIndex() error {
+	if err := m.createMongoIndex(unrelation.ScheduledMsg, true, "send_id", "client_msg_id"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.ScheduledMsg, false, "status", "send_time"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Mongo) CreateMsgPinIndex() error {
+	return m.createMongoIndex(unrelation.MsgPin, true, "conversation_id", "seq")
+}
+
+// CreateMsgSearchIndex the text index does not split words of languages without spaces, such as chinese,
+// the CJK chars are indexed as unigrams and bigrams added to the text instead.
+func (m *Mongo) CreateMsgSearchIndex() error {
+	if err := m.createMongoIndex(unrelation.MsgSearch, true, "conversation_id", "seq"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.MsgSearch, false, "-send_time"); err != nil {
+		return err
+	}
+	indexView := m.GetDatabase().Collection(unrelation.MsgSearch).Indexes()
+	opts := options.CreateIndexes().SetMaxTime(10 * time.Second)
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "text", Value: "text"}},
+			Options: options.Index().SetDefaultLanguage("none"),
+		},
+		{
+			// 按消息保留时间过期
+			Keys:    bson.D{{Key: "expire_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	}
+	if _, err := indexView.CreateMany(context.Background(), indexes, opts); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
 func (m *Mongo) CreateSuperGroupIndex() error {
 	if err := m.createMongoIndex(unrelation.CSuperGroup, true, "group_id"); err != nil {
 		return err
@@ -21,8 +21,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/OpenIMSDK/protocol/msg"
-
 	"github.com/OpenIMSDK/protocol/constant"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -40,13 +38,14 @@ import (
 var ErrMsgListNotExist = errors.New("user not have msg in mongoDB")
 
 type MsgMongoDriver struct {
-	MsgCollection *mongo.Collection
-	model         table.MsgDocModel
+	MsgCollection    *mongo.Collection
+	threadCollection *mongo.Collection
+	model            table.MsgDocModel
 }
 
 func NewMsgMongoDriver(database *mongo.Database) table.MsgDocModelInterface {
 	collection := database.Collection(table.MsgDocModel{}.TableName())
-	return &MsgMongoDriver{MsgCollection: collection}
+	return &MsgMongoDriver{MsgCollection: collection, threadCollection: database.Collection(table.MsgThread)}
 }
 
 func (m *MsgMongoDriver) PushMsgsToDoc(ctx context.Context, docID string, msgsToMongo []table.MsgInfoModel) error {
@@ -81,7 +80,145 @@ func (m *MsgMongoDriver) UpdateMsg(
 	return res, nil
 }
 
-// PushUnique value must slice.
+// UpdateMsgIfAbsent sets msgs.index.key only while it is still null, so a re-delivered message never overwrites
+// what is already stored. exist reports whether the doc exists, set whether the field was written.
+func (m *MsgMongoDriver) UpdateMsgIfAbsent(
+	ctx context.Context,
+	docID string,
+	index int64,
+	key string,
+	value any,
+) (exist bool, set bool, err error) {
+	field := fmt.Sprintf("msgs.%d.%s", index, key)
+	filter := bson.M{"doc_id": docID, field: nil}
+	update := bson.M{"$set": bson.M{field: value}}
+	res, err := m.MsgCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, false, utils.Wrap(err, "")
+	}
+	if res.MatchedCount > 0 {
+		return true, true, nil
+	}
+	exist, err = m.IsExistDocID(ctx, docID)
+	return exist, false, err
+}
+
+// AddReaction appends reaction unless the user already put the same emoji on the msg.
+func (m *MsgMongoDriver) AddReaction(ctx context.Context, docID string, index int64, reaction *table.ReactionModel) (bool, error) {
+	field := fmt.Sprintf("msgs.%d.reactions", index)
+	filter := bson.M{
+		"doc_id": docID,
+		field:    bson.M{"$not": bson.M{"$elemMatch": bson.M{"emoji": reaction.Emoji, "user_id": reaction.UserID}}},
+	}
+	res, err := m.MsgCollection.UpdateOne(ctx, filter, bson.M{"$push": bson.M{field: reaction}})
+	if err != nil {
+		return false, utils.Wrap(err, "")
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (m *MsgMongoDriver) RemoveReaction(ctx context.Context, docID string, index int64, emoji string, userID string) (bool, error) {
+	field := fmt.Sprintf("msgs.%d.reactions", index)
+	update := bson.M{"$pull": bson.M{field: bson.M{"emoji": emoji, "user_id": userID}}}
+	res, err := m.MsgCollection.UpdateOne(ctx, bson.M{"doc_id": docID}, update)
+	if err != nil {
+		return false, utils.Wrap(err, "")
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (m *MsgMongoDriver) GetReactions(ctx context.Context, docID string, index int64) ([]*table.ReactionModel, error) {
+	opts := options.FindOne().SetProjection(bson.M{"msgs": bson.M{"$slice": []int64{index, 1}}})
+	doc := &table.MsgDocModel{}
+	if err := m.MsgCollection.FindOne(ctx, bson.M{"doc_id": docID}, opts).Decode(doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, utils.Wrap(err, "")
+	}
+	if len(doc.Msg) == 0 || doc.Msg[0] == nil {
+		return nil, nil
+	}
+	return doc.Msg[0].Reactions, nil
+}
+
+func (m *MsgMongoDriver) UpdateThreadReply(ctx context.Context, conversationID string, rootSeq int64, thread *table.ThreadModel) error {
+	docID := m.model.GetDocID(conversationID, rootSeq)
+	field := fmt.Sprintf("msgs.%d.thread.", m.model.GetMsgIndex(rootSeq))
+	update := bson.M{
+		"$inc": bson.M{field + "reply_count": 1},
+		"$set": bson.M{
+			field + "conversation_id":          thread.ConversationID,
+			field + "last_reply_seq":           thread.LastReplySeq,
+			field + "last_reply_user_id":       thread.LastReplyUserID,
+			field + "last_reply_client_msg_id": thread.LastReplyClientMsgID,
+			field + "last_reply_time":          thread.LastReplyTime,
+		},
+	}
+	res, err := m.MsgCollection.UpdateOne(ctx, bson.M{"doc_id": docID}, update)
+	if err != nil {
+		return utils.Wrap(err, "")
+	}
+	if res.MatchedCount == 0 {
+		return errs.ErrRecordNotFound.Wrap("msg doc not found " + docID)
+	}
+	// 并发回复时最后回复时间只增不减
+	_, err = m.threadCollection.UpdateOne(ctx,
+		bson.M{"conversation_id": conversationID, "root_seq": rootSeq},
+		bson.M{
+			"$set": bson.M{"thread_id": thread.ConversationID},
+			"$max": bson.M{"last_reply_time": thread.LastReplyTime},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return errs.Wrap(err)
+}
+
+func (m *MsgMongoDriver) FindThreads(ctx context.Context, userID string, conversationID string, minSeq int64, pageNumber int32, showNumber int32) (int64, []*table.MsgInfoModel, error) {
+	filter := bson.M{"conversation_id": conversationID, "root_seq": bson.M{"$gte": minSeq}}
+	total, err := m.threadCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "last_reply_time", Value: -1}, {Key: "root_seq", Value: -1}})
+	if pageNumber > 0 && showNumber > 0 {
+		opts.SetSkip(int64(pageNumber-1) * int64(showNumber)).SetLimit(int64(showNumber))
+	}
+	cursor, err := m.threadCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	var threads []*table.MsgThreadModel
+	if err := cursor.All(ctx, &threads); err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	if len(threads) == 0 {
+		return total, nil, nil
+	}
+	seqs := make([]int64, 0, len(threads))
+	for _, thread := range threads {
+		seqs = append(seqs, thread.RootSeq)
+	}
+	roots := make(map[int64]*table.MsgInfoModel, len(seqs))
+	for docID, docSeqs := range m.model.GetDocIDSeqsMap(conversationID, seqs) {
+		msgs, err := m.GetMsgBySeqIndexIn1Doc(ctx, userID, docID, docSeqs)
+		if err != nil {
+			return 0, nil, err
+		}
+		for _, msg := range msgs {
+			roots[msg.Msg.Seq] = msg
+		}
+	}
+	// 按索引顺序返回, 用户已删除的根消息不返回
+	msgs := make([]*table.MsgInfoModel, 0, len(threads))
+	for _, thread := range threads {
+		if msg, ok := roots[thread.RootSeq]; ok && msg.Thread != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+	return total, msgs, nil
+}
+
 func (m *MsgMongoDriver) PushUnique(
 	ctx context.Context,
 	docID string,
@@ -1066,148 +1203,3 @@ func (m *MsgMongoDriver) RangeGroupSendCount(
 	}
 	return result[0].MsgCount, result[0].UserCount, groups, dateCount, nil
 }
-
-func (m *MsgMongoDriver) SearchMessage(ctx context.Context, req *msg.SearchMessageReq) (int32, []*table.MsgInfoModel, error) {
-	total, msgs, err := m.searchMessage(ctx, req)
-	if err != nil {
-		return 0, nil, err
-	}
-	for _, msg1 := range msgs {
-		if msg1.IsRead {
-			msg1.Msg.IsRead = true
-		}
-	}
-	return total, msgs, nil
-}
-
-func (m *MsgMongoDriver) searchMessage(ctx context.Context, req *msg.SearchMessageReq) (int32, []*table.MsgInfoModel, error) {
-	var pipe mongo.Pipeline
-	condition := bson.A{}
-	if req.SendTime != "" {
-		condition = append(condition, bson.M{"$eq": bson.A{bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": bson.M{"$toDate": "$$item.msg.send_time"}}}, req.SendTime}})
-	}
-	if req.MsgType != 0 {
-		condition = append(condition, bson.M{"$eq": bson.A{"$$item.msg.content_type", req.MsgType}})
-	}
-	if req.SessionType != 0 {
-		condition = append(condition, bson.M{"$eq": bson.A{"$$item.msg.session_type", req.SessionType}})
-	}
-	if req.RecvID != "" {
-		condition = append(condition, bson.M{"$regexFind": bson.M{"input": "$$item.msg.recv_id", "regex": req.RecvID}})
-	}
-	if req.SendID != "" {
-		condition = append(condition, bson.M{"$regexFind": bson.M{"input": "$$item.msg.send_id", "regex": req.SendID}})
-	}
-
-	or := bson.A{
-		bson.M{
-			"doc_id": bson.M{
-				"$regex":   "^si_",
-				"$options": "i",
-			},
-		},
-	}
-	or = append(or,
-		bson.M{
-			"doc_id": bson.M{
-				"$regex":   "^g_",
-				"$options": "i",
-			},
-		},
-		bson.M{
-			"doc_id": bson.M{
-				"$regex":   "^sg_",
-				"$options": "i",
-			},
-		},
-	)
-
-	pipe = mongo.Pipeline{
-		{
-			{"$match", bson.D{
-				{
-					"$or", or,
-				},
-			}},
-		},
-		{
-			{"$project", bson.D{
-				{
-					"msgs", bson.D{
-						{
-							"$filter", bson.D{
-								{"input", "$msgs"},
-								{"as", "item"},
-								{
-									"cond", bson.D{
-										{"$and", condition},
-									},
-								},
-							},
-						},
-					},
-				},
-				{"doc_id", 1},
-			}},
-		},
-	}
-	cursor, err := m.MsgCollection.Aggregate(ctx, pipe)
-	if err != nil {
-		return 0, nil, err
-	}
-
-	var msgsDocs []table.MsgDocModel
-	err = cursor.All(ctx, &msgsDocs)
-	if err != nil {
-		return 0, nil, err
-	}
-	if len(msgsDocs) == 0 {
-		return 0, nil, errs.Wrap(mongo.ErrNoDocuments)
-	}
-	msgs := make([]*table.MsgInfoModel, 0)
-	for index := range msgsDocs {
-		for i := range msgsDocs[index].Msg {
-			msg := msgsDocs[index].Msg[i]
-			if msg == nil || msg.Msg == nil {
-				continue
-			}
-			if msg.Revoke != nil {
-				revokeContent := sdkws.MessageRevokedContent{
-					RevokerID:                   msg.Revoke.UserID,
-					RevokerRole:                 msg.Revoke.Role,
-					ClientMsgID:                 msg.Msg.ClientMsgID,
-					RevokerNickname:             msg.Revoke.Nickname,
-					RevokeTime:                  msg.Revoke.Time,
-					SourceMessageSendTime:       msg.Msg.SendTime,
-					SourceMessageSendID:         msg.Msg.SendID,
-					SourceMessageSenderNickname: msg.Msg.SenderNickname,
-					SessionType:                 msg.Msg.SessionType,
-					Seq:                         msg.Msg.Seq,
-					Ex:                          msg.Msg.Ex,
-				}
-				data, err := json.Marshal(&revokeContent)
-				if err != nil {
-					return 0, nil, err
-				}
-				elem := sdkws.NotificationElem{
-					Detail: string(data),
-				}
-				content, err := json.Marshal(&elem)
-				if err != nil {
-					return 0, nil, err
-				}
-				msg.Msg.ContentType = constant.MsgRevokeNotification
-				msg.Msg.Content = string(content)
-			}
-			msgs = append(msgs, msg)
-		}
-	}
-	start := (req.Pagination.PageNumber - 1) * req.Pagination.ShowNumber
-	n := int32(len(msgs))
-	if start+req.Pagination.ShowNumber < n {
-		msgs = msgs[start : start+req.Pagination.ShowNumber]
-	} else {
-		msgs = msgs[start:]
-	}
-	return n, msgs, nil
-}
@@ -0,0 +1,71 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/tools/errs"
+)
+
+func NewMsgPinMongoDriver(database *mongo.Database) unrelation.MsgPinModelInterface {
+	return &MsgPinMongoDriver{
+		collection: database.Collection(unrelation.MsgPin),
+	}
+}
+
+type MsgPinMongoDriver struct {
+	collection *mongo.Collection
+}
+
+func (m *MsgPinMongoDriver) Create(ctx context.Context, pin *unrelation.MsgPinModel) (bool, error) {
+	filter := bson.M{"conversation_id": pin.ConversationID, "seq": pin.Seq}
+	res, err := m.collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": pin}, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return res.UpsertedCount > 0, nil
+}
+
+func (m *MsgPinMongoDriver) Delete(ctx context.Context, conversationID string, seq int64) (bool, error) {
+	res, err := m.collection.DeleteOne(ctx, bson.M{"conversation_id": conversationID, "seq": seq})
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return res.DeletedCount > 0, nil
+}
+
+func (m *MsgPinMongoDriver) Count(ctx context.Context, conversationID string) (int64, error) {
+	count, err := m.collection.CountDocuments(ctx, bson.M{"conversation_id": conversationID})
+	return count, errs.Wrap(err)
+}
+
+func (m *MsgPinMongoDriver) Find(ctx context.Context, conversationID string) ([]*unrelation.MsgPinModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "pin_time", Value: -1}})
+	cursor, err := m.collection.Find(ctx, bson.M{"conversation_id": conversationID}, opts)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var pins []*unrelation.MsgPinModel
+	if err := cursor.All(ctx, &pins); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return pins, nil
+}
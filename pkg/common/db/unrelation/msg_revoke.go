@@ -0,0 +1,87 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/tools/errs"
+)
+
+func NewMsgRevokeAuditMongoDriver(database *mongo.Database) unrelation.MsgRevokeAuditModelInterface {
+	return &MsgRevokeAuditMongoDriver{
+		collection: database.Collection(unrelation.MsgRevokeAudit),
+	}
+}
+
+type MsgRevokeAuditMongoDriver struct {
+	collection *mongo.Collection
+}
+
+func (m *MsgRevokeAuditMongoDriver) Create(ctx context.Context, audit *unrelation.MsgRevokeAuditModel) error {
+	filter := bson.M{"conversation_id": audit.ConversationID, "seq": audit.Seq}
+	_, err := m.collection.ReplaceOne(ctx, filter, audit, options.Replace().SetUpsert(true))
+	return errs.Wrap(err)
+}
+
+func (m *MsgRevokeAuditMongoDriver) Search(
+	ctx context.Context,
+	conversationID string,
+	userID string,
+	startTime int64,
+	endTime int64,
+	pageNumber int32,
+	showNumber int32,
+) (int64, []*unrelation.MsgRevokeAuditModel, error) {
+	filter := bson.M{}
+	if conversationID != "" {
+		filter["conversation_id"] = conversationID
+	}
+	if userID != "" {
+		filter["$or"] = bson.A{bson.M{"revoker_user_id": userID}, bson.M{"send_id": userID}}
+	}
+	if startTime > 0 || endTime > 0 {
+		revokeTime := bson.M{}
+		if startTime > 0 {
+			revokeTime["$gte"] = startTime
+		}
+		if endTime > 0 {
+			revokeTime["$lte"] = endTime
+		}
+		filter["revoke_time"] = revokeTime
+	}
+	total, err := m.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "revoke_time", Value: -1}})
+	if pageNumber > 0 && showNumber > 0 {
+		opts.SetSkip(int64(pageNumber-1) * int64(showNumber)).SetLimit(int64(showNumber))
+	}
+	cursor, err := m.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	var audits []*unrelation.MsgRevokeAuditModel
+	if err := cursor.All(ctx, &audits); err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	return total, audits, nil
+}
@@ -0,0 +1,195 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/tools/errs"
+)
+
+func NewMsgSearchMongoDriver(database *mongo.Database) unrelation.MsgSearchModelInterface {
+	return &MsgSearchMongoDriver{
+		collection: database.Collection(unrelation.MsgSearch),
+	}
+}
+
+// MsgSearchMongoDriver searches msgs with a mongo text index on the flattened msgs.
+type MsgSearchMongoDriver struct {
+	collection *mongo.Collection
+}
+
+func (m *MsgSearchMongoDriver) Upsert(ctx context.Context, msgs []*unrelation.MsgSearchModel) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(msgs))
+	for _, msg := range msgs {
+		// $set不覆盖为空的del_list
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"conversation_id": msg.ConversationID, "seq": msg.Seq}).
+			SetUpdate(bson.M{"$set": msg}).
+			SetUpsert(true))
+	}
+	_, err := m.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return errs.Wrap(err)
+}
+
+func (m *MsgSearchMongoDriver) Delete(ctx context.Context, conversationID string, seqs []int64) error {
+	if len(seqs) == 0 {
+		return nil
+	}
+	_, err := m.collection.DeleteMany(ctx, bson.M{"conversation_id": conversationID, "seq": bson.M{"$in": seqs}})
+	return errs.Wrap(err)
+}
+
+func (m *MsgSearchMongoDriver) AddDelList(ctx context.Context, conversationID string, seqs []int64, userID string) error {
+	if len(seqs) == 0 {
+		return nil
+	}
+	_, err := m.collection.UpdateMany(ctx,
+		bson.M{"conversation_id": conversationID, "seq": bson.M{"$in": seqs}},
+		bson.M{"$addToSet": bson.M{"del_list": userID}},
+	)
+	return errs.Wrap(err)
+}
+
+func (m *MsgSearchMongoDriver) Search(ctx context.Context, cond *unrelation.MsgSearchCond, cursor string, count int32) ([]*unrelation.MsgSearchModel, string, error) {
+	filter := m.filter(cond)
+	if cursor != "" {
+		sendTime, id, err := parseSearchCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter["$or"] = bson.A{
+			bson.M{"send_time": bson.M{"$lt": sendTime}},
+			bson.M{"send_time": sendTime, "_id": bson.M{"$lt": id}},
+		}
+	}
+	// 多取一条判断是否还有下一页
+	opts := options.Find().SetSort(bson.D{{Key: "send_time", Value: -1}, {Key: "_id", Value: -1}}).SetLimit(int64(count) + 1)
+	msgs, err := m.find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(msgs) <= int(count) {
+		return msgs, "", nil
+	}
+	msgs = msgs[:count]
+	last := msgs[len(msgs)-1]
+	return msgs, strconv.FormatInt(last.SendTime, 10) + "_" + last.ID.Hex(), nil
+}
+
+func (m *MsgSearchMongoDriver) SearchPage(ctx context.Context, cond *unrelation.MsgSearchCond, pageNumber int32, showNumber int32) (int64, []*unrelation.MsgSearchModel, error) {
+	filter := m.filter(cond)
+	total, err := m.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "send_time", Value: -1}, {Key: "_id", Value: -1}})
+	if pageNumber > 0 && showNumber > 0 {
+		opts.SetSkip(int64(pageNumber-1) * int64(showNumber)).SetLimit(int64(showNumber))
+	}
+	msgs, err := m.find(ctx, filter, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	return total, msgs, nil
+}
+
+func (m *MsgSearchMongoDriver) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*unrelation.MsgSearchModel, error) {
+	cur, err := m.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var msgs []*unrelation.MsgSearchModel
+	if err := cur.All(ctx, &msgs); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return msgs, nil
+}
+
+func (m *MsgSearchMongoDriver) filter(cond *unrelation.MsgSearchCond) bson.M {
+	filter := bson.M{}
+	if cond.Keyword != "" {
+		filter["$text"] = bson.M{"$search": cond.Keyword}
+	}
+	if cond.SendID != "" {
+		filter["send_id"] = cond.SendID
+	}
+	if cond.RecvID != "" {
+		filter["recv_id"] = cond.RecvID
+	}
+	if cond.GroupID != "" {
+		filter["group_id"] = cond.GroupID
+	}
+	if cond.ConversationID != "" {
+		filter["conversation_id"] = cond.ConversationID
+	}
+	if cond.UserID != "" {
+		filter["del_list"] = bson.M{"$ne": cond.UserID}
+	}
+	if cond.MinSeq > 0 || cond.MaxSeq > 0 {
+		seq := bson.M{}
+		if cond.MinSeq > 0 {
+			seq["$gte"] = cond.MinSeq
+		}
+		if cond.MaxSeq > 0 {
+			seq["$lte"] = cond.MaxSeq
+		}
+		filter["seq"] = seq
+	}
+	if cond.SessionType != 0 {
+		filter["session_type"] = cond.SessionType
+	}
+	if len(cond.ContentTypes) > 0 {
+		filter["content_type"] = bson.M{"$in": cond.ContentTypes}
+	}
+	if cond.StartTime > 0 || cond.EndTime > 0 {
+		sendTime := bson.M{}
+		if cond.StartTime > 0 {
+			sendTime["$gte"] = cond.StartTime
+		}
+		if cond.EndTime > 0 {
+			sendTime["$lt"] = cond.EndTime
+		}
+		filter["send_time"] = sendTime
+	}
+	return filter
+}
+
+func parseSearchCursor(cursor string) (int64, primitive.ObjectID, error) {
+	i := strings.Index(cursor, "_")
+	if i <= 0 {
+		return 0, primitive.NilObjectID, errs.ErrArgs.Wrap("invalid cursor")
+	}
+	sendTime, err := strconv.ParseInt(cursor[:i], 10, 64)
+	if err != nil {
+		return 0, primitive.NilObjectID, errs.ErrArgs.Wrap("invalid cursor")
+	}
+	id, err := primitive.ObjectIDFromHex(cursor[i+1:])
+	if err != nil {
+		return 0, primitive.NilObjectID, errs.ErrArgs.Wrap("invalid cursor")
+	}
+	return sendTime, id, nil
+}
@@ -0,0 +1,127 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/tools/errs"
+)
+
+func NewScheduledMsgMongoDriver(database *mongo.Database) unrelation.ScheduledMsgModelInterface {
+	return &ScheduledMsgMongoDriver{
+		collection: database.Collection(unrelation.ScheduledMsg),
+	}
+}
+
+type ScheduledMsgMongoDriver struct {
+	collection *mongo.Collection
+}
+
+func (s *ScheduledMsgMongoDriver) Create(ctx context.Context, msg *unrelation.ScheduledMsgModel) error {
+	_, err := s.collection.InsertOne(ctx, msg)
+	if mongo.IsDuplicateKeyError(err) {
+		return errs.ErrDuplicateKey.Wrap("scheduled msg already exists")
+	}
+	return errs.Wrap(err)
+}
+
+func (s *ScheduledMsgMongoDriver) Take(ctx context.Context, now int64, lockTimeout int64) (*unrelation.ScheduledMsgModel, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{
+				"status":     unrelation.ScheduledMsgPending,
+				"send_time":  bson.M{"$lte": now},
+				"retry_time": bson.M{"$not": bson.M{"$gt": now}},
+			},
+			bson.M{"status": unrelation.ScheduledMsgSending, "lock_time": bson.M{"$lt": now - lockTimeout}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{"status": unrelation.ScheduledMsgSending, "lock_time": now, "update_time": now},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "send_time", Value: 1}}).SetReturnDocument(options.After)
+	var msg unrelation.ScheduledMsgModel
+	if err := s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&msg); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	return &msg, nil
+}
+
+func (s *ScheduledMsgMongoDriver) SetStatus(ctx context.Context, sendID string, clientMsgID string, status int32, errMsg string) error {
+	filter := bson.M{"send_id": sendID, "client_msg_id": clientMsgID}
+	update := bson.M{"$set": bson.M{"status": status, "err_msg": errMsg, "update_time": time.Now().UnixMilli()}}
+	_, err := s.collection.UpdateOne(ctx, filter, update)
+	return errs.Wrap(err)
+}
+
+func (s *ScheduledMsgMongoDriver) Retry(ctx context.Context, sendID string, clientMsgID string, retryTime int64, errMsg string) error {
+	filter := bson.M{"send_id": sendID, "client_msg_id": clientMsgID}
+	update := bson.M{"$set": bson.M{
+		"status":      unrelation.ScheduledMsgPending,
+		"retry_time":  retryTime,
+		"err_msg":     errMsg,
+		"update_time": time.Now().UnixMilli(),
+	}}
+	_, err := s.collection.UpdateOne(ctx, filter, update)
+	return errs.Wrap(err)
+}
+
+func (s *ScheduledMsgMongoDriver) Cancel(ctx context.Context, sendID string, clientMsgID string) (bool, error) {
+	filter := bson.M{"send_id": sendID, "client_msg_id": clientMsgID, "status": unrelation.ScheduledMsgPending}
+	update := bson.M{"$set": bson.M{"status": unrelation.ScheduledMsgCanceled, "update_time": time.Now().UnixMilli()}}
+	res, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (s *ScheduledMsgMongoDriver) FindBySendID(
+	ctx context.Context,
+	sendID string,
+	status int32,
+	pageNumber int32,
+	showNumber int32,
+) (int64, []*unrelation.ScheduledMsgModel, error) {
+	filter := bson.M{"send_id": sendID, "status": status}
+	total, err := s.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "send_time", Value: 1}})
+	if pageNumber > 0 && showNumber > 0 {
+		opts.SetSkip(int64(pageNumber-1) * int64(showNumber)).SetLimit(int64(showNumber))
+	}
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	var msgs []*unrelation.ScheduledMsgModel
+	if err := cursor.All(ctx, &msgs); err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	return total, msgs, nil
+}
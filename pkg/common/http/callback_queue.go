@@ -0,0 +1,81 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	urlLib "net/url"
+	"sync"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/kafka"
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/mcontext"
+)
+
+// CallbackTask is an after callback queued in kafka, msg_transfer posts it to every url.
+type CallbackTask struct {
+	OperationID string          `json:"operationID"`
+	Command     string          `json:"command"`
+	URLs        []string        `json:"urls"`
+	Timeout     int             `json:"timeout"`
+	Body        json.RawMessage `json:"body"`
+}
+
+var (
+	callbackProducerOnce sync.Once
+	callbackProducer     *kafka.Producer
+)
+
+func enqueueCallback(ctx context.Context, command string, urls []string, input interface{}, timeout int) error {
+	if config.Config.Kafka.Callback.Topic == "" {
+		return errs.ErrInternalServer.Wrap("kafka callback topic is empty")
+	}
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	task := CallbackTask{
+		OperationID: mcontext.GetOperationID(ctx),
+		Command:     command,
+		URLs:        urls,
+		Timeout:     timeout,
+		Body:        body,
+	}
+	value, err := json.Marshal(&task)
+	if err != nil {
+		return err
+	}
+	// 只有开启异步回调的服务才连接kafka
+	callbackProducerOnce.Do(func() {
+		callbackProducer = kafka.NewKafkaProducer(config.Config.Kafka.Addr, config.Config.Kafka.Callback.Topic)
+	})
+	headers := []sarama.RecordHeader{{Key: []byte(constant.OperationID), Value: []byte(task.OperationID)}}
+	_, _, err = callbackProducer.SendRawMessage(ctx, task.OperationID, value, headers)
+	return err
+}
+
+// PostCallbackTask posts a queued callback to url, only a failed request is an error,
+// the receiver has no say in an after callback.
+func PostCallbackTask(ctx context.Context, task *CallbackTask, url string) error {
+	v := urlLib.Values{}
+	v.Set(constant.CallbackCommand, task.Command)
+	_, err := callbackPost(ctx, url+"?"+v.Encode(), task.Command, task.Body, task.Timeout)
+	return err
+}
@@ -24,8 +24,10 @@ import (
 	urlLib "net/url"
 	"time"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/callbacksign"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/callbackstruct"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/kafka"
 	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/tools/errs"
 	"github.com/OpenIMSDK/tools/log"
@@ -63,7 +65,11 @@ func Post(
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonStr))
+	return post(ctx, url, header, jsonStr)
+}
+
+func post(ctx context.Context, url string, header map[string]string, body []byte) (content []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
@@ -109,10 +115,46 @@ func callBackPostReturn(
 	callbackConfig config.CallBackConfig,
 ) error {
 	defer log.ZDebug(ctx, "callback", "url", url, "command", command, "input", input, "callbackConfig", callbackConfig)
+	urls := callbackConfig.URLs
+	if len(urls) == 0 {
+		urls = []string{url}
+	}
+	if callbackConfig.Async {
+		err := enqueueCallback(ctx, command, urls, input, callbackConfig.CallbackTimeOut)
+		if err == nil {
+			return nil
+		}
+		log.ZWarn(ctx, "enqueue callback failed, post directly", err, "command", command)
+	}
+	var continueErr error
+	for _, url := range urls {
+		if err := callBackPostURL(ctx, url, command, input, output, callbackConfig); err != nil {
+			if err == errs.ErrCallbackContinue {
+				continueErr = err
+				continue
+			}
+			return err
+		}
+	}
+	return continueErr
+}
+
+// callBackPostURL calls one callback url, failed requests are retried by callbackConfig.Retry.
+func callBackPostURL(
+	ctx context.Context,
+	url, command string,
+	input interface{},
+	output callbackstruct.CallbackResp,
+	callbackConfig config.CallBackConfig,
+) error {
 	v := urlLib.Values{}
 	v.Set(constant.CallbackCommand, command)
 	url = url + "?" + v.Encode()
-	b, err := Post(ctx, url, nil, input, callbackConfig.CallbackTimeOut)
+	var b []byte
+	_, err := kafka.NewRetryPolicy(callbackConfig.Retry).Do(ctx, "callback "+command, func() (err error) {
+		b, err = callbackPost(ctx, url, command, input, callbackConfig.CallbackTimeOut)
+		return err
+	})
 	if err != nil {
 		if callbackConfig.CallbackFailedContinue != nil && *callbackConfig.CallbackFailedContinue {
 			log.ZWarn(ctx, "callback failed but continue", err, "url", url)
@@ -130,6 +172,27 @@ func callBackPostReturn(
 	return output.Parse()
 }
 
+// callbackPost posts input like Post, signed with the callback secrets when signing is enabled.
+func callbackPost(ctx context.Context, url, command string, input interface{}, timeout int) ([]byte, error) {
+	if !config.Config.Callback.Sign.Enable {
+		return Post(ctx, url, nil, input, timeout)
+	}
+	if timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, time.Second*time.Duration(timeout))
+		defer cancel()
+	}
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]callbacksign.Secret, 0, len(config.Config.Callback.Sign.Secrets))
+	for _, secret := range config.Config.Callback.Sign.Secrets {
+		secrets = append(secrets, callbacksign.Secret{KeyID: secret.KeyID, Secret: secret.Secret})
+	}
+	return post(ctx, url, callbacksign.Header(secrets, time.Now(), command, body), body)
+}
+
 func CallBackPostReturn(
 	ctx context.Context,
 	url string,
@@ -0,0 +1,158 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/prome"
+	"github.com/OpenIMSDK/tools/log"
+)
+
+// Headers added to a dead-lettered message, the original headers are kept after them.
+const (
+	DeadLetterTopic     = "dlq.topic"
+	DeadLetterPartition = "dlq.partition"
+	DeadLetterOffset    = "dlq.offset"
+	DeadLetterSource    = "dlq.source"
+	DeadLetterReason    = "dlq.reason"
+	DeadLetterAttempts  = "dlq.attempts"
+	DeadLetterTime      = "dlq.time"
+)
+
+// DeadLetter is a message that could not be handled, Topic is where it is replayed to.
+// Partition and Offset are -1 when the message failed to be produced rather than consumed.
+type DeadLetter struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Source    string
+	Reason    string
+	Attempts  int
+	Time      time.Time
+	Key       string
+	Value     []byte
+	Headers   []sarama.RecordHeader
+}
+
+// NewConsumedDeadLetter dead-letters a consumed message, source is the consumer group that failed on it.
+func NewConsumedDeadLetter(msg *sarama.ConsumerMessage, source string, attempts int, reason error) *DeadLetter {
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers))
+	for _, h := range msg.Headers {
+		headers = append(headers, *h)
+	}
+	return &DeadLetter{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Source:    source,
+		Reason:    reason.Error(),
+		Attempts:  attempts,
+		Time:      time.Now(),
+		Key:       string(msg.Key),
+		Value:     msg.Value,
+		Headers:   headers,
+	}
+}
+
+// NewProducedDeadLetter dead-letters a message that could not be sent to topic.
+func NewProducedDeadLetter(ctx context.Context, topic, key string, value []byte, source string, attempts int, reason error) *DeadLetter {
+	headers, _ := GetMQHeaderWithContext(ctx)
+	return &DeadLetter{
+		Topic:     topic,
+		Partition: -1,
+		Offset:    -1,
+		Source:    source,
+		Reason:    reason.Error(),
+		Attempts:  attempts,
+		Time:      time.Now(),
+		Key:       key,
+		Value:     value,
+		Headers:   headers,
+	}
+}
+
+// ParseDeadLetter reads a message consumed from the dead letter topic.
+func ParseDeadLetter(msg *sarama.ConsumerMessage) *DeadLetter {
+	dl := &DeadLetter{Key: string(msg.Key), Value: msg.Value, Partition: -1, Offset: -1}
+	for _, h := range msg.Headers {
+		value := string(h.Value)
+		switch string(h.Key) {
+		case DeadLetterTopic:
+			dl.Topic = value
+		case DeadLetterPartition:
+			partition, _ := strconv.ParseInt(value, 10, 32)
+			dl.Partition = int32(partition)
+		case DeadLetterOffset:
+			dl.Offset, _ = strconv.ParseInt(value, 10, 64)
+		case DeadLetterSource:
+			dl.Source = value
+		case DeadLetterReason:
+			dl.Reason = value
+		case DeadLetterAttempts:
+			dl.Attempts, _ = strconv.Atoi(value)
+		case DeadLetterTime:
+			dl.Time, _ = time.Parse(time.RFC3339Nano, value)
+		default:
+			if !strings.HasPrefix(string(h.Key), "dlq.") {
+				dl.Headers = append(dl.Headers, *h)
+			}
+		}
+	}
+	return dl
+}
+
+func (dl *DeadLetter) headers() []sarama.RecordHeader {
+	headers := []sarama.RecordHeader{
+		{Key: []byte(DeadLetterTopic), Value: []byte(dl.Topic)},
+		{Key: []byte(DeadLetterPartition), Value: []byte(strconv.Itoa(int(dl.Partition)))},
+		{Key: []byte(DeadLetterOffset), Value: []byte(strconv.FormatInt(dl.Offset, 10))},
+		{Key: []byte(DeadLetterSource), Value: []byte(dl.Source)},
+		{Key: []byte(DeadLetterReason), Value: []byte(dl.Reason)},
+		{Key: []byte(DeadLetterAttempts), Value: []byte(strconv.Itoa(dl.Attempts))},
+		{Key: []byte(DeadLetterTime), Value: []byte(dl.Time.Format(time.RFC3339Nano))},
+	}
+	return append(headers, dl.Headers...)
+}
+
+type DeadLetterProducer struct {
+	producer *Producer
+}
+
+func NewDeadLetterProducer() *DeadLetterProducer {
+	return &DeadLetterProducer{
+		producer: NewKafkaProducer(config.Config.Kafka.Addr, config.Config.Kafka.DeadLetter.Topic),
+	}
+}
+
+// Send writes dl to the dead letter topic, if that fails too the payload is only left in the error log.
+// The error is returned for consumers that must not commit the offset of a message that was not parked.
+func (d *DeadLetterProducer) Send(ctx context.Context, dl *DeadLetter) error {
+	prome.IncVec(prome.DeadLetterCounter, dl.Source, dl.Topic)
+	log.ZWarn(ctx, "dead letter", nil, "topic", dl.Topic, "partition", dl.Partition, "offset", dl.Offset,
+		"source", dl.Source, "reason", dl.Reason, "attempts", dl.Attempts, "key", dl.Key)
+	if _, _, err := d.producer.SendRawMessage(ctx, dl.Key, dl.Value, dl.headers()); err != nil {
+		log.ZError(ctx, "send dead letter failed, message lost", err, "topic", dl.Topic, "partition", dl.Partition,
+			"offset", dl.Offset, "source", dl.Source, "reason", dl.Reason, "key", dl.Key, "value", dl.Value)
+		return err
+	}
+	return nil
+}
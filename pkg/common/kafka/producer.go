@@ -105,6 +105,23 @@ func GetContextWithMQHeader(header []*sarama.RecordHeader) context.Context {
 	return mcontext.WithMustInfoCtx(values) // TODO
 }
 
+// SendRawMessage sends an already encoded value, the headers are sent as is.
+func (p *Producer) SendRawMessage(ctx context.Context, key string, value []byte, headers []sarama.RecordHeader) (int32, int64, error) {
+	if len(value) == 0 {
+		return 0, 0, utils.Wrap(errEmptyMsg, "")
+	}
+	kMsg := &sarama.ProducerMessage{
+		Topic:    p.topic,
+		Key:      sarama.StringEncoder(key),
+		Value:    sarama.ByteEncoder(value),
+		Headers:  headers,
+		Metadata: ctx,
+	}
+	partition, offset, err := p.producer.SendMessage(kMsg)
+	log.ZDebug(ctx, "SendRawMessage end", "topic", p.topic, "key", key, "partition", partition, "offset", offset)
+	return partition, offset, utils.Wrap(err, "")
+}
+
 func (p *Producer) SendMessage(ctx context.Context, key string, msg proto.Message) (int32, int64, error) {
 	log.ZDebug(ctx, "SendMessage", "msg", msg, "topic", p.topic, "key", key)
 	kMsg := &sarama.ProducerMessage{}
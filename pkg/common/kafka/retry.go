@@ -0,0 +1,63 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/tools/log"
+)
+
+// RetryPolicy retries the handling of a consumed message with a doubling backoff,
+// the message is dead-lettered once MaxAttempts tries have failed.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+func NewRetryPolicy(conf config.KafkaRetry) RetryPolicy {
+	r := RetryPolicy{
+		MaxAttempts: conf.MaxAttempts,
+		Backoff:     time.Duration(conf.Backoff) * time.Millisecond,
+		MaxBackoff:  time.Duration(conf.MaxBackoff) * time.Millisecond,
+	}
+	if r.MaxAttempts < 1 {
+		r.MaxAttempts = 1
+	}
+	return r
+}
+
+// Do calls fn until it succeeds, MaxAttempts is reached or ctx is done, it returns the number of attempts and the last error.
+func (r RetryPolicy) Do(ctx context.Context, name string, fn func() error) (attempts int, err error) {
+	backoff := r.Backoff
+	for attempts = 1; ; attempts++ {
+		if err = fn(); err == nil || attempts >= r.MaxAttempts {
+			return attempts, err
+		}
+		log.ZWarn(ctx, "retry "+name, err, "attempts", attempts, "backoff", backoff)
+		select {
+		case <-ctx.Done():
+			return attempts, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if r.MaxBackoff > 0 && backoff > r.MaxBackoff {
+			backoff = r.MaxBackoff
+		}
+	}
+}
@@ -49,6 +49,10 @@ var (
 	GroupChatMsgRecvSuccessCounter          prometheus.Counter
 	WorkSuperGroupChatMsgRecvSuccessCounter prometheus.Counter
 	OnlineUserGauge                         prometheus.Gauge
+	MsgGatewayRateLimitCounter              *prometheus.CounterVec
+	MsgGatewayRateLimitKickCounter          prometheus.Counter
+	MsgGatewayPushAckLatency                *prometheus.HistogramVec
+	MsgGatewayPushAckTimeoutCounter         *prometheus.CounterVec
 
 	// msg-msg.
 	SingleChatMsgProcessSuccessCounter         prometheus.Counter
@@ -72,7 +76,8 @@ var (
 	GrpcRequestSuccessCounter prometheus.Counter
 	GrpcRequestFailedCounter  prometheus.Counter
 
-	SendMsgCounter prometheus.Counter
+	SendMsgCounter    prometheus.Counter
+	DeadLetterCounter *prometheus.CounterVec
 
 	// conversation.
 	ConversationCreateSuccessCounter prometheus.Counter
@@ -468,3 +473,54 @@ func NewConversationCreateFailedCounter() {
 		Help: "The number of conversation failed pushed",
 	})
 }
+
+func NewMsgGatewayRateLimitCounter() {
+	if MsgGatewayRateLimitCounter != nil {
+		return
+	}
+	MsgGatewayRateLimitCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "msg_gateway_rate_limit",
+		Help: "The number of ws requests rejected by the rate limiter",
+	}, []string{"reqIdentifier", "scope"})
+}
+
+func NewMsgGatewayRateLimitKickCounter() {
+	if MsgGatewayRateLimitKickCounter != nil {
+		return
+	}
+	MsgGatewayRateLimitKickCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "msg_gateway_rate_limit_kick",
+		Help: "The number of ws connections kicked for exceeding the rate limit",
+	})
+}
+
+func NewMsgGatewayPushAckLatency() {
+	if MsgGatewayPushAckLatency != nil {
+		return
+	}
+	MsgGatewayPushAckLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "msg_gateway_push_ack_latency_seconds",
+		Help:    "The delay between an online push and its ack by the client",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"platform"})
+}
+
+func NewMsgGatewayPushAckTimeoutCounter() {
+	if MsgGatewayPushAckTimeoutCounter != nil {
+		return
+	}
+	MsgGatewayPushAckTimeoutCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "msg_gateway_push_ack_timeout",
+		Help: "The number of online pushes not acked by the client in time",
+	}, []string{"platform"})
+}
+
+func NewDeadLetterCounter() {
+	if DeadLetterCounter != nil {
+		return
+	}
+	DeadLetterCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_dead_letter",
+		Help: "The number of kafka messages sent to the dead letter topic",
+	}, []string{"source", "topic"})
+}
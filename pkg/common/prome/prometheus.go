@@ -80,6 +80,30 @@ func Add(counter prometheus.Counter, add int) {
 	}
 }
 
+func IncVec(counter *prometheus.CounterVec, labelValues ...string) {
+	if config.Config.Prometheus.Enable {
+		if counter != nil {
+			counter.WithLabelValues(labelValues...).Inc()
+		}
+	}
+}
+
+func AddVec(counter *prometheus.CounterVec, add int, labelValues ...string) {
+	if config.Config.Prometheus.Enable {
+		if counter != nil {
+			counter.WithLabelValues(labelValues...).Add(float64(add))
+		}
+	}
+}
+
+func ObserveVec(histogram *prometheus.HistogramVec, value float64, labelValues ...string) {
+	if config.Config.Prometheus.Enable {
+		if histogram != nil {
+			histogram.WithLabelValues(labelValues...).Observe(value)
+		}
+	}
+}
+
 func GaugeInc(gauges prometheus.Gauge) {
 	if config.Config.Prometheus.Enable {
 		if gauges != nil {
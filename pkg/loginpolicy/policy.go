@@ -0,0 +1,175 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loginpolicy decides which sessions of a user may stay logged in when a new login arrives,
+// the auth service applies it when issuing tokens and the gateway when a connection is made.
+package loginpolicy
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+)
+
+// LoginRejected follows the token error codes of the tools errs package.
+const LoginRejected = 1508
+
+var ErrLoginRejected = errs.NewCodeError(LoginRejected, "LoginRejectedError")
+
+const (
+	OnExceedKickOldest = "kickOldest"
+	OnExceedReject     = "reject"
+)
+
+// Rule limits the concurrent sessions on its platforms, a MaxSessions of 0 means unlimited.
+type Rule struct {
+	Name        string
+	PlatformIDs []int
+	MaxSessions int
+	Reject      bool
+}
+
+// Session is a login, the tokens refreshed from it belong to the same session.
+type Session struct {
+	ID         string
+	PlatformID int
+	LoginTime  int64
+}
+
+type Policy struct {
+	platformRules map[int]*Rule
+}
+
+func New(rules []*Rule) (*Policy, error) {
+	p := &Policy{platformRules: make(map[int]*Rule)}
+	for _, rule := range rules {
+		if rule.MaxSessions < 0 {
+			return nil, errs.ErrArgs.Wrap(fmt.Sprintf("multi login rule %s: maxSessions < 0", rule.Name))
+		}
+		for _, platformID := range rule.PlatformIDs {
+			other, ok := p.platformRules[platformID]
+			if other == rule {
+				// class and platformIDs of one rule may name the same platform
+				continue
+			}
+			if ok {
+				return nil, errs.ErrArgs.Wrap(fmt.Sprintf("multi login rules %s and %s both cover platform %d", other.Name, rule.Name, platformID))
+			}
+			p.platformRules[platformID] = rule
+		}
+	}
+	return p, nil
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultPolicy *Policy
+	defaultErr    error
+)
+
+// Default is built from multiLoginRules once, or from multiLoginPolicy when there are no rules.
+// Services call it at startup so that a bad config fails fast instead of on every login.
+func Default() (*Policy, error) {
+	defaultOnce.Do(func() {
+		defaultPolicy, defaultErr = New(configRules())
+	})
+	return defaultPolicy, defaultErr
+}
+
+func configRules() []*Rule {
+	if len(config.Config.MultiLoginRules) == 0 {
+		return legacyRules(config.Config.MultiLoginPolicy)
+	}
+	rules := make([]*Rule, 0, len(config.Config.MultiLoginRules))
+	for _, r := range config.Config.MultiLoginRules {
+		rule := &Rule{
+			Name:        r.Name,
+			PlatformIDs: append([]int{}, r.PlatformIDs...),
+			MaxSessions: r.MaxSessions,
+			Reject:      r.OnExceed == OnExceedReject,
+		}
+		if r.Class != "" {
+			for platformID, class := range constant.PlatformID2class {
+				if class == r.Class {
+					rule.PlatformIDs = append(rule.PlatformIDs, platformID)
+				}
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// legacyRules expresses the multiLoginPolicy constants as rules, the ones never supported stay unlimited.
+func legacyRules(policy int) []*Rule {
+	var rules []*Rule
+	switch policy {
+	case constant.AllLoginButSameTermKick, constant.PCAndOther:
+		for platformID, name := range constant.PlatformID2Name {
+			if policy == constant.PCAndOther && constant.PlatformIDToClass(platformID) == constant.TerminalPC {
+				continue
+			}
+			rules = append(rules, &Rule{Name: name, PlatformIDs: []int{platformID}, MaxSessions: 1})
+		}
+	}
+	return rules
+}
+
+// Limited reports whether logins on the platform are limited by a rule.
+func (p *Policy) Limited(platformID int) bool {
+	rule, ok := p.platformRules[platformID]
+	return ok && rule.MaxSessions > 0
+}
+
+// Check returns the sessions to kick when current logs in, sessions are the active sessions of the user
+// and may already contain current. Current is issued before the check, so concurrent logins see each other
+// and at most MaxSessions of them stay. ErrLoginRejected is returned when current itself must go, either
+// because the rule rejects new logins or because newer logins took all the places.
+func (p *Policy) Check(sessions []Session, current Session) ([]Session, error) {
+	rule, ok := p.platformRules[current.PlatformID]
+	if !ok || rule.MaxSessions <= 0 {
+		return nil, nil
+	}
+	covered := []Session{current}
+	for _, session := range sessions {
+		if session.ID != current.ID && p.platformRules[session.PlatformID] == rule {
+			covered = append(covered, session)
+		}
+	}
+	exceed := len(covered) - rule.MaxSessions
+	if exceed <= 0 {
+		return nil, nil
+	}
+	if rule.Reject {
+		return nil, ErrLoginRejected.Wrap(fmt.Sprintf("multi login rule %s allows %d sessions", rule.Name, rule.MaxSessions))
+	}
+	sort.Slice(covered, func(i, j int) bool {
+		if covered[i].LoginTime != covered[j].LoginTime {
+			return covered[i].LoginTime < covered[j].LoginTime
+		}
+		return covered[i].ID < covered[j].ID
+	})
+	kicks := covered[:exceed]
+	for _, session := range kicks {
+		if session.ID == current.ID {
+			return nil, ErrLoginRejected.Wrap(fmt.Sprintf("multi login rule %s: newer sessions logged in", rule.Name))
+		}
+	}
+	return kicks, nil
+}
@@ -0,0 +1,223 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loginpolicy
+
+import (
+	"testing"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func sessionIDs(sessions []Session) []string {
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	return ids
+}
+
+func TestCheck(t *testing.T) {
+	policy, err := New([]*Rule{
+		{Name: "mobile", PlatformIDs: []int{constant.IOSPlatformID, constant.AndroidPlatformID}, MaxSessions: 2},
+		{Name: "pc", PlatformIDs: []int{constant.WindowsPlatformID, constant.OSXPlatformID}, MaxSessions: 1, Reject: true},
+		{Name: "web", PlatformIDs: []int{constant.WebPlatformID}, MaxSessions: 0},
+	})
+	assert.Nil(t, err)
+	tests := []struct {
+		name     string
+		sessions []Session
+		current  Session
+		kicks    []string
+		rejected bool
+	}{
+		{
+			name:     "under limit",
+			sessions: []Session{{ID: "a", PlatformID: constant.IOSPlatformID, LoginTime: 1}},
+			current:  Session{ID: "new", PlatformID: constant.AndroidPlatformID, LoginTime: 5},
+		},
+		{
+			name: "kick oldest",
+			sessions: []Session{
+				{ID: "b", PlatformID: constant.AndroidPlatformID, LoginTime: 2},
+				{ID: "a", PlatformID: constant.IOSPlatformID, LoginTime: 1},
+			},
+			current: Session{ID: "new", PlatformID: constant.IOSPlatformID, LoginTime: 5},
+			kicks:   []string{"a"},
+		},
+		{
+			name: "kick oldest with current in sessions",
+			sessions: []Session{
+				{ID: "new", PlatformID: constant.IOSPlatformID, LoginTime: 5},
+				{ID: "c", PlatformID: constant.IOSPlatformID, LoginTime: 3},
+				{ID: "a", PlatformID: constant.AndroidPlatformID, LoginTime: 1},
+				{ID: "b", PlatformID: constant.IOSPlatformID, LoginTime: 2},
+			},
+			current: Session{ID: "new", PlatformID: constant.IOSPlatformID, LoginTime: 5},
+			kicks:   []string{"a", "b"},
+		},
+		{
+			name: "kick oldest ignores other rules",
+			sessions: []Session{
+				{ID: "pc", PlatformID: constant.WindowsPlatformID, LoginTime: 0},
+				{ID: "a", PlatformID: constant.IOSPlatformID, LoginTime: 1},
+				{ID: "b", PlatformID: constant.IOSPlatformID, LoginTime: 2},
+			},
+			current: Session{ID: "new", PlatformID: constant.AndroidPlatformID, LoginTime: 5},
+			kicks:   []string{"a"},
+		},
+		{
+			name: "newer logins took the places",
+			sessions: []Session{
+				{ID: "a", PlatformID: constant.IOSPlatformID, LoginTime: 6},
+				{ID: "b", PlatformID: constant.IOSPlatformID, LoginTime: 7},
+			},
+			current:  Session{ID: "new", PlatformID: constant.IOSPlatformID, LoginTime: 5},
+			rejected: true,
+		},
+		{
+			name:     "reject",
+			sessions: []Session{{ID: "a", PlatformID: constant.OSXPlatformID, LoginTime: 1}},
+			current:  Session{ID: "new", PlatformID: constant.WindowsPlatformID, LoginTime: 5},
+			rejected: true,
+		},
+		{
+			name: "reject concurrent logins",
+			sessions: []Session{
+				{ID: "other", PlatformID: constant.OSXPlatformID, LoginTime: 5},
+				{ID: "new", PlatformID: constant.WindowsPlatformID, LoginTime: 5},
+			},
+			current:  Session{ID: "new", PlatformID: constant.WindowsPlatformID, LoginTime: 5},
+			rejected: true,
+		},
+		{
+			name:     "reject under limit",
+			sessions: []Session{{ID: "new", PlatformID: constant.WindowsPlatformID, LoginTime: 5}},
+			current:  Session{ID: "new", PlatformID: constant.WindowsPlatformID, LoginTime: 5},
+		},
+		{
+			name: "unlimited rule",
+			sessions: []Session{
+				{ID: "a", PlatformID: constant.WebPlatformID, LoginTime: 1},
+				{ID: "b", PlatformID: constant.WebPlatformID, LoginTime: 2},
+			},
+			current: Session{ID: "new", PlatformID: constant.WebPlatformID, LoginTime: 5},
+		},
+		{
+			name:     "platform without rule",
+			sessions: []Session{{ID: "a", PlatformID: constant.LinuxPlatformID, LoginTime: 1}},
+			current:  Session{ID: "new", PlatformID: constant.LinuxPlatformID, LoginTime: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kicks, err := policy.Check(tt.sessions, tt.current)
+			if tt.rejected {
+				assert.True(t, ErrLoginRejected.Is(err))
+				return
+			}
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, tt.kicks, sessionIDs(kicks))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []*Rule
+		ok    bool
+	}{
+		{
+			name:  "duplicate platform in one rule",
+			rules: []*Rule{{Name: "pc", PlatformIDs: []int{constant.WindowsPlatformID, constant.WindowsPlatformID}, MaxSessions: 1}},
+			ok:    true,
+		},
+		{
+			name: "platform in two rules",
+			rules: []*Rule{
+				{Name: "a", PlatformIDs: []int{constant.WindowsPlatformID}, MaxSessions: 1},
+				{Name: "b", PlatformIDs: []int{constant.WindowsPlatformID}, MaxSessions: 1},
+			},
+		},
+		{
+			name:  "negative max sessions",
+			rules: []*Rule{{Name: "a", PlatformIDs: []int{constant.WindowsPlatformID}, MaxSessions: -1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.rules)
+			assert.Equal(t, tt.ok, err == nil)
+		})
+	}
+}
+
+func TestLegacyRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  int
+		limited map[int]bool
+	}{
+		{
+			name:   "all login but same terminal kick",
+			policy: constant.AllLoginButSameTermKick,
+			limited: map[int]bool{
+				constant.IOSPlatformID:     true,
+				constant.AndroidPlatformID: true,
+				constant.WindowsPlatformID: true,
+				constant.WebPlatformID:     true,
+			},
+		},
+		{
+			name:   "pc and other",
+			policy: constant.PCAndOther,
+			limited: map[int]bool{
+				constant.IOSPlatformID:     true,
+				constant.WebPlatformID:     true,
+				constant.WindowsPlatformID: false,
+				constant.OSXPlatformID:     false,
+				constant.LinuxPlatformID:   false,
+			},
+		},
+		{
+			name:   "unsupported policy",
+			policy: 2,
+			limited: map[int]bool{
+				constant.IOSPlatformID:     false,
+				constant.WindowsPlatformID: false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := New(legacyRules(tt.policy))
+			assert.Nil(t, err)
+			for platformID, limited := range tt.limited {
+				assert.Equal(t, limited, policy.Limited(platformID), "platform %d", platformID)
+				if !limited {
+					continue
+				}
+				// one session per platform, the old one is kicked
+				kicks, err := policy.Check(
+					[]Session{{ID: "old", PlatformID: platformID, LoginTime: 1}},
+					Session{ID: "new", PlatformID: platformID, LoginTime: 2},
+				)
+				assert.Nil(t, err)
+				assert.Equal(t, []string{"old"}, sessionIDs(kicks))
+			}
+		})
+	}
+}
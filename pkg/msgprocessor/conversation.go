@@ -5,6 +5,7 @@ import (
 	"github.com/OpenIMSDK/protocol/sdkws"
 	"google.golang.org/protobuf/proto"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -107,6 +108,40 @@ func IsNotificationByMsg(msg *sdkws.MsgData) bool {
 	return !Options(msg.Options).IsNotNotification()
 }
 
+// GetThreadConversationID the replies of a thread have their own seqs under this conversationID.
+func GetThreadConversationID(groupID string, rootSeq int64) string {
+	return "th_" + groupID + "_" + strconv.FormatInt(rootSeq, 10)
+}
+
+func IsThread(conversationID string) bool {
+	return strings.HasPrefix(conversationID, "th_")
+}
+
+// ParseThreadConversationID returns the groupID and the seq of the root msg of a thread.
+func ParseThreadConversationID(conversationID string) (groupID string, rootSeq int64, ok bool) {
+	if !IsThread(conversationID) {
+		return "", 0, false
+	}
+	s := strings.TrimPrefix(conversationID, "th_")
+	i := strings.LastIndex(s, "_")
+	if i <= 0 {
+		return "", 0, false
+	}
+	rootSeq, err := strconv.ParseInt(s[i+1:], 10, 64)
+	if err != nil || rootSeq <= 0 {
+		return "", 0, false
+	}
+	return s[:i], rootSeq, true
+}
+
+// ParseSuperGroupConversationID returns the groupID of a super group chat conversation.
+func ParseSuperGroupConversationID(conversationID string) (groupID string, ok bool) {
+	if !strings.HasPrefix(conversationID, "sg_") || len(conversationID) == len("sg_") {
+		return "", false
+	}
+	return strings.TrimPrefix(conversationID, "sg_"), true
+}
+
 func ParseConversationID(msg *sdkws.MsgData) (isNotification bool, conversationID string) {
 	options := Options(msg.Options)
 	switch msg.SessionType {
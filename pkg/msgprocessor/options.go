@@ -124,6 +124,9 @@ func WithReactionFromCache() OptionsOpt {
 	}
 }
 
+// IsScheduled 客户端显式指定的定时消息, 按sendTime延迟发送, 默认不是定时消息.
+const IsScheduled = "scheduled"
+
 func (o Options) Is(notification string) bool {
 	v, ok := o[notification]
 	if !ok || v {
@@ -0,0 +1,464 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.22.0
+// source: authext/authext.proto
+
+package authext
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	AuthExt_GetUserTokens_FullMethodName        = "/OpenIMServer.authext.authExt/GetUserTokens"
+	AuthExt_RefreshToken_FullMethodName         = "/OpenIMServer.authext.authExt/RefreshToken"
+	AuthExt_CreateAppCredential_FullMethodName  = "/OpenIMServer.authext.authExt/CreateAppCredential"
+	AuthExt_RevokeAppCredential_FullMethodName  = "/OpenIMServer.authext.authExt/RevokeAppCredential"
+	AuthExt_GetAppCredentials_FullMethodName    = "/OpenIMServer.authext.authExt/GetAppCredentials"
+	AuthExt_VerifyAppCredential_FullMethodName  = "/OpenIMServer.authext.authExt/VerifyAppCredential"
+	AuthExt_GetAppCredentialLogs_FullMethodName = "/OpenIMServer.authext.authExt/GetAppCredentialLogs"
+	AuthExt_GetSessions_FullMethodName          = "/OpenIMServer.authext.authExt/GetSessions"
+	AuthExt_RevokeSession_FullMethodName        = "/OpenIMServer.authext.authExt/RevokeSession"
+	AuthExt_RevokeOtherSessions_FullMethodName  = "/OpenIMServer.authext.authExt/RevokeOtherSessions"
+)
+
+// AuthExtClient is the client API for AuthExt service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthExtClient interface {
+	GetUserTokens(ctx context.Context, in *GetUserTokensReq, opts ...grpc.CallOption) (*GetUserTokensResp, error)
+	// the refresh token is rotated on every call, reusing an old one revokes all tokens issued from the same login
+	RefreshToken(ctx context.Context, in *RefreshTokenReq, opts ...grpc.CallOption) (*RefreshTokenResp, error)
+	CreateAppCredential(ctx context.Context, in *CreateAppCredentialReq, opts ...grpc.CallOption) (*CreateAppCredentialResp, error)
+	// takes effect on the next request made with the credential
+	RevokeAppCredential(ctx context.Context, in *RevokeAppCredentialReq, opts ...grpc.CallOption) (*RevokeAppCredentialResp, error)
+	GetAppCredentials(ctx context.Context, in *GetAppCredentialsReq, opts ...grpc.CallOption) (*GetAppCredentialsResp, error)
+	// called by the api for every request made with a credential, the result is written to the audit log
+	VerifyAppCredential(ctx context.Context, in *VerifyAppCredentialReq, opts ...grpc.CallOption) (*VerifyAppCredentialResp, error)
+	GetAppCredentialLogs(ctx context.Context, in *GetAppCredentialLogsReq, opts ...grpc.CallOption) (*GetAppCredentialLogsResp, error)
+	// logins of the user with a usable token, a login and the tokens refreshed from it are one session
+	GetSessions(ctx context.Context, in *GetSessionsReq, opts ...grpc.CallOption) (*GetSessionsResp, error)
+	// marks the tokens of the session kicked and kicks the connections of its platform
+	RevokeSession(ctx context.Context, in *RevokeSessionReq, opts ...grpc.CallOption) (*RevokeSessionResp, error)
+	RevokeOtherSessions(ctx context.Context, in *RevokeOtherSessionsReq, opts ...grpc.CallOption) (*RevokeOtherSessionsResp, error)
+}
+
+type authExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthExtClient(cc grpc.ClientConnInterface) AuthExtClient {
+	return &authExtClient{cc}
+}
+
+func (c *authExtClient) GetUserTokens(ctx context.Context, in *GetUserTokensReq, opts ...grpc.CallOption) (*GetUserTokensResp, error) {
+	out := new(GetUserTokensResp)
+	err := c.cc.Invoke(ctx, AuthExt_GetUserTokens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authExtClient) RefreshToken(ctx context.Context, in *RefreshTokenReq, opts ...grpc.CallOption) (*RefreshTokenResp, error) {
+	out := new(RefreshTokenResp)
+	err := c.cc.Invoke(ctx, AuthExt_RefreshToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authExtClient) CreateAppCredential(ctx context.Context, in *CreateAppCredentialReq, opts ...grpc.CallOption) (*CreateAppCredentialResp, error) {
+	out := new(CreateAppCredentialResp)
+	err := c.cc.Invoke(ctx, AuthExt_CreateAppCredential_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authExtClient) RevokeAppCredential(ctx context.Context, in *RevokeAppCredentialReq, opts ...grpc.CallOption) (*RevokeAppCredentialResp, error) {
+	out := new(RevokeAppCredentialResp)
+	err := c.cc.Invoke(ctx, AuthExt_RevokeAppCredential_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authExtClient) GetAppCredentials(ctx context.Context, in *GetAppCredentialsReq, opts ...grpc.CallOption) (*GetAppCredentialsResp, error) {
+	out := new(GetAppCredentialsResp)
+	err := c.cc.Invoke(ctx, AuthExt_GetAppCredentials_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authExtClient) VerifyAppCredential(ctx context.Context, in *VerifyAppCredentialReq, opts ...grpc.CallOption) (*VerifyAppCredentialResp, error) {
+	out := new(VerifyAppCredentialResp)
+	err := c.cc.Invoke(ctx, AuthExt_VerifyAppCredential_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authExtClient) GetAppCredentialLogs(ctx context.Context, in *GetAppCredentialLogsReq, opts ...grpc.CallOption) (*GetAppCredentialLogsResp, error) {
+	out := new(GetAppCredentialLogsResp)
+	err := c.cc.Invoke(ctx, AuthExt_GetAppCredentialLogs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authExtClient) GetSessions(ctx context.Context, in *GetSessionsReq, opts ...grpc.CallOption) (*GetSessionsResp, error) {
+	out := new(GetSessionsResp)
+	err := c.cc.Invoke(ctx, AuthExt_GetSessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authExtClient) RevokeSession(ctx context.Context, in *RevokeSessionReq, opts ...grpc.CallOption) (*RevokeSessionResp, error) {
+	out := new(RevokeSessionResp)
+	err := c.cc.Invoke(ctx, AuthExt_RevokeSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authExtClient) RevokeOtherSessions(ctx context.Context, in *RevokeOtherSessionsReq, opts ...grpc.CallOption) (*RevokeOtherSessionsResp, error) {
+	out := new(RevokeOtherSessionsResp)
+	err := c.cc.Invoke(ctx, AuthExt_RevokeOtherSessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthExtServer is the server API for AuthExt service.
+// All implementations should embed UnimplementedAuthExtServer
+// for forward compatibility
+type AuthExtServer interface {
+	GetUserTokens(context.Context, *GetUserTokensReq) (*GetUserTokensResp, error)
+	// the refresh token is rotated on every call, reusing an old one revokes all tokens issued from the same login
+	RefreshToken(context.Context, *RefreshTokenReq) (*RefreshTokenResp, error)
+	CreateAppCredential(context.Context, *CreateAppCredentialReq) (*CreateAppCredentialResp, error)
+	// takes effect on the next request made with the credential
+	RevokeAppCredential(context.Context, *RevokeAppCredentialReq) (*RevokeAppCredentialResp, error)
+	GetAppCredentials(context.Context, *GetAppCredentialsReq) (*GetAppCredentialsResp, error)
+	// called by the api for every request made with a credential, the result is written to the audit log
+	VerifyAppCredential(context.Context, *VerifyAppCredentialReq) (*VerifyAppCredentialResp, error)
+	GetAppCredentialLogs(context.Context, *GetAppCredentialLogsReq) (*GetAppCredentialLogsResp, error)
+	// logins of the user with a usable token, a login and the tokens refreshed from it are one session
+	GetSessions(context.Context, *GetSessionsReq) (*GetSessionsResp, error)
+	// marks the tokens of the session kicked and kicks the connections of its platform
+	RevokeSession(context.Context, *RevokeSessionReq) (*RevokeSessionResp, error)
+	RevokeOtherSessions(context.Context, *RevokeOtherSessionsReq) (*RevokeOtherSessionsResp, error)
+}
+
+// UnimplementedAuthExtServer should be embedded to have forward compatible implementations.
+type UnimplementedAuthExtServer struct {
+}
+
+func (UnimplementedAuthExtServer) GetUserTokens(context.Context, *GetUserTokensReq) (*GetUserTokensResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserTokens not implemented")
+}
+func (UnimplementedAuthExtServer) RefreshToken(context.Context, *RefreshTokenReq) (*RefreshTokenResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedAuthExtServer) CreateAppCredential(context.Context, *CreateAppCredentialReq) (*CreateAppCredentialResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAppCredential not implemented")
+}
+func (UnimplementedAuthExtServer) RevokeAppCredential(context.Context, *RevokeAppCredentialReq) (*RevokeAppCredentialResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAppCredential not implemented")
+}
+func (UnimplementedAuthExtServer) GetAppCredentials(context.Context, *GetAppCredentialsReq) (*GetAppCredentialsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAppCredentials not implemented")
+}
+func (UnimplementedAuthExtServer) VerifyAppCredential(context.Context, *VerifyAppCredentialReq) (*VerifyAppCredentialResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAppCredential not implemented")
+}
+func (UnimplementedAuthExtServer) GetAppCredentialLogs(context.Context, *GetAppCredentialLogsReq) (*GetAppCredentialLogsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAppCredentialLogs not implemented")
+}
+func (UnimplementedAuthExtServer) GetSessions(context.Context, *GetSessionsReq) (*GetSessionsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessions not implemented")
+}
+func (UnimplementedAuthExtServer) RevokeSession(context.Context, *RevokeSessionReq) (*RevokeSessionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedAuthExtServer) RevokeOtherSessions(context.Context, *RevokeOtherSessionsReq) (*RevokeOtherSessionsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeOtherSessions not implemented")
+}
+
+// UnsafeAuthExtServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthExtServer will
+// result in compilation errors.
+type UnsafeAuthExtServer interface {
+	mustEmbedUnimplementedAuthExtServer()
+}
+
+func RegisterAuthExtServer(s grpc.ServiceRegistrar, srv AuthExtServer) {
+	s.RegisterService(&AuthExt_ServiceDesc, srv)
+}
+
+func _AuthExt_GetUserTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserTokensReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthExtServer).GetUserTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthExt_GetUserTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthExtServer).GetUserTokens(ctx, req.(*GetUserTokensReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthExt_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthExtServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthExt_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthExtServer).RefreshToken(ctx, req.(*RefreshTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthExt_CreateAppCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAppCredentialReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthExtServer).CreateAppCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthExt_CreateAppCredential_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthExtServer).CreateAppCredential(ctx, req.(*CreateAppCredentialReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthExt_RevokeAppCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAppCredentialReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthExtServer).RevokeAppCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthExt_RevokeAppCredential_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthExtServer).RevokeAppCredential(ctx, req.(*RevokeAppCredentialReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthExt_GetAppCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAppCredentialsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthExtServer).GetAppCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthExt_GetAppCredentials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthExtServer).GetAppCredentials(ctx, req.(*GetAppCredentialsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthExt_VerifyAppCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAppCredentialReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthExtServer).VerifyAppCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthExt_VerifyAppCredential_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthExtServer).VerifyAppCredential(ctx, req.(*VerifyAppCredentialReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthExt_GetAppCredentialLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAppCredentialLogsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthExtServer).GetAppCredentialLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthExt_GetAppCredentialLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthExtServer).GetAppCredentialLogs(ctx, req.(*GetAppCredentialLogsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthExt_GetSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthExtServer).GetSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthExt_GetSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthExtServer).GetSessions(ctx, req.(*GetSessionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthExt_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthExtServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthExt_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthExtServer).RevokeSession(ctx, req.(*RevokeSessionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthExt_RevokeOtherSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeOtherSessionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthExtServer).RevokeOtherSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthExt_RevokeOtherSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthExtServer).RevokeOtherSessions(ctx, req.(*RevokeOtherSessionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthExt_ServiceDesc is the grpc.ServiceDesc for AuthExt service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthExt_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "OpenIMServer.authext.authExt",
+	HandlerType: (*AuthExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUserTokens",
+			Handler:    _AuthExt_GetUserTokens_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _AuthExt_RefreshToken_Handler,
+		},
+		{
+			MethodName: "CreateAppCredential",
+			Handler:    _AuthExt_CreateAppCredential_Handler,
+		},
+		{
+			MethodName: "RevokeAppCredential",
+			Handler:    _AuthExt_RevokeAppCredential_Handler,
+		},
+		{
+			MethodName: "GetAppCredentials",
+			Handler:    _AuthExt_GetAppCredentials_Handler,
+		},
+		{
+			MethodName: "VerifyAppCredential",
+			Handler:    _AuthExt_VerifyAppCredential_Handler,
+		},
+		{
+			MethodName: "GetAppCredentialLogs",
+			Handler:    _AuthExt_GetAppCredentialLogs_Handler,
+		},
+		{
+			MethodName: "GetSessions",
+			Handler:    _AuthExt_GetSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _AuthExt_RevokeSession_Handler,
+		},
+		{
+			MethodName: "RevokeOtherSessions",
+			Handler:    _AuthExt_RevokeOtherSessions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "authext/authext.proto",
+}
@@ -0,0 +1,304 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.22.0
+// source: callbackext/callbackext.proto
+
+package callbackext
+
+import (
+	sdkws "github.com/OpenIMSDK/protocol/sdkws"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// implemented by the business server for the before send callbacks with transport grpc
+type BeforeSendMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command     string         `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	OperationID string         `protobuf:"bytes,2,opt,name=operationID,proto3" json:"operationID,omitempty"`
+	MsgData     *sdkws.MsgData `protobuf:"bytes,3,opt,name=msgData,proto3" json:"msgData,omitempty"`
+}
+
+func (x *BeforeSendMsgReq) Reset() {
+	*x = BeforeSendMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_callbackext_callbackext_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeforeSendMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeforeSendMsgReq) ProtoMessage() {}
+
+func (x *BeforeSendMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_callbackext_callbackext_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeforeSendMsgReq.ProtoReflect.Descriptor instead.
+func (*BeforeSendMsgReq) Descriptor() ([]byte, []int) {
+	return file_callbackext_callbackext_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BeforeSendMsgReq) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *BeforeSendMsgReq) GetOperationID() string {
+	if x != nil {
+		return x.OperationID
+	}
+	return ""
+}
+
+func (x *BeforeSendMsgReq) GetMsgData() *sdkws.MsgData {
+	if x != nil {
+		return x.MsgData
+	}
+	return nil
+}
+
+// same meaning as the json callback response, msgData is only read by msgModify and replaces the
+// modifiable fields of the sent message when set
+type BeforeSendMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActionCode int32          `protobuf:"varint,1,opt,name=actionCode,proto3" json:"actionCode,omitempty"`
+	ErrCode    int32          `protobuf:"varint,2,opt,name=errCode,proto3" json:"errCode,omitempty"`
+	ErrMsg     string         `protobuf:"bytes,3,opt,name=errMsg,proto3" json:"errMsg,omitempty"`
+	ErrDlt     string         `protobuf:"bytes,4,opt,name=errDlt,proto3" json:"errDlt,omitempty"`
+	MsgData    *sdkws.MsgData `protobuf:"bytes,5,opt,name=msgData,proto3" json:"msgData,omitempty"`
+}
+
+func (x *BeforeSendMsgResp) Reset() {
+	*x = BeforeSendMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_callbackext_callbackext_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeforeSendMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeforeSendMsgResp) ProtoMessage() {}
+
+func (x *BeforeSendMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_callbackext_callbackext_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeforeSendMsgResp.ProtoReflect.Descriptor instead.
+func (*BeforeSendMsgResp) Descriptor() ([]byte, []int) {
+	return file_callbackext_callbackext_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BeforeSendMsgResp) GetActionCode() int32 {
+	if x != nil {
+		return x.ActionCode
+	}
+	return 0
+}
+
+func (x *BeforeSendMsgResp) GetErrCode() int32 {
+	if x != nil {
+		return x.ErrCode
+	}
+	return 0
+}
+
+func (x *BeforeSendMsgResp) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+func (x *BeforeSendMsgResp) GetErrDlt() string {
+	if x != nil {
+		return x.ErrDlt
+	}
+	return ""
+}
+
+func (x *BeforeSendMsgResp) GetMsgData() *sdkws.MsgData {
+	if x != nil {
+		return x.MsgData
+	}
+	return nil
+}
+
+var File_callbackext_callbackext_proto protoreflect.FileDescriptor
+
+var file_callbackext_callbackext_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x78, 0x74, 0x2f, 0x63, 0x61,
+	0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x78, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x18, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x63, 0x61,
+	0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x78, 0x74, 0x1a, 0x11, 0x73, 0x64, 0x6b, 0x77, 0x73,
+	0x2f, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x85, 0x01, 0x0a,
+	0x10, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65,
+	0x71, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x35, 0x0a,
+	0x07, 0x6d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x73, 0x64,
+	0x6b, 0x77, 0x73, 0x2e, 0x4d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x52, 0x07, 0x6d, 0x73, 0x67,
+	0x44, 0x61, 0x74, 0x61, 0x22, 0xb4, 0x01, 0x0a, 0x11, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x53,
+	0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x72,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x72, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x4d, 0x73, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x72, 0x72, 0x44, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72,
+	0x72, 0x44, 0x6c, 0x74, 0x12, 0x35, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x4d, 0x73, 0x67, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x32, 0x77, 0x0a, 0x0b, 0x6d,
+	0x73, 0x67, 0x43, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x68, 0x0a, 0x0d, 0x42, 0x65,
+	0x66, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x2a, 0x2e, 0x4f, 0x70,
+	0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x78, 0x74, 0x2e, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x6e,
+	0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x2b, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x65,
+	0x78, 0x74, 0x2e, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x44, 0x4b, 0x2f, 0x4f, 0x70, 0x65,
+	0x6e, 0x2d, 0x49, 0x4d, 0x2d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x78,
+	0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_callbackext_callbackext_proto_rawDescOnce sync.Once
+	file_callbackext_callbackext_proto_rawDescData = file_callbackext_callbackext_proto_rawDesc
+)
+
+func file_callbackext_callbackext_proto_rawDescGZIP() []byte {
+	file_callbackext_callbackext_proto_rawDescOnce.Do(func() {
+		file_callbackext_callbackext_proto_rawDescData = protoimpl.X.CompressGZIP(file_callbackext_callbackext_proto_rawDescData)
+	})
+	return file_callbackext_callbackext_proto_rawDescData
+}
+
+var file_callbackext_callbackext_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_callbackext_callbackext_proto_goTypes = []interface{}{
+	(*BeforeSendMsgReq)(nil),  // 0: OpenIMServer.callbackext.BeforeSendMsgReq
+	(*BeforeSendMsgResp)(nil), // 1: OpenIMServer.callbackext.BeforeSendMsgResp
+	(*sdkws.MsgData)(nil),     // 2: OpenIMServer.sdkws.MsgData
+}
+var file_callbackext_callbackext_proto_depIdxs = []int32{
+	2, // 0: OpenIMServer.callbackext.BeforeSendMsgReq.msgData:type_name -> OpenIMServer.sdkws.MsgData
+	2, // 1: OpenIMServer.callbackext.BeforeSendMsgResp.msgData:type_name -> OpenIMServer.sdkws.MsgData
+	0, // 2: OpenIMServer.callbackext.msgCallback.BeforeSendMsg:input_type -> OpenIMServer.callbackext.BeforeSendMsgReq
+	1, // 3: OpenIMServer.callbackext.msgCallback.BeforeSendMsg:output_type -> OpenIMServer.callbackext.BeforeSendMsgResp
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_callbackext_callbackext_proto_init() }
+func file_callbackext_callbackext_proto_init() {
+	if File_callbackext_callbackext_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_callbackext_callbackext_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BeforeSendMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_callbackext_callbackext_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BeforeSendMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_callbackext_callbackext_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_callbackext_callbackext_proto_goTypes,
+		DependencyIndexes: file_callbackext_callbackext_proto_depIdxs,
+		MessageInfos:      file_callbackext_callbackext_proto_msgTypes,
+	}.Build()
+	File_callbackext_callbackext_proto = out.File
+	file_callbackext_callbackext_proto_rawDesc = nil
+	file_callbackext_callbackext_proto_goTypes = nil
+	file_callbackext_callbackext_proto_depIdxs = nil
+}
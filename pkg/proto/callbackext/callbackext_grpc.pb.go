@@ -0,0 +1,121 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.22.0
+// source: callbackext/callbackext.proto
+
+package callbackext
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	MsgCallback_BeforeSendMsg_FullMethodName = "/OpenIMServer.callbackext.msgCallback/BeforeSendMsg"
+)
+
+// MsgCallbackClient is the client API for MsgCallback service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MsgCallbackClient interface {
+	BeforeSendMsg(ctx context.Context, in *BeforeSendMsgReq, opts ...grpc.CallOption) (*BeforeSendMsgResp, error)
+}
+
+type msgCallbackClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMsgCallbackClient(cc grpc.ClientConnInterface) MsgCallbackClient {
+	return &msgCallbackClient{cc}
+}
+
+func (c *msgCallbackClient) BeforeSendMsg(ctx context.Context, in *BeforeSendMsgReq, opts ...grpc.CallOption) (*BeforeSendMsgResp, error) {
+	out := new(BeforeSendMsgResp)
+	err := c.cc.Invoke(ctx, MsgCallback_BeforeSendMsg_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MsgCallbackServer is the server API for MsgCallback service.
+// All implementations should embed UnimplementedMsgCallbackServer
+// for forward compatibility
+type MsgCallbackServer interface {
+	BeforeSendMsg(context.Context, *BeforeSendMsgReq) (*BeforeSendMsgResp, error)
+}
+
+// UnimplementedMsgCallbackServer should be embedded to have forward compatible implementations.
+type UnimplementedMsgCallbackServer struct {
+}
+
+func (UnimplementedMsgCallbackServer) BeforeSendMsg(context.Context, *BeforeSendMsgReq) (*BeforeSendMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeforeSendMsg not implemented")
+}
+
+// UnsafeMsgCallbackServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MsgCallbackServer will
+// result in compilation errors.
+type UnsafeMsgCallbackServer interface {
+	mustEmbedUnimplementedMsgCallbackServer()
+}
+
+func RegisterMsgCallbackServer(s grpc.ServiceRegistrar, srv MsgCallbackServer) {
+	s.RegisterService(&MsgCallback_ServiceDesc, srv)
+}
+
+func _MsgCallback_BeforeSendMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeforeSendMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgCallbackServer).BeforeSendMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgCallback_BeforeSendMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgCallbackServer).BeforeSendMsg(ctx, req.(*BeforeSendMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MsgCallback_ServiceDesc is the grpc.ServiceDesc for MsgCallback service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MsgCallback_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "OpenIMServer.callbackext.msgCallback",
+	HandlerType: (*MsgCallbackServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BeforeSendMsg",
+			Handler:    _MsgCallback_BeforeSendMsg_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "callbackext/callbackext.proto",
+}
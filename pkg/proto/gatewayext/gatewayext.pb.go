@@ -0,0 +1,597 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.22.0
+// source: gatewayext/gatewayext.proto
+
+package gatewayext
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// websocket request envelope of the protobuf encoding, the json and gob encodings carry the same fields
+type WsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReqIdentifier int32  `protobuf:"varint,1,opt,name=reqIdentifier,proto3" json:"reqIdentifier,omitempty"`
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	SendID        string `protobuf:"bytes,3,opt,name=sendID,proto3" json:"sendID,omitempty"`
+	OperationID   string `protobuf:"bytes,4,opt,name=operationID,proto3" json:"operationID,omitempty"`
+	MsgIncr       string `protobuf:"bytes,5,opt,name=msgIncr,proto3" json:"msgIncr,omitempty"`
+	Data          []byte `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *WsReq) Reset() {
+	*x = WsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gatewayext_gatewayext_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WsReq) ProtoMessage() {}
+
+func (x *WsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_gatewayext_gatewayext_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WsReq.ProtoReflect.Descriptor instead.
+func (*WsReq) Descriptor() ([]byte, []int) {
+	return file_gatewayext_gatewayext_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WsReq) GetReqIdentifier() int32 {
+	if x != nil {
+		return x.ReqIdentifier
+	}
+	return 0
+}
+
+func (x *WsReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *WsReq) GetSendID() string {
+	if x != nil {
+		return x.SendID
+	}
+	return ""
+}
+
+func (x *WsReq) GetOperationID() string {
+	if x != nil {
+		return x.OperationID
+	}
+	return ""
+}
+
+func (x *WsReq) GetMsgIncr() string {
+	if x != nil {
+		return x.MsgIncr
+	}
+	return ""
+}
+
+func (x *WsReq) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// websocket response envelope of the protobuf encoding
+type WsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReqIdentifier int32  `protobuf:"varint,1,opt,name=reqIdentifier,proto3" json:"reqIdentifier,omitempty"`
+	MsgIncr       string `protobuf:"bytes,2,opt,name=msgIncr,proto3" json:"msgIncr,omitempty"`
+	OperationID   string `protobuf:"bytes,3,opt,name=operationID,proto3" json:"operationID,omitempty"`
+	ErrCode       int32  `protobuf:"varint,4,opt,name=errCode,proto3" json:"errCode,omitempty"`
+	ErrMsg        string `protobuf:"bytes,5,opt,name=errMsg,proto3" json:"errMsg,omitempty"`
+	Data          []byte `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *WsResp) Reset() {
+	*x = WsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gatewayext_gatewayext_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WsResp) ProtoMessage() {}
+
+func (x *WsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_gatewayext_gatewayext_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WsResp.ProtoReflect.Descriptor instead.
+func (*WsResp) Descriptor() ([]byte, []int) {
+	return file_gatewayext_gatewayext_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WsResp) GetReqIdentifier() int32 {
+	if x != nil {
+		return x.ReqIdentifier
+	}
+	return 0
+}
+
+func (x *WsResp) GetMsgIncr() string {
+	if x != nil {
+		return x.MsgIncr
+	}
+	return ""
+}
+
+func (x *WsResp) GetOperationID() string {
+	if x != nil {
+		return x.OperationID
+	}
+	return ""
+}
+
+func (x *WsResp) GetErrCode() int32 {
+	if x != nil {
+		return x.ErrCode
+	}
+	return 0
+}
+
+func (x *WsResp) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+func (x *WsResp) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DrainReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// seconds over which connected clients are told to reconnect, 0 uses the configured window
+	Window int64 `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`
+}
+
+func (x *DrainReq) Reset() {
+	*x = DrainReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gatewayext_gatewayext_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainReq) ProtoMessage() {}
+
+func (x *DrainReq) ProtoReflect() protoreflect.Message {
+	mi := &file_gatewayext_gatewayext_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainReq.ProtoReflect.Descriptor instead.
+func (*DrainReq) Descriptor() ([]byte, []int) {
+	return file_gatewayext_gatewayext_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DrainReq) GetWindow() int64 {
+	if x != nil {
+		return x.Window
+	}
+	return 0
+}
+
+type DrainResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConnNum int64 `protobuf:"varint,1,opt,name=connNum,proto3" json:"connNum,omitempty"`
+}
+
+func (x *DrainResp) Reset() {
+	*x = DrainResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gatewayext_gatewayext_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainResp) ProtoMessage() {}
+
+func (x *DrainResp) ProtoReflect() protoreflect.Message {
+	mi := &file_gatewayext_gatewayext_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainResp.ProtoReflect.Descriptor instead.
+func (*DrainResp) Descriptor() ([]byte, []int) {
+	return file_gatewayext_gatewayext_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DrainResp) GetConnNum() int64 {
+	if x != nil {
+		return x.ConnNum
+	}
+	return 0
+}
+
+// data of WsReconnectMsg pushed to clients when the gateway drains
+type ReconnectTips struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// milliseconds the client should wait before reconnecting
+	Backoff int64 `protobuf:"varint,1,opt,name=backoff,proto3" json:"backoff,omitempty"`
+}
+
+func (x *ReconnectTips) Reset() {
+	*x = ReconnectTips{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gatewayext_gatewayext_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconnectTips) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconnectTips) ProtoMessage() {}
+
+func (x *ReconnectTips) ProtoReflect() protoreflect.Message {
+	mi := &file_gatewayext_gatewayext_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconnectTips.ProtoReflect.Descriptor instead.
+func (*ReconnectTips) Descriptor() ([]byte, []int) {
+	return file_gatewayext_gatewayext_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReconnectTips) GetBackoff() int64 {
+	if x != nil {
+		return x.Backoff
+	}
+	return 0
+}
+
+// data of WsResumeDoneMsg pushed to clients after the messages missed since the seqs given at handshake are sent
+type ResumeDone struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MsgNum int64 `protobuf:"varint,1,opt,name=msgNum,proto3" json:"msgNum,omitempty"`
+	// conversations with more missed messages than the gateway resumes, the client pulls the rest itself
+	IncompleteConversationIDs []string `protobuf:"bytes,2,rep,name=incompleteConversationIDs,proto3" json:"incompleteConversationIDs,omitempty"`
+}
+
+func (x *ResumeDone) Reset() {
+	*x = ResumeDone{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gatewayext_gatewayext_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumeDone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeDone) ProtoMessage() {}
+
+func (x *ResumeDone) ProtoReflect() protoreflect.Message {
+	mi := &file_gatewayext_gatewayext_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeDone.ProtoReflect.Descriptor instead.
+func (*ResumeDone) Descriptor() ([]byte, []int) {
+	return file_gatewayext_gatewayext_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResumeDone) GetMsgNum() int64 {
+	if x != nil {
+		return x.MsgNum
+	}
+	return 0
+}
+
+func (x *ResumeDone) GetIncompleteConversationIDs() []string {
+	if x != nil {
+		return x.IncompleteConversationIDs
+	}
+	return nil
+}
+
+var File_gatewayext_gatewayext_proto protoreflect.FileDescriptor
+
+var file_gatewayext_gatewayext_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x65, 0x78, 0x74, 0x2f, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x65, 0x78, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x4f,
+	0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x65, 0x78, 0x74, 0x22, 0xab, 0x01, 0x0a, 0x05, 0x57, 0x73, 0x52, 0x65, 0x71,
+	0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x72, 0x65, 0x71, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x6e, 0x64, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x49, 0x6e, 0x63,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x49, 0x6e, 0x63, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0xb0, 0x01, 0x0a, 0x06, 0x57, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x24, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x72, 0x65, 0x71, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x49, 0x6e, 0x63, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x49, 0x6e, 0x63, 0x72, 0x12,
+	0x20, 0x0a, 0x0b, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x72, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x65, 0x72, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x72, 0x72, 0x4d, 0x73, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72,
+	0x4d, 0x73, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x22, 0x0a, 0x08, 0x44, 0x72, 0x61, 0x69, 0x6e,
+	0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0x25, 0x0a, 0x09, 0x44,
+	0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x6e,
+	0x4e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x4e,
+	0x75, 0x6d, 0x22, 0x29, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54,
+	0x69, 0x70, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x22, 0x62, 0x0a,
+	0x0a, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d,
+	0x73, 0x67, 0x4e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x73, 0x67,
+	0x4e, 0x75, 0x6d, 0x12, 0x3c, 0x0a, 0x19, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x19, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65,
+	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x73, 0x32, 0x5f, 0x0a, 0x0d, 0x6d, 0x73, 0x67, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x45,
+	0x78, 0x74, 0x12, 0x4e, 0x0a, 0x05, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x12, 0x21, 0x2e, 0x4f, 0x70,
+	0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x65, 0x78, 0x74, 0x2e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x22,
+	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x67, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x65, 0x78, 0x74, 0x2e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x44, 0x4b, 0x2f, 0x4f, 0x70, 0x65, 0x6e, 0x2d,
+	0x49, 0x4d, 0x2d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x65, 0x78, 0x74, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gatewayext_gatewayext_proto_rawDescOnce sync.Once
+	file_gatewayext_gatewayext_proto_rawDescData = file_gatewayext_gatewayext_proto_rawDesc
+)
+
+func file_gatewayext_gatewayext_proto_rawDescGZIP() []byte {
+	file_gatewayext_gatewayext_proto_rawDescOnce.Do(func() {
+		file_gatewayext_gatewayext_proto_rawDescData = protoimpl.X.CompressGZIP(file_gatewayext_gatewayext_proto_rawDescData)
+	})
+	return file_gatewayext_gatewayext_proto_rawDescData
+}
+
+var file_gatewayext_gatewayext_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_gatewayext_gatewayext_proto_goTypes = []interface{}{
+	(*WsReq)(nil),         // 0: OpenIMServer.gatewayext.WsReq
+	(*WsResp)(nil),        // 1: OpenIMServer.gatewayext.WsResp
+	(*DrainReq)(nil),      // 2: OpenIMServer.gatewayext.DrainReq
+	(*DrainResp)(nil),     // 3: OpenIMServer.gatewayext.DrainResp
+	(*ReconnectTips)(nil), // 4: OpenIMServer.gatewayext.ReconnectTips
+	(*ResumeDone)(nil),    // 5: OpenIMServer.gatewayext.ResumeDone
+}
+var file_gatewayext_gatewayext_proto_depIdxs = []int32{
+	2, // 0: OpenIMServer.gatewayext.msgGatewayExt.Drain:input_type -> OpenIMServer.gatewayext.DrainReq
+	3, // 1: OpenIMServer.gatewayext.msgGatewayExt.Drain:output_type -> OpenIMServer.gatewayext.DrainResp
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_gatewayext_gatewayext_proto_init() }
+func file_gatewayext_gatewayext_proto_init() {
+	if File_gatewayext_gatewayext_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gatewayext_gatewayext_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gatewayext_gatewayext_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gatewayext_gatewayext_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gatewayext_gatewayext_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gatewayext_gatewayext_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconnectTips); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gatewayext_gatewayext_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeDone); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gatewayext_gatewayext_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gatewayext_gatewayext_proto_goTypes,
+		DependencyIndexes: file_gatewayext_gatewayext_proto_depIdxs,
+		MessageInfos:      file_gatewayext_gatewayext_proto_msgTypes,
+	}.Build()
+	File_gatewayext_gatewayext_proto = out.File
+	file_gatewayext_gatewayext_proto_rawDesc = nil
+	file_gatewayext_gatewayext_proto_goTypes = nil
+	file_gatewayext_gatewayext_proto_depIdxs = nil
+}
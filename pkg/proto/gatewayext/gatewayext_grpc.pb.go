@@ -0,0 +1,121 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.22.0
+// source: gatewayext/gatewayext.proto
+
+package gatewayext
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	MsgGatewayExt_Drain_FullMethodName = "/OpenIMServer.gatewayext.msgGatewayExt/Drain"
+)
+
+// MsgGatewayExtClient is the client API for MsgGatewayExt service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MsgGatewayExtClient interface {
+	Drain(ctx context.Context, in *DrainReq, opts ...grpc.CallOption) (*DrainResp, error)
+}
+
+type msgGatewayExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMsgGatewayExtClient(cc grpc.ClientConnInterface) MsgGatewayExtClient {
+	return &msgGatewayExtClient{cc}
+}
+
+func (c *msgGatewayExtClient) Drain(ctx context.Context, in *DrainReq, opts ...grpc.CallOption) (*DrainResp, error) {
+	out := new(DrainResp)
+	err := c.cc.Invoke(ctx, MsgGatewayExt_Drain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MsgGatewayExtServer is the server API for MsgGatewayExt service.
+// All implementations should embed UnimplementedMsgGatewayExtServer
+// for forward compatibility
+type MsgGatewayExtServer interface {
+	Drain(context.Context, *DrainReq) (*DrainResp, error)
+}
+
+// UnimplementedMsgGatewayExtServer should be embedded to have forward compatible implementations.
+type UnimplementedMsgGatewayExtServer struct {
+}
+
+func (UnimplementedMsgGatewayExtServer) Drain(context.Context, *DrainReq) (*DrainResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Drain not implemented")
+}
+
+// UnsafeMsgGatewayExtServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MsgGatewayExtServer will
+// result in compilation errors.
+type UnsafeMsgGatewayExtServer interface {
+	mustEmbedUnimplementedMsgGatewayExtServer()
+}
+
+func RegisterMsgGatewayExtServer(s grpc.ServiceRegistrar, srv MsgGatewayExtServer) {
+	s.RegisterService(&MsgGatewayExt_ServiceDesc, srv)
+}
+
+func _MsgGatewayExt_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgGatewayExtServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgGatewayExt_Drain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgGatewayExtServer).Drain(ctx, req.(*DrainReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MsgGatewayExt_ServiceDesc is the grpc.ServiceDesc for MsgGatewayExt service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MsgGatewayExt_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "OpenIMServer.gatewayext.msgGatewayExt",
+	HandlerType: (*MsgGatewayExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Drain",
+			Handler:    _MsgGatewayExt_Drain_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gatewayext/gatewayext.proto",
+}
@@ -0,0 +1,24 @@
+# Copyright © 2023 OpenIM. All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Server side extensions of github.com/OpenIMSDK/protocol, run in this directory.
+PROTOCOL=$(go list -m -f '{{.Dir}}' github.com/OpenIMSDK/protocol)
+MODULE=github.com/OpenIMSDK/Open-IM-Server
+
+for f in gatewayext/gatewayext.proto pushext/pushext.proto callbackext/callbackext.proto msgext/msgext.proto authext/authext.proto; do
+  protoc -I . -I "$PROTOCOL" \
+    --go_out=../.. --go_opt=module=$MODULE \
+    --go-grpc_out=../.. --go-grpc_opt=module=$MODULE,require_unimplemented_servers=false \
+    "$f"
+done
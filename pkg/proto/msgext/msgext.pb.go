@@ -0,0 +1,3321 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.22.0
+// source: msgext/msgext.proto
+
+package msgext
+
+import (
+	msg "github.com/OpenIMSDK/protocol/msg"
+	sdkws "github.com/OpenIMSDK/protocol/sdkws"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EditMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationID string `protobuf:"bytes,1,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	Seq            int64  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	UserID         string `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+	Content        string `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *EditMsgReq) Reset() {
+	*x = EditMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EditMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMsgReq) ProtoMessage() {}
+
+func (x *EditMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMsgReq.ProtoReflect.Descriptor instead.
+func (*EditMsgReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EditMsgReq) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *EditMsgReq) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *EditMsgReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *EditMsgReq) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type EditMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EditMsgResp) Reset() {
+	*x = EditMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EditMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMsgResp) ProtoMessage() {}
+
+func (x *EditMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMsgResp.ProtoReflect.Descriptor instead.
+func (*EditMsgResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{1}
+}
+
+// content of the MsgEditNotification, content is the new content of the msg
+type MsgEditTips struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EditorUserID   string `protobuf:"bytes,1,opt,name=editorUserID,proto3" json:"editorUserID,omitempty"`
+	ClientMsgID    string `protobuf:"bytes,2,opt,name=clientMsgID,proto3" json:"clientMsgID,omitempty"`
+	EditTime       int64  `protobuf:"varint,3,opt,name=editTime,proto3" json:"editTime,omitempty"`
+	Seq            int64  `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+	SessionType    int32  `protobuf:"varint,5,opt,name=sessionType,proto3" json:"sessionType,omitempty"`
+	ConversationID string `protobuf:"bytes,6,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	Content        string `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *MsgEditTips) Reset() {
+	*x = MsgEditTips{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MsgEditTips) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MsgEditTips) ProtoMessage() {}
+
+func (x *MsgEditTips) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MsgEditTips.ProtoReflect.Descriptor instead.
+func (*MsgEditTips) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MsgEditTips) GetEditorUserID() string {
+	if x != nil {
+		return x.EditorUserID
+	}
+	return ""
+}
+
+func (x *MsgEditTips) GetClientMsgID() string {
+	if x != nil {
+		return x.ClientMsgID
+	}
+	return ""
+}
+
+func (x *MsgEditTips) GetEditTime() int64 {
+	if x != nil {
+		return x.EditTime
+	}
+	return 0
+}
+
+func (x *MsgEditTips) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *MsgEditTips) GetSessionType() int32 {
+	if x != nil {
+		return x.SessionType
+	}
+	return 0
+}
+
+func (x *MsgEditTips) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *MsgEditTips) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type AddReactionReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationID string `protobuf:"bytes,1,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	Seq            int64  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	UserID         string `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+	Emoji          string `protobuf:"bytes,4,opt,name=emoji,proto3" json:"emoji,omitempty"`
+}
+
+func (x *AddReactionReq) Reset() {
+	*x = AddReactionReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddReactionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReactionReq) ProtoMessage() {}
+
+func (x *AddReactionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReactionReq.ProtoReflect.Descriptor instead.
+func (*AddReactionReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddReactionReq) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *AddReactionReq) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *AddReactionReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *AddReactionReq) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type AddReactionResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AddReactionResp) Reset() {
+	*x = AddReactionResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddReactionResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReactionResp) ProtoMessage() {}
+
+func (x *AddReactionResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReactionResp.ProtoReflect.Descriptor instead.
+func (*AddReactionResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{4}
+}
+
+type RemoveReactionReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationID string `protobuf:"bytes,1,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	Seq            int64  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	UserID         string `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+	Emoji          string `protobuf:"bytes,4,opt,name=emoji,proto3" json:"emoji,omitempty"`
+}
+
+func (x *RemoveReactionReq) Reset() {
+	*x = RemoveReactionReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveReactionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReactionReq) ProtoMessage() {}
+
+func (x *RemoveReactionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReactionReq.ProtoReflect.Descriptor instead.
+func (*RemoveReactionReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemoveReactionReq) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *RemoveReactionReq) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *RemoveReactionReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *RemoveReactionReq) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type RemoveReactionResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoveReactionResp) Reset() {
+	*x = RemoveReactionResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveReactionResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReactionResp) ProtoMessage() {}
+
+func (x *RemoveReactionResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReactionResp.ProtoReflect.Descriptor instead.
+func (*RemoveReactionResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{6}
+}
+
+type GetReactionsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationID string  `protobuf:"bytes,1,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	Seqs           []int64 `protobuf:"varint,2,rep,packed,name=seqs,proto3" json:"seqs,omitempty"`
+	UserID         string  `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+}
+
+func (x *GetReactionsReq) Reset() {
+	*x = GetReactionsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReactionsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReactionsReq) ProtoMessage() {}
+
+func (x *GetReactionsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReactionsReq.ProtoReflect.Descriptor instead.
+func (*GetReactionsReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetReactionsReq) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *GetReactionsReq) GetSeqs() []int64 {
+	if x != nil {
+		return x.Seqs
+	}
+	return nil
+}
+
+func (x *GetReactionsReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+type ReactionCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Emoji       string `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Count       int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	ReactedByMe bool   `protobuf:"varint,3,opt,name=reactedByMe,proto3" json:"reactedByMe,omitempty"`
+}
+
+func (x *ReactionCount) Reset() {
+	*x = ReactionCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReactionCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionCount) ProtoMessage() {}
+
+func (x *ReactionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionCount.ProtoReflect.Descriptor instead.
+func (*ReactionCount) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReactionCount) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactionCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ReactionCount) GetReactedByMe() bool {
+	if x != nil {
+		return x.ReactedByMe
+	}
+	return false
+}
+
+type MsgReactions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq       int64            `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Reactions []*ReactionCount `protobuf:"bytes,2,rep,name=reactions,proto3" json:"reactions,omitempty"`
+}
+
+func (x *MsgReactions) Reset() {
+	*x = MsgReactions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MsgReactions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MsgReactions) ProtoMessage() {}
+
+func (x *MsgReactions) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MsgReactions.ProtoReflect.Descriptor instead.
+func (*MsgReactions) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MsgReactions) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *MsgReactions) GetReactions() []*ReactionCount {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+type GetReactionsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msgs []*MsgReactions `protobuf:"bytes,1,rep,name=msgs,proto3" json:"msgs,omitempty"`
+}
+
+func (x *GetReactionsResp) Reset() {
+	*x = GetReactionsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReactionsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReactionsResp) ProtoMessage() {}
+
+func (x *GetReactionsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReactionsResp.ProtoReflect.Descriptor instead.
+func (*GetReactionsResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetReactionsResp) GetMsgs() []*MsgReactions {
+	if x != nil {
+		return x.Msgs
+	}
+	return nil
+}
+
+// content of the MsgReactionNotification
+type ReactionTips struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID         string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	ClientMsgID    string `protobuf:"bytes,2,opt,name=clientMsgID,proto3" json:"clientMsgID,omitempty"`
+	Seq            int64  `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	SessionType    int32  `protobuf:"varint,4,opt,name=sessionType,proto3" json:"sessionType,omitempty"`
+	ConversationID string `protobuf:"bytes,5,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	Emoji          string `protobuf:"bytes,6,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Removed        bool   `protobuf:"varint,7,opt,name=removed,proto3" json:"removed,omitempty"`
+	Time           int64  `protobuf:"varint,8,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *ReactionTips) Reset() {
+	*x = ReactionTips{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReactionTips) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionTips) ProtoMessage() {}
+
+func (x *ReactionTips) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionTips.ProtoReflect.Descriptor instead.
+func (*ReactionTips) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReactionTips) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *ReactionTips) GetClientMsgID() string {
+	if x != nil {
+		return x.ClientMsgID
+	}
+	return ""
+}
+
+func (x *ReactionTips) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ReactionTips) GetSessionType() int32 {
+	if x != nil {
+		return x.SessionType
+	}
+	return 0
+}
+
+func (x *ReactionTips) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *ReactionTips) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactionTips) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+func (x *ReactionTips) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+// a revoked msg with its original content, only visible to app managers
+type RevokeAudit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationID  string `protobuf:"bytes,1,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	Seq             int64  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	ClientMsgID     string `protobuf:"bytes,3,opt,name=clientMsgID,proto3" json:"clientMsgID,omitempty"`
+	SessionType     int32  `protobuf:"varint,4,opt,name=sessionType,proto3" json:"sessionType,omitempty"`
+	SendID          string `protobuf:"bytes,5,opt,name=sendID,proto3" json:"sendID,omitempty"`
+	SendTime        int64  `protobuf:"varint,6,opt,name=sendTime,proto3" json:"sendTime,omitempty"`
+	ContentType     int32  `protobuf:"varint,7,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Content         string `protobuf:"bytes,8,opt,name=content,proto3" json:"content,omitempty"`
+	RevokerUserID   string `protobuf:"bytes,9,opt,name=revokerUserID,proto3" json:"revokerUserID,omitempty"`
+	RevokerRole     int32  `protobuf:"varint,10,opt,name=revokerRole,proto3" json:"revokerRole,omitempty"`
+	RevokerNickname string `protobuf:"bytes,11,opt,name=revokerNickname,proto3" json:"revokerNickname,omitempty"`
+	RevokeTime      int64  `protobuf:"varint,12,opt,name=revokeTime,proto3" json:"revokeTime,omitempty"`
+}
+
+func (x *RevokeAudit) Reset() {
+	*x = RevokeAudit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeAudit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAudit) ProtoMessage() {}
+
+func (x *RevokeAudit) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAudit.ProtoReflect.Descriptor instead.
+func (*RevokeAudit) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RevokeAudit) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *RevokeAudit) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *RevokeAudit) GetClientMsgID() string {
+	if x != nil {
+		return x.ClientMsgID
+	}
+	return ""
+}
+
+func (x *RevokeAudit) GetSessionType() int32 {
+	if x != nil {
+		return x.SessionType
+	}
+	return 0
+}
+
+func (x *RevokeAudit) GetSendID() string {
+	if x != nil {
+		return x.SendID
+	}
+	return ""
+}
+
+func (x *RevokeAudit) GetSendTime() int64 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+func (x *RevokeAudit) GetContentType() int32 {
+	if x != nil {
+		return x.ContentType
+	}
+	return 0
+}
+
+func (x *RevokeAudit) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *RevokeAudit) GetRevokerUserID() string {
+	if x != nil {
+		return x.RevokerUserID
+	}
+	return ""
+}
+
+func (x *RevokeAudit) GetRevokerRole() int32 {
+	if x != nil {
+		return x.RevokerRole
+	}
+	return 0
+}
+
+func (x *RevokeAudit) GetRevokerNickname() string {
+	if x != nil {
+		return x.RevokerNickname
+	}
+	return ""
+}
+
+func (x *RevokeAudit) GetRevokeTime() int64 {
+	if x != nil {
+		return x.RevokeTime
+	}
+	return 0
+}
+
+// userID matches the revoker or the sender, startTime and endTime are revoke times in ms, 0 means no limit
+type GetRevokeAuditsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationID string                   `protobuf:"bytes,1,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	UserID         string                   `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	StartTime      int64                    `protobuf:"varint,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime        int64                    `protobuf:"varint,4,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Pagination     *sdkws.RequestPagination `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (x *GetRevokeAuditsReq) Reset() {
+	*x = GetRevokeAuditsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRevokeAuditsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRevokeAuditsReq) ProtoMessage() {}
+
+func (x *GetRevokeAuditsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRevokeAuditsReq.ProtoReflect.Descriptor instead.
+func (*GetRevokeAuditsReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetRevokeAuditsReq) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *GetRevokeAuditsReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetRevokeAuditsReq) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *GetRevokeAuditsReq) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *GetRevokeAuditsReq) GetPagination() *sdkws.RequestPagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type GetRevokeAuditsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total  int64          `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Audits []*RevokeAudit `protobuf:"bytes,2,rep,name=audits,proto3" json:"audits,omitempty"`
+}
+
+func (x *GetRevokeAuditsResp) Reset() {
+	*x = GetRevokeAuditsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRevokeAuditsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRevokeAuditsResp) ProtoMessage() {}
+
+func (x *GetRevokeAuditsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRevokeAuditsResp.ProtoReflect.Descriptor instead.
+func (*GetRevokeAuditsResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetRevokeAuditsResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetRevokeAuditsResp) GetAudits() []*RevokeAudit {
+	if x != nil {
+		return x.Audits
+	}
+	return nil
+}
+
+type CancelScheduledMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID      string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	ClientMsgID string `protobuf:"bytes,2,opt,name=clientMsgID,proto3" json:"clientMsgID,omitempty"`
+}
+
+func (x *CancelScheduledMsgReq) Reset() {
+	*x = CancelScheduledMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelScheduledMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMsgReq) ProtoMessage() {}
+
+func (x *CancelScheduledMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMsgReq.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMsgReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelScheduledMsgReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *CancelScheduledMsgReq) GetClientMsgID() string {
+	if x != nil {
+		return x.ClientMsgID
+	}
+	return ""
+}
+
+type CancelScheduledMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CancelScheduledMsgResp) Reset() {
+	*x = CancelScheduledMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelScheduledMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMsgResp) ProtoMessage() {}
+
+func (x *CancelScheduledMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMsgResp.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMsgResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{16}
+}
+
+// pending scheduled msgs sent by userID, ordered by sendTime
+type GetScheduledMsgsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID     string                   `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Pagination *sdkws.RequestPagination `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (x *GetScheduledMsgsReq) Reset() {
+	*x = GetScheduledMsgsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetScheduledMsgsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduledMsgsReq) ProtoMessage() {}
+
+func (x *GetScheduledMsgsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduledMsgsReq.ProtoReflect.Descriptor instead.
+func (*GetScheduledMsgsReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetScheduledMsgsReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetScheduledMsgsReq) GetPagination() *sdkws.RequestPagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type GetScheduledMsgsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total int64            `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Msgs  []*sdkws.MsgData `protobuf:"bytes,2,rep,name=msgs,proto3" json:"msgs,omitempty"`
+}
+
+func (x *GetScheduledMsgsResp) Reset() {
+	*x = GetScheduledMsgsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetScheduledMsgsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduledMsgsResp) ProtoMessage() {}
+
+func (x *GetScheduledMsgsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduledMsgsResp.ProtoReflect.Descriptor instead.
+func (*GetScheduledMsgsResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetScheduledMsgsResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetScheduledMsgsResp) GetMsgs() []*sdkws.MsgData {
+	if x != nil {
+		return x.Msgs
+	}
+	return nil
+}
+
+// msgData is a reply of the msg rootSeq in conversationID, only super group conversations have threads
+type SendThreadMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationID string         `protobuf:"bytes,1,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	RootSeq        int64          `protobuf:"varint,2,opt,name=rootSeq,proto3" json:"rootSeq,omitempty"`
+	MsgData        *sdkws.MsgData `protobuf:"bytes,3,opt,name=msgData,proto3" json:"msgData,omitempty"`
+}
+
+func (x *SendThreadMsgReq) Reset() {
+	*x = SendThreadMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendThreadMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendThreadMsgReq) ProtoMessage() {}
+
+func (x *SendThreadMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendThreadMsgReq.ProtoReflect.Descriptor instead.
+func (*SendThreadMsgReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SendThreadMsgReq) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *SendThreadMsgReq) GetRootSeq() int64 {
+	if x != nil {
+		return x.RootSeq
+	}
+	return 0
+}
+
+func (x *SendThreadMsgReq) GetMsgData() *sdkws.MsgData {
+	if x != nil {
+		return x.MsgData
+	}
+	return nil
+}
+
+// the reply is pulled with threadID as the conversationID
+type SendThreadMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID    string `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Seq         int64  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	ServerMsgID string `protobuf:"bytes,3,opt,name=serverMsgID,proto3" json:"serverMsgID,omitempty"`
+	ClientMsgID string `protobuf:"bytes,4,opt,name=clientMsgID,proto3" json:"clientMsgID,omitempty"`
+	SendTime    int64  `protobuf:"varint,5,opt,name=sendTime,proto3" json:"sendTime,omitempty"`
+}
+
+func (x *SendThreadMsgResp) Reset() {
+	*x = SendThreadMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendThreadMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendThreadMsgResp) ProtoMessage() {}
+
+func (x *SendThreadMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendThreadMsgResp.ProtoReflect.Descriptor instead.
+func (*SendThreadMsgResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SendThreadMsgResp) GetThreadID() string {
+	if x != nil {
+		return x.ThreadID
+	}
+	return ""
+}
+
+func (x *SendThreadMsgResp) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SendThreadMsgResp) GetServerMsgID() string {
+	if x != nil {
+		return x.ServerMsgID
+	}
+	return ""
+}
+
+func (x *SendThreadMsgResp) GetClientMsgID() string {
+	if x != nil {
+		return x.ClientMsgID
+	}
+	return ""
+}
+
+func (x *SendThreadMsgResp) GetSendTime() int64 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+// content of the ThreadReplyNotification
+type ThreadReplyTips struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID        string `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	ConversationID  string `protobuf:"bytes,2,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	RootSeq         int64  `protobuf:"varint,3,opt,name=rootSeq,proto3" json:"rootSeq,omitempty"`
+	RootClientMsgID string `protobuf:"bytes,4,opt,name=rootClientMsgID,proto3" json:"rootClientMsgID,omitempty"`
+	Seq             int64  `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+	ClientMsgID     string `protobuf:"bytes,6,opt,name=clientMsgID,proto3" json:"clientMsgID,omitempty"`
+	SendID          string `protobuf:"bytes,7,opt,name=sendID,proto3" json:"sendID,omitempty"`
+	SendTime        int64  `protobuf:"varint,8,opt,name=sendTime,proto3" json:"sendTime,omitempty"`
+}
+
+func (x *ThreadReplyTips) Reset() {
+	*x = ThreadReplyTips{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ThreadReplyTips) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThreadReplyTips) ProtoMessage() {}
+
+func (x *ThreadReplyTips) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThreadReplyTips.ProtoReflect.Descriptor instead.
+func (*ThreadReplyTips) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ThreadReplyTips) GetThreadID() string {
+	if x != nil {
+		return x.ThreadID
+	}
+	return ""
+}
+
+func (x *ThreadReplyTips) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *ThreadReplyTips) GetRootSeq() int64 {
+	if x != nil {
+		return x.RootSeq
+	}
+	return 0
+}
+
+func (x *ThreadReplyTips) GetRootClientMsgID() string {
+	if x != nil {
+		return x.RootClientMsgID
+	}
+	return ""
+}
+
+func (x *ThreadReplyTips) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ThreadReplyTips) GetClientMsgID() string {
+	if x != nil {
+		return x.ClientMsgID
+	}
+	return ""
+}
+
+func (x *ThreadReplyTips) GetSendID() string {
+	if x != nil {
+		return x.SendID
+	}
+	return ""
+}
+
+func (x *ThreadReplyTips) GetSendTime() int64 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+type ThreadInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID             string         `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	RootMsg              *sdkws.MsgData `protobuf:"bytes,2,opt,name=rootMsg,proto3" json:"rootMsg,omitempty"`
+	ReplyCount           int64          `protobuf:"varint,3,opt,name=replyCount,proto3" json:"replyCount,omitempty"`
+	LastReplySeq         int64          `protobuf:"varint,4,opt,name=lastReplySeq,proto3" json:"lastReplySeq,omitempty"`
+	LastReplyUserID      string         `protobuf:"bytes,5,opt,name=lastReplyUserID,proto3" json:"lastReplyUserID,omitempty"`
+	LastReplyClientMsgID string         `protobuf:"bytes,6,opt,name=lastReplyClientMsgID,proto3" json:"lastReplyClientMsgID,omitempty"`
+	LastReplyTime        int64          `protobuf:"varint,7,opt,name=lastReplyTime,proto3" json:"lastReplyTime,omitempty"`
+}
+
+func (x *ThreadInfo) Reset() {
+	*x = ThreadInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ThreadInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThreadInfo) ProtoMessage() {}
+
+func (x *ThreadInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThreadInfo.ProtoReflect.Descriptor instead.
+func (*ThreadInfo) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ThreadInfo) GetThreadID() string {
+	if x != nil {
+		return x.ThreadID
+	}
+	return ""
+}
+
+func (x *ThreadInfo) GetRootMsg() *sdkws.MsgData {
+	if x != nil {
+		return x.RootMsg
+	}
+	return nil
+}
+
+func (x *ThreadInfo) GetReplyCount() int64 {
+	if x != nil {
+		return x.ReplyCount
+	}
+	return 0
+}
+
+func (x *ThreadInfo) GetLastReplySeq() int64 {
+	if x != nil {
+		return x.LastReplySeq
+	}
+	return 0
+}
+
+func (x *ThreadInfo) GetLastReplyUserID() string {
+	if x != nil {
+		return x.LastReplyUserID
+	}
+	return ""
+}
+
+func (x *ThreadInfo) GetLastReplyClientMsgID() string {
+	if x != nil {
+		return x.LastReplyClientMsgID
+	}
+	return ""
+}
+
+func (x *ThreadInfo) GetLastReplyTime() int64 {
+	if x != nil {
+		return x.LastReplyTime
+	}
+	return 0
+}
+
+// threads of conversationID, ordered by lastReplyTime desc
+type GetThreadsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID         string                   `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	ConversationID string                   `protobuf:"bytes,2,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	Pagination     *sdkws.RequestPagination `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (x *GetThreadsReq) Reset() {
+	*x = GetThreadsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetThreadsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadsReq) ProtoMessage() {}
+
+func (x *GetThreadsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadsReq.ProtoReflect.Descriptor instead.
+func (*GetThreadsReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetThreadsReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetThreadsReq) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *GetThreadsReq) GetPagination() *sdkws.RequestPagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type GetThreadsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total   int64         `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Threads []*ThreadInfo `protobuf:"bytes,2,rep,name=threads,proto3" json:"threads,omitempty"`
+}
+
+func (x *GetThreadsResp) Reset() {
+	*x = GetThreadsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetThreadsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadsResp) ProtoMessage() {}
+
+func (x *GetThreadsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadsResp.ProtoReflect.Descriptor instead.
+func (*GetThreadsResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetThreadsResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetThreadsResp) GetThreads() []*ThreadInfo {
+	if x != nil {
+		return x.Threads
+	}
+	return nil
+}
+
+type PinMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationID string `protobuf:"bytes,1,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	Seq            int64  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	UserID         string `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+}
+
+func (x *PinMsgReq) Reset() {
+	*x = PinMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PinMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMsgReq) ProtoMessage() {}
+
+func (x *PinMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMsgReq.ProtoReflect.Descriptor instead.
+func (*PinMsgReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PinMsgReq) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *PinMsgReq) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *PinMsgReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+type PinMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PinMsgResp) Reset() {
+	*x = PinMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PinMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMsgResp) ProtoMessage() {}
+
+func (x *PinMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMsgResp.ProtoReflect.Descriptor instead.
+func (*PinMsgResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{26}
+}
+
+type UnpinMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationID string `protobuf:"bytes,1,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	Seq            int64  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	UserID         string `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+}
+
+func (x *UnpinMsgReq) Reset() {
+	*x = UnpinMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnpinMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMsgReq) ProtoMessage() {}
+
+func (x *UnpinMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMsgReq.ProtoReflect.Descriptor instead.
+func (*UnpinMsgReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UnpinMsgReq) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *UnpinMsgReq) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *UnpinMsgReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+type UnpinMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UnpinMsgResp) Reset() {
+	*x = UnpinMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnpinMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMsgResp) ProtoMessage() {}
+
+func (x *UnpinMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMsgResp.ProtoReflect.Descriptor instead.
+func (*UnpinMsgResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{28}
+}
+
+type PinnedMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq      int64          `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	PinnedBy string         `protobuf:"bytes,2,opt,name=pinnedBy,proto3" json:"pinnedBy,omitempty"`
+	PinTime  int64          `protobuf:"varint,3,opt,name=pinTime,proto3" json:"pinTime,omitempty"`
+	MsgData  *sdkws.MsgData `protobuf:"bytes,4,opt,name=msgData,proto3" json:"msgData,omitempty"`
+}
+
+func (x *PinnedMsg) Reset() {
+	*x = PinnedMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PinnedMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinnedMsg) ProtoMessage() {}
+
+func (x *PinnedMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinnedMsg.ProtoReflect.Descriptor instead.
+func (*PinnedMsg) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PinnedMsg) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *PinnedMsg) GetPinnedBy() string {
+	if x != nil {
+		return x.PinnedBy
+	}
+	return ""
+}
+
+func (x *PinnedMsg) GetPinTime() int64 {
+	if x != nil {
+		return x.PinTime
+	}
+	return 0
+}
+
+func (x *PinnedMsg) GetMsgData() *sdkws.MsgData {
+	if x != nil {
+		return x.MsgData
+	}
+	return nil
+}
+
+// pinned msgs of conversationID, latest pinned first
+type GetPinnedMsgsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID         string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	ConversationID string `protobuf:"bytes,2,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+}
+
+func (x *GetPinnedMsgsReq) Reset() {
+	*x = GetPinnedMsgsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPinnedMsgsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPinnedMsgsReq) ProtoMessage() {}
+
+func (x *GetPinnedMsgsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPinnedMsgsReq.ProtoReflect.Descriptor instead.
+func (*GetPinnedMsgsReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetPinnedMsgsReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetPinnedMsgsReq) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+type GetPinnedMsgsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msgs []*PinnedMsg `protobuf:"bytes,1,rep,name=msgs,proto3" json:"msgs,omitempty"`
+}
+
+func (x *GetPinnedMsgsResp) Reset() {
+	*x = GetPinnedMsgsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPinnedMsgsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPinnedMsgsResp) ProtoMessage() {}
+
+func (x *GetPinnedMsgsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPinnedMsgsResp.ProtoReflect.Descriptor instead.
+func (*GetPinnedMsgsResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetPinnedMsgsResp) GetMsgs() []*PinnedMsg {
+	if x != nil {
+		return x.Msgs
+	}
+	return nil
+}
+
+// content of the MsgPinNotification
+type MsgPinTips struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OperatorUserID string `protobuf:"bytes,1,opt,name=operatorUserID,proto3" json:"operatorUserID,omitempty"`
+	ClientMsgID    string `protobuf:"bytes,2,opt,name=clientMsgID,proto3" json:"clientMsgID,omitempty"`
+	Seq            int64  `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	SessionType    int32  `protobuf:"varint,4,opt,name=sessionType,proto3" json:"sessionType,omitempty"`
+	ConversationID string `protobuf:"bytes,5,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	Unpinned       bool   `protobuf:"varint,6,opt,name=unpinned,proto3" json:"unpinned,omitempty"`
+	Time           int64  `protobuf:"varint,7,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *MsgPinTips) Reset() {
+	*x = MsgPinTips{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MsgPinTips) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MsgPinTips) ProtoMessage() {}
+
+func (x *MsgPinTips) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MsgPinTips.ProtoReflect.Descriptor instead.
+func (*MsgPinTips) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MsgPinTips) GetOperatorUserID() string {
+	if x != nil {
+		return x.OperatorUserID
+	}
+	return ""
+}
+
+func (x *MsgPinTips) GetClientMsgID() string {
+	if x != nil {
+		return x.ClientMsgID
+	}
+	return ""
+}
+
+func (x *MsgPinTips) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *MsgPinTips) GetSessionType() int32 {
+	if x != nil {
+		return x.SessionType
+	}
+	return 0
+}
+
+func (x *MsgPinTips) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *MsgPinTips) GetUnpinned() bool {
+	if x != nil {
+		return x.Unpinned
+	}
+	return false
+}
+
+func (x *MsgPinTips) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+// empty fields are not filtered, startTime and endTime are send times in ms,
+// keyword matches whole words of the text of the msgs.
+// app managers can search all msgs, other users only the conversationID they are in
+type SearchMsgsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID         string  `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Keyword        string  `protobuf:"bytes,2,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	SendID         string  `protobuf:"bytes,3,opt,name=sendID,proto3" json:"sendID,omitempty"`
+	RecvID         string  `protobuf:"bytes,4,opt,name=recvID,proto3" json:"recvID,omitempty"`
+	GroupID        string  `protobuf:"bytes,5,opt,name=groupID,proto3" json:"groupID,omitempty"`
+	ConversationID string  `protobuf:"bytes,6,opt,name=conversationID,proto3" json:"conversationID,omitempty"`
+	SessionType    int32   `protobuf:"varint,7,opt,name=sessionType,proto3" json:"sessionType,omitempty"`
+	ContentTypes   []int32 `protobuf:"varint,8,rep,packed,name=contentTypes,proto3" json:"contentTypes,omitempty"`
+	StartTime      int64   `protobuf:"varint,9,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime        int64   `protobuf:"varint,10,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Cursor         string  `protobuf:"bytes,11,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Count          int32   `protobuf:"varint,12,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *SearchMsgsReq) Reset() {
+	*x = SearchMsgsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchMsgsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMsgsReq) ProtoMessage() {}
+
+func (x *SearchMsgsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMsgsReq.ProtoReflect.Descriptor instead.
+func (*SearchMsgsReq) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SearchMsgsReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *SearchMsgsReq) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *SearchMsgsReq) GetSendID() string {
+	if x != nil {
+		return x.SendID
+	}
+	return ""
+}
+
+func (x *SearchMsgsReq) GetRecvID() string {
+	if x != nil {
+		return x.RecvID
+	}
+	return ""
+}
+
+func (x *SearchMsgsReq) GetGroupID() string {
+	if x != nil {
+		return x.GroupID
+	}
+	return ""
+}
+
+func (x *SearchMsgsReq) GetConversationID() string {
+	if x != nil {
+		return x.ConversationID
+	}
+	return ""
+}
+
+func (x *SearchMsgsReq) GetSessionType() int32 {
+	if x != nil {
+		return x.SessionType
+	}
+	return 0
+}
+
+func (x *SearchMsgsReq) GetContentTypes() []int32 {
+	if x != nil {
+		return x.ContentTypes
+	}
+	return nil
+}
+
+func (x *SearchMsgsReq) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *SearchMsgsReq) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *SearchMsgsReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchMsgsReq) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// latest msgs first, nextCursor is empty at the end
+type SearchMsgsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChatLogs   []*msg.ChatLog `protobuf:"bytes,1,rep,name=chatLogs,proto3" json:"chatLogs,omitempty"`
+	NextCursor string         `protobuf:"bytes,2,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+}
+
+func (x *SearchMsgsResp) Reset() {
+	*x = SearchMsgsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msgext_msgext_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchMsgsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMsgsResp) ProtoMessage() {}
+
+func (x *SearchMsgsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_msgext_msgext_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMsgsResp.ProtoReflect.Descriptor instead.
+func (*SearchMsgsResp) Descriptor() ([]byte, []int) {
+	return file_msgext_msgext_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SearchMsgsResp) GetChatLogs() []*msg.ChatLog {
+	if x != nil {
+		return x.ChatLogs
+	}
+	return nil
+}
+
+func (x *SearchMsgsResp) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+var File_msgext_msgext_proto protoreflect.FileDescriptor
+
+var file_msgext_msgext_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2f, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x1a, 0x11, 0x73, 0x64, 0x6b, 0x77,
+	0x73, 0x2f, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0d, 0x6d,
+	0x73, 0x67, 0x2f, 0x6d, 0x73, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x78, 0x0a, 0x0a,
+	0x45, 0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x73, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x0d, 0x0a, 0x0b, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x22, 0xe5, 0x01, 0x0a, 0x0b, 0x4d, 0x73, 0x67, 0x45, 0x64, 0x69,
+	0x74, 0x54, 0x69, 0x70, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x64, 0x69,
+	0x74, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x65,
+	0x64, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65,
+	0x64, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x78, 0x0a,
+	0x0e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12,
+	0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x22, 0x11, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x52, 0x65,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x7b, 0x0a, 0x11, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12,
+	0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x22, 0x14, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x65, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x71, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x71, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x44, 0x22, 0x5d, 0x0a, 0x0d, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x61, 0x63, 0x74, 0x65, 0x64, 0x42, 0x79, 0x4d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x63, 0x74, 0x65, 0x64, 0x42,
+	0x79, 0x4d, 0x65, 0x22, 0x62, 0x0a, 0x0c, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x40, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49,
+	0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x52,
+	0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x09, 0x72, 0x65,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x49, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x35, 0x0a, 0x04, 0x6d,
+	0x73, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x4f, 0x70, 0x65, 0x6e,
+	0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e,
+	0x4d, 0x73, 0x67, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x04, 0x6d, 0x73,
+	0x67, 0x73, 0x22, 0xe8, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x69, 0x70, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12,
+	0x20, 0x0a, 0x0b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x6f,
+	0x6a, 0x69, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x8d, 0x03,
+	0x0a, 0x0b, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41, 0x75, 0x64, 0x69, 0x74, 0x12, 0x26, 0x0a,
+	0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x65, 0x6e, 0x64, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e,
+	0x64, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x72,
+	0x65, 0x76, 0x6f, 0x6b, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x72, 0x52,
+	0x6f, 0x6c, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x72, 0x4e, 0x69,
+	0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x72, 0x4e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xd3, 0x01,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x45, 0x0a, 0x0a,
+	0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x61, 0x67,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x65, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x38, 0x0a, 0x06, 0x61, 0x75, 0x64, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x52, 0x06, 0x61, 0x75, 0x64, 0x69, 0x74, 0x73, 0x22, 0x51, 0x0a, 0x15, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67,
+	0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x22, 0x18, 0x0a,
+	0x16, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x22, 0x74, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16,
+	0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x45, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x4f, 0x70, 0x65,
+	0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x5d, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2f, 0x0a, 0x04, 0x6d,
+	0x73, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x4f, 0x70, 0x65, 0x6e,
+	0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x4d,
+	0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x73, 0x67, 0x73, 0x22, 0x8b, 0x01, 0x0a,
+	0x10, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65,
+	0x71, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x6f, 0x6f,
+	0x74, 0x53, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x6f, 0x6f, 0x74,
+	0x53, 0x65, 0x71, 0x12, 0x35, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x4d, 0x73, 0x67, 0x44, 0x61, 0x74,
+	0x61, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x22, 0xa1, 0x01, 0x0a, 0x11, 0x53,
+	0x65, 0x6e, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03,
+	0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x20,
+	0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x49, 0x44,
+	0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67,
+	0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x81,
+	0x02, 0x0a, 0x0f, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x69,
+	0x70, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x26,
+	0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x74, 0x53, 0x65,
+	0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x6f, 0x6f, 0x74, 0x53, 0x65, 0x71,
+	0x12, 0x28, 0x0a, 0x0f, 0x72, 0x6f, 0x6f, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73,
+	0x67, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x6f, 0x6f, 0x74, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65,
+	0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x20, 0x0a, 0x0b,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49, 0x44, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x65, 0x6e, 0x64, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x22, 0xa7, 0x02, 0x0a, 0x0a, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x35, 0x0a,
+	0x07, 0x72, 0x6f, 0x6f, 0x74, 0x4d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x73, 0x64,
+	0x6b, 0x77, 0x73, 0x2e, 0x4d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x52, 0x07, 0x72, 0x6f, 0x6f,
+	0x74, 0x4d, 0x73, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x53, 0x65, 0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x53, 0x65, 0x71, 0x12, 0x28, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x55, 0x73, 0x65, 0x72,
+	0x49, 0x44, 0x12, 0x32, 0x0a, 0x14, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x14, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6c,
+	0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x96, 0x01, 0x0a,
+	0x0d, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16,
+	0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x45,
+	0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50,
+	0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x61, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x39, 0x0a,
+	0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73,
+	0x67, 0x65, 0x78, 0x74, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x22, 0x5d, 0x0a, 0x09, 0x50, 0x69, 0x6e, 0x4d,
+	0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x22, 0x0c, 0x0a, 0x0a, 0x50, 0x69, 0x6e, 0x4d, 0x73,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x22, 0x5f, 0x0a, 0x0b, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x4d, 0x73,
+	0x67, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03,
+	0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x16,
+	0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x22, 0x0e, 0x0a, 0x0c, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x4d,
+	0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x22, 0x8a, 0x01, 0x0a, 0x09, 0x50, 0x69, 0x6e, 0x6e, 0x65,
+	0x64, 0x4d, 0x73, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64,
+	0x42, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64,
+	0x42, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x07,
+	0x6d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x73, 0x64, 0x6b,
+	0x77, 0x73, 0x2e, 0x4d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x44,
+	0x61, 0x74, 0x61, 0x22, 0x52, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64,
+	0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12,
+	0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0x47, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x69,
+	0x6e, 0x6e, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x32, 0x0a, 0x04,
+	0x6d, 0x73, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x4f, 0x70, 0x65,
+	0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74,
+	0x2e, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x04, 0x6d, 0x73, 0x67, 0x73,
+	0x22, 0xe2, 0x01, 0x0a, 0x0a, 0x4d, 0x73, 0x67, 0x50, 0x69, 0x6e, 0x54, 0x69, 0x70, 0x73, 0x12,
+	0x26, 0x0a, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x20, 0x0a, 0x0b, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a,
+	0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x70, 0x69, 0x6e, 0x6e, 0x65,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x75, 0x6e, 0x70, 0x69, 0x6e, 0x6e, 0x65,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xdf, 0x02, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12,
+	0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e,
+	0x64, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49,
+	0x44, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x67, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x35, 0x0a, 0x08, 0x63, 0x68, 0x61,
+	0x74, 0x4c, 0x6f, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x4f, 0x70,
+	0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x2e, 0x43,
+	0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x32, 0xb8, 0x09, 0x0a, 0x06, 0x6d, 0x73, 0x67, 0x45, 0x78, 0x74, 0x12, 0x4c, 0x0a, 0x07, 0x45,
+	0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x12, 0x1f, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x45, 0x64, 0x69,
+	0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x20, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x45, 0x64,
+	0x69, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x58, 0x0a, 0x0b, 0x41, 0x64, 0x64,
+	0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49,
+	0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x41,
+	0x64, 0x64, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x24, 0x2e,
+	0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67,
+	0x65, 0x78, 0x74, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x61, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x27, 0x2e,
+	0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67,
+	0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x5b, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x25, 0x2e, 0x4f,
+	0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65,
+	0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x64, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x73, 0x12, 0x27, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41, 0x75, 0x64, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x1a,
+	0x28, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d,
+	0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x6d, 0x0a, 0x12, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x12,
+	0x2a, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d,
+	0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x2b, 0x2e, 0x4f, 0x70,
+	0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78,
+	0x74, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x67, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x12, 0x28, 0x2e, 0x4f,
+	0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65,
+	0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d,
+	0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x29, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x5e, 0x0a, 0x0d, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x4d,
+	0x73, 0x67, 0x12, 0x25, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x26, 0x2e, 0x4f, 0x70, 0x65, 0x6e,
+	0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e,
+	0x53, 0x65, 0x6e, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x55, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x12,
+	0x22, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d,
+	0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x1a, 0x23, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x49, 0x0a, 0x06, 0x50, 0x69, 0x6e, 0x4d,
+	0x73, 0x67, 0x12, 0x1e, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x52,
+	0x65, 0x71, 0x1a, 0x1f, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x4f, 0x0a, 0x08, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x12,
+	0x20, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d,
+	0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x52, 0x65,
+	0x71, 0x1a, 0x21, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x4d, 0x73, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x5e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65,
+	0x64, 0x4d, 0x73, 0x67, 0x73, 0x12, 0x25, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x50,
+	0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x26, 0x2e, 0x4f,
+	0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65,
+	0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x55, 0x0a, 0x0a, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x73,
+	0x67, 0x73, 0x12, 0x22, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d,
+	0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x23, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x6d, 0x73, 0x67, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x42, 0x36, 0x5a, 0x34, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d,
+	0x53, 0x44, 0x4b, 0x2f, 0x4f, 0x70, 0x65, 0x6e, 0x2d, 0x49, 0x4d, 0x2d, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x73, 0x67,
+	0x65, 0x78, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_msgext_msgext_proto_rawDescOnce sync.Once
+	file_msgext_msgext_proto_rawDescData = file_msgext_msgext_proto_rawDesc
+)
+
+func file_msgext_msgext_proto_rawDescGZIP() []byte {
+	file_msgext_msgext_proto_rawDescOnce.Do(func() {
+		file_msgext_msgext_proto_rawDescData = protoimpl.X.CompressGZIP(file_msgext_msgext_proto_rawDescData)
+	})
+	return file_msgext_msgext_proto_rawDescData
+}
+
+var file_msgext_msgext_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_msgext_msgext_proto_goTypes = []interface{}{
+	(*EditMsgReq)(nil),              // 0: OpenIMServer.msgext.EditMsgReq
+	(*EditMsgResp)(nil),             // 1: OpenIMServer.msgext.EditMsgResp
+	(*MsgEditTips)(nil),             // 2: OpenIMServer.msgext.MsgEditTips
+	(*AddReactionReq)(nil),          // 3: OpenIMServer.msgext.AddReactionReq
+	(*AddReactionResp)(nil),         // 4: OpenIMServer.msgext.AddReactionResp
+	(*RemoveReactionReq)(nil),       // 5: OpenIMServer.msgext.RemoveReactionReq
+	(*RemoveReactionResp)(nil),      // 6: OpenIMServer.msgext.RemoveReactionResp
+	(*GetReactionsReq)(nil),         // 7: OpenIMServer.msgext.GetReactionsReq
+	(*ReactionCount)(nil),           // 8: OpenIMServer.msgext.ReactionCount
+	(*MsgReactions)(nil),            // 9: OpenIMServer.msgext.MsgReactions
+	(*GetReactionsResp)(nil),        // 10: OpenIMServer.msgext.GetReactionsResp
+	(*ReactionTips)(nil),            // 11: OpenIMServer.msgext.ReactionTips
+	(*RevokeAudit)(nil),             // 12: OpenIMServer.msgext.RevokeAudit
+	(*GetRevokeAuditsReq)(nil),      // 13: OpenIMServer.msgext.GetRevokeAuditsReq
+	(*GetRevokeAuditsResp)(nil),     // 14: OpenIMServer.msgext.GetRevokeAuditsResp
+	(*CancelScheduledMsgReq)(nil),   // 15: OpenIMServer.msgext.CancelScheduledMsgReq
+	(*CancelScheduledMsgResp)(nil),  // 16: OpenIMServer.msgext.CancelScheduledMsgResp
+	(*GetScheduledMsgsReq)(nil),     // 17: OpenIMServer.msgext.GetScheduledMsgsReq
+	(*GetScheduledMsgsResp)(nil),    // 18: OpenIMServer.msgext.GetScheduledMsgsResp
+	(*SendThreadMsgReq)(nil),        // 19: OpenIMServer.msgext.SendThreadMsgReq
+	(*SendThreadMsgResp)(nil),       // 20: OpenIMServer.msgext.SendThreadMsgResp
+	(*ThreadReplyTips)(nil),         // 21: OpenIMServer.msgext.ThreadReplyTips
+	(*ThreadInfo)(nil),              // 22: OpenIMServer.msgext.ThreadInfo
+	(*GetThreadsReq)(nil),           // 23: OpenIMServer.msgext.GetThreadsReq
+	(*GetThreadsResp)(nil),          // 24: OpenIMServer.msgext.GetThreadsResp
+	(*PinMsgReq)(nil),               // 25: OpenIMServer.msgext.PinMsgReq
+	(*PinMsgResp)(nil),              // 26: OpenIMServer.msgext.PinMsgResp
+	(*UnpinMsgReq)(nil),             // 27: OpenIMServer.msgext.UnpinMsgReq
+	(*UnpinMsgResp)(nil),            // 28: OpenIMServer.msgext.UnpinMsgResp
+	(*PinnedMsg)(nil),               // 29: OpenIMServer.msgext.PinnedMsg
+	(*GetPinnedMsgsReq)(nil),        // 30: OpenIMServer.msgext.GetPinnedMsgsReq
+	(*GetPinnedMsgsResp)(nil),       // 31: OpenIMServer.msgext.GetPinnedMsgsResp
+	(*MsgPinTips)(nil),              // 32: OpenIMServer.msgext.MsgPinTips
+	(*SearchMsgsReq)(nil),           // 33: OpenIMServer.msgext.SearchMsgsReq
+	(*SearchMsgsResp)(nil),          // 34: OpenIMServer.msgext.SearchMsgsResp
+	(*sdkws.RequestPagination)(nil), // 35: OpenIMServer.sdkws.RequestPagination
+	(*sdkws.MsgData)(nil),           // 36: OpenIMServer.sdkws.MsgData
+	(*msg.ChatLog)(nil),             // 37: OpenIMServer.msg.ChatLog
+}
+var file_msgext_msgext_proto_depIdxs = []int32{
+	8,  // 0: OpenIMServer.msgext.MsgReactions.reactions:type_name -> OpenIMServer.msgext.ReactionCount
+	9,  // 1: OpenIMServer.msgext.GetReactionsResp.msgs:type_name -> OpenIMServer.msgext.MsgReactions
+	35, // 2: OpenIMServer.msgext.GetRevokeAuditsReq.pagination:type_name -> OpenIMServer.sdkws.RequestPagination
+	12, // 3: OpenIMServer.msgext.GetRevokeAuditsResp.audits:type_name -> OpenIMServer.msgext.RevokeAudit
+	35, // 4: OpenIMServer.msgext.GetScheduledMsgsReq.pagination:type_name -> OpenIMServer.sdkws.RequestPagination
+	36, // 5: OpenIMServer.msgext.GetScheduledMsgsResp.msgs:type_name -> OpenIMServer.sdkws.MsgData
+	36, // 6: OpenIMServer.msgext.SendThreadMsgReq.msgData:type_name -> OpenIMServer.sdkws.MsgData
+	36, // 7: OpenIMServer.msgext.ThreadInfo.rootMsg:type_name -> OpenIMServer.sdkws.MsgData
+	35, // 8: OpenIMServer.msgext.GetThreadsReq.pagination:type_name -> OpenIMServer.sdkws.RequestPagination
+	22, // 9: OpenIMServer.msgext.GetThreadsResp.threads:type_name -> OpenIMServer.msgext.ThreadInfo
+	36, // 10: OpenIMServer.msgext.PinnedMsg.msgData:type_name -> OpenIMServer.sdkws.MsgData
+	29, // 11: OpenIMServer.msgext.GetPinnedMsgsResp.msgs:type_name -> OpenIMServer.msgext.PinnedMsg
+	37, // 12: OpenIMServer.msgext.SearchMsgsResp.chatLogs:type_name -> OpenIMServer.msg.ChatLog
+	0,  // 13: OpenIMServer.msgext.msgExt.EditMsg:input_type -> OpenIMServer.msgext.EditMsgReq
+	3,  // 14: OpenIMServer.msgext.msgExt.AddReaction:input_type -> OpenIMServer.msgext.AddReactionReq
+	5,  // 15: OpenIMServer.msgext.msgExt.RemoveReaction:input_type -> OpenIMServer.msgext.RemoveReactionReq
+	7,  // 16: OpenIMServer.msgext.msgExt.GetReactions:input_type -> OpenIMServer.msgext.GetReactionsReq
+	13, // 17: OpenIMServer.msgext.msgExt.GetRevokeAudits:input_type -> OpenIMServer.msgext.GetRevokeAuditsReq
+	15, // 18: OpenIMServer.msgext.msgExt.CancelScheduledMsg:input_type -> OpenIMServer.msgext.CancelScheduledMsgReq
+	17, // 19: OpenIMServer.msgext.msgExt.GetScheduledMsgs:input_type -> OpenIMServer.msgext.GetScheduledMsgsReq
+	19, // 20: OpenIMServer.msgext.msgExt.SendThreadMsg:input_type -> OpenIMServer.msgext.SendThreadMsgReq
+	23, // 21: OpenIMServer.msgext.msgExt.GetThreads:input_type -> OpenIMServer.msgext.GetThreadsReq
+	25, // 22: OpenIMServer.msgext.msgExt.PinMsg:input_type -> OpenIMServer.msgext.PinMsgReq
+	27, // 23: OpenIMServer.msgext.msgExt.UnpinMsg:input_type -> OpenIMServer.msgext.UnpinMsgReq
+	30, // 24: OpenIMServer.msgext.msgExt.GetPinnedMsgs:input_type -> OpenIMServer.msgext.GetPinnedMsgsReq
+	33, // 25: OpenIMServer.msgext.msgExt.SearchMsgs:input_type -> OpenIMServer.msgext.SearchMsgsReq
+	1,  // 26: OpenIMServer.msgext.msgExt.EditMsg:output_type -> OpenIMServer.msgext.EditMsgResp
+	4,  // 27: OpenIMServer.msgext.msgExt.AddReaction:output_type -> OpenIMServer.msgext.AddReactionResp
+	6,  // 28: OpenIMServer.msgext.msgExt.RemoveReaction:output_type -> OpenIMServer.msgext.RemoveReactionResp
+	10, // 29: OpenIMServer.msgext.msgExt.GetReactions:output_type -> OpenIMServer.msgext.GetReactionsResp
+	14, // 30: OpenIMServer.msgext.msgExt.GetRevokeAudits:output_type -> OpenIMServer.msgext.GetRevokeAuditsResp
+	16, // 31: OpenIMServer.msgext.msgExt.CancelScheduledMsg:output_type -> OpenIMServer.msgext.CancelScheduledMsgResp
+	18, // 32: OpenIMServer.msgext.msgExt.GetScheduledMsgs:output_type -> OpenIMServer.msgext.GetScheduledMsgsResp
+	20, // 33: OpenIMServer.msgext.msgExt.SendThreadMsg:output_type -> OpenIMServer.msgext.SendThreadMsgResp
+	24, // 34: OpenIMServer.msgext.msgExt.GetThreads:output_type -> OpenIMServer.msgext.GetThreadsResp
+	26, // 35: OpenIMServer.msgext.msgExt.PinMsg:output_type -> OpenIMServer.msgext.PinMsgResp
+	28, // 36: OpenIMServer.msgext.msgExt.UnpinMsg:output_type -> OpenIMServer.msgext.UnpinMsgResp
+	31, // 37: OpenIMServer.msgext.msgExt.GetPinnedMsgs:output_type -> OpenIMServer.msgext.GetPinnedMsgsResp
+	34, // 38: OpenIMServer.msgext.msgExt.SearchMsgs:output_type -> OpenIMServer.msgext.SearchMsgsResp
+	26, // [26:39] is the sub-list for method output_type
+	13, // [13:26] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_msgext_msgext_proto_init() }
+func file_msgext_msgext_proto_init() {
+	if File_msgext_msgext_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_msgext_msgext_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EditMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EditMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgEditTips); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddReactionReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddReactionResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveReactionReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveReactionResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReactionsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReactionCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgReactions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReactionsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReactionTips); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeAudit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRevokeAuditsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRevokeAuditsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelScheduledMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelScheduledMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetScheduledMsgsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetScheduledMsgsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendThreadMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendThreadMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ThreadReplyTips); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ThreadInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetThreadsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetThreadsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PinMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PinMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnpinMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnpinMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PinnedMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPinnedMsgsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPinnedMsgsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgPinTips); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchMsgsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msgext_msgext_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchMsgsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_msgext_msgext_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   35,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_msgext_msgext_proto_goTypes,
+		DependencyIndexes: file_msgext_msgext_proto_depIdxs,
+		MessageInfos:      file_msgext_msgext_proto_msgTypes,
+	}.Build()
+	File_msgext_msgext_proto = out.File
+	file_msgext_msgext_proto_rawDesc = nil
+	file_msgext_msgext_proto_goTypes = nil
+	file_msgext_msgext_proto_depIdxs = nil
+}
@@ -0,0 +1,565 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.22.0
+// source: msgext/msgext.proto
+
+package msgext
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	MsgExt_EditMsg_FullMethodName            = "/OpenIMServer.msgext.msgExt/EditMsg"
+	MsgExt_AddReaction_FullMethodName        = "/OpenIMServer.msgext.msgExt/AddReaction"
+	MsgExt_RemoveReaction_FullMethodName     = "/OpenIMServer.msgext.msgExt/RemoveReaction"
+	MsgExt_GetReactions_FullMethodName       = "/OpenIMServer.msgext.msgExt/GetReactions"
+	MsgExt_GetRevokeAudits_FullMethodName    = "/OpenIMServer.msgext.msgExt/GetRevokeAudits"
+	MsgExt_CancelScheduledMsg_FullMethodName = "/OpenIMServer.msgext.msgExt/CancelScheduledMsg"
+	MsgExt_GetScheduledMsgs_FullMethodName   = "/OpenIMServer.msgext.msgExt/GetScheduledMsgs"
+	MsgExt_SendThreadMsg_FullMethodName      = "/OpenIMServer.msgext.msgExt/SendThreadMsg"
+	MsgExt_GetThreads_FullMethodName         = "/OpenIMServer.msgext.msgExt/GetThreads"
+	MsgExt_PinMsg_FullMethodName             = "/OpenIMServer.msgext.msgExt/PinMsg"
+	MsgExt_UnpinMsg_FullMethodName           = "/OpenIMServer.msgext.msgExt/UnpinMsg"
+	MsgExt_GetPinnedMsgs_FullMethodName      = "/OpenIMServer.msgext.msgExt/GetPinnedMsgs"
+	MsgExt_SearchMsgs_FullMethodName         = "/OpenIMServer.msgext.msgExt/SearchMsgs"
+)
+
+// MsgExtClient is the client API for MsgExt service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MsgExtClient interface {
+	EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error)
+	AddReaction(ctx context.Context, in *AddReactionReq, opts ...grpc.CallOption) (*AddReactionResp, error)
+	RemoveReaction(ctx context.Context, in *RemoveReactionReq, opts ...grpc.CallOption) (*RemoveReactionResp, error)
+	GetReactions(ctx context.Context, in *GetReactionsReq, opts ...grpc.CallOption) (*GetReactionsResp, error)
+	GetRevokeAudits(ctx context.Context, in *GetRevokeAuditsReq, opts ...grpc.CallOption) (*GetRevokeAuditsResp, error)
+	CancelScheduledMsg(ctx context.Context, in *CancelScheduledMsgReq, opts ...grpc.CallOption) (*CancelScheduledMsgResp, error)
+	GetScheduledMsgs(ctx context.Context, in *GetScheduledMsgsReq, opts ...grpc.CallOption) (*GetScheduledMsgsResp, error)
+	SendThreadMsg(ctx context.Context, in *SendThreadMsgReq, opts ...grpc.CallOption) (*SendThreadMsgResp, error)
+	GetThreads(ctx context.Context, in *GetThreadsReq, opts ...grpc.CallOption) (*GetThreadsResp, error)
+	PinMsg(ctx context.Context, in *PinMsgReq, opts ...grpc.CallOption) (*PinMsgResp, error)
+	UnpinMsg(ctx context.Context, in *UnpinMsgReq, opts ...grpc.CallOption) (*UnpinMsgResp, error)
+	GetPinnedMsgs(ctx context.Context, in *GetPinnedMsgsReq, opts ...grpc.CallOption) (*GetPinnedMsgsResp, error)
+	SearchMsgs(ctx context.Context, in *SearchMsgsReq, opts ...grpc.CallOption) (*SearchMsgsResp, error)
+}
+
+type msgExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMsgExtClient(cc grpc.ClientConnInterface) MsgExtClient {
+	return &msgExtClient{cc}
+}
+
+func (c *msgExtClient) EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error) {
+	out := new(EditMsgResp)
+	err := c.cc.Invoke(ctx, MsgExt_EditMsg_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) AddReaction(ctx context.Context, in *AddReactionReq, opts ...grpc.CallOption) (*AddReactionResp, error) {
+	out := new(AddReactionResp)
+	err := c.cc.Invoke(ctx, MsgExt_AddReaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) RemoveReaction(ctx context.Context, in *RemoveReactionReq, opts ...grpc.CallOption) (*RemoveReactionResp, error) {
+	out := new(RemoveReactionResp)
+	err := c.cc.Invoke(ctx, MsgExt_RemoveReaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) GetReactions(ctx context.Context, in *GetReactionsReq, opts ...grpc.CallOption) (*GetReactionsResp, error) {
+	out := new(GetReactionsResp)
+	err := c.cc.Invoke(ctx, MsgExt_GetReactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) GetRevokeAudits(ctx context.Context, in *GetRevokeAuditsReq, opts ...grpc.CallOption) (*GetRevokeAuditsResp, error) {
+	out := new(GetRevokeAuditsResp)
+	err := c.cc.Invoke(ctx, MsgExt_GetRevokeAudits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) CancelScheduledMsg(ctx context.Context, in *CancelScheduledMsgReq, opts ...grpc.CallOption) (*CancelScheduledMsgResp, error) {
+	out := new(CancelScheduledMsgResp)
+	err := c.cc.Invoke(ctx, MsgExt_CancelScheduledMsg_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) GetScheduledMsgs(ctx context.Context, in *GetScheduledMsgsReq, opts ...grpc.CallOption) (*GetScheduledMsgsResp, error) {
+	out := new(GetScheduledMsgsResp)
+	err := c.cc.Invoke(ctx, MsgExt_GetScheduledMsgs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) SendThreadMsg(ctx context.Context, in *SendThreadMsgReq, opts ...grpc.CallOption) (*SendThreadMsgResp, error) {
+	out := new(SendThreadMsgResp)
+	err := c.cc.Invoke(ctx, MsgExt_SendThreadMsg_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) GetThreads(ctx context.Context, in *GetThreadsReq, opts ...grpc.CallOption) (*GetThreadsResp, error) {
+	out := new(GetThreadsResp)
+	err := c.cc.Invoke(ctx, MsgExt_GetThreads_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) PinMsg(ctx context.Context, in *PinMsgReq, opts ...grpc.CallOption) (*PinMsgResp, error) {
+	out := new(PinMsgResp)
+	err := c.cc.Invoke(ctx, MsgExt_PinMsg_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) UnpinMsg(ctx context.Context, in *UnpinMsgReq, opts ...grpc.CallOption) (*UnpinMsgResp, error) {
+	out := new(UnpinMsgResp)
+	err := c.cc.Invoke(ctx, MsgExt_UnpinMsg_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) GetPinnedMsgs(ctx context.Context, in *GetPinnedMsgsReq, opts ...grpc.CallOption) (*GetPinnedMsgsResp, error) {
+	out := new(GetPinnedMsgsResp)
+	err := c.cc.Invoke(ctx, MsgExt_GetPinnedMsgs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgExtClient) SearchMsgs(ctx context.Context, in *SearchMsgsReq, opts ...grpc.CallOption) (*SearchMsgsResp, error) {
+	out := new(SearchMsgsResp)
+	err := c.cc.Invoke(ctx, MsgExt_SearchMsgs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MsgExtServer is the server API for MsgExt service.
+// All implementations should embed UnimplementedMsgExtServer
+// for forward compatibility
+type MsgExtServer interface {
+	EditMsg(context.Context, *EditMsgReq) (*EditMsgResp, error)
+	AddReaction(context.Context, *AddReactionReq) (*AddReactionResp, error)
+	RemoveReaction(context.Context, *RemoveReactionReq) (*RemoveReactionResp, error)
+	GetReactions(context.Context, *GetReactionsReq) (*GetReactionsResp, error)
+	GetRevokeAudits(context.Context, *GetRevokeAuditsReq) (*GetRevokeAuditsResp, error)
+	CancelScheduledMsg(context.Context, *CancelScheduledMsgReq) (*CancelScheduledMsgResp, error)
+	GetScheduledMsgs(context.Context, *GetScheduledMsgsReq) (*GetScheduledMsgsResp, error)
+	SendThreadMsg(context.Context, *SendThreadMsgReq) (*SendThreadMsgResp, error)
+	GetThreads(context.Context, *GetThreadsReq) (*GetThreadsResp, error)
+	PinMsg(context.Context, *PinMsgReq) (*PinMsgResp, error)
+	UnpinMsg(context.Context, *UnpinMsgReq) (*UnpinMsgResp, error)
+	GetPinnedMsgs(context.Context, *GetPinnedMsgsReq) (*GetPinnedMsgsResp, error)
+	SearchMsgs(context.Context, *SearchMsgsReq) (*SearchMsgsResp, error)
+}
+
+// UnimplementedMsgExtServer should be embedded to have forward compatible implementations.
+type UnimplementedMsgExtServer struct {
+}
+
+func (UnimplementedMsgExtServer) EditMsg(context.Context, *EditMsgReq) (*EditMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditMsg not implemented")
+}
+func (UnimplementedMsgExtServer) AddReaction(context.Context, *AddReactionReq) (*AddReactionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReaction not implemented")
+}
+func (UnimplementedMsgExtServer) RemoveReaction(context.Context, *RemoveReactionReq) (*RemoveReactionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveReaction not implemented")
+}
+func (UnimplementedMsgExtServer) GetReactions(context.Context, *GetReactionsReq) (*GetReactionsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReactions not implemented")
+}
+func (UnimplementedMsgExtServer) GetRevokeAudits(context.Context, *GetRevokeAuditsReq) (*GetRevokeAuditsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevokeAudits not implemented")
+}
+func (UnimplementedMsgExtServer) CancelScheduledMsg(context.Context, *CancelScheduledMsgReq) (*CancelScheduledMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledMsg not implemented")
+}
+func (UnimplementedMsgExtServer) GetScheduledMsgs(context.Context, *GetScheduledMsgsReq) (*GetScheduledMsgsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduledMsgs not implemented")
+}
+func (UnimplementedMsgExtServer) SendThreadMsg(context.Context, *SendThreadMsgReq) (*SendThreadMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendThreadMsg not implemented")
+}
+func (UnimplementedMsgExtServer) GetThreads(context.Context, *GetThreadsReq) (*GetThreadsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThreads not implemented")
+}
+func (UnimplementedMsgExtServer) PinMsg(context.Context, *PinMsgReq) (*PinMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinMsg not implemented")
+}
+func (UnimplementedMsgExtServer) UnpinMsg(context.Context, *UnpinMsgReq) (*UnpinMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpinMsg not implemented")
+}
+func (UnimplementedMsgExtServer) GetPinnedMsgs(context.Context, *GetPinnedMsgsReq) (*GetPinnedMsgsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPinnedMsgs not implemented")
+}
+func (UnimplementedMsgExtServer) SearchMsgs(context.Context, *SearchMsgsReq) (*SearchMsgsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMsgs not implemented")
+}
+
+// UnsafeMsgExtServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MsgExtServer will
+// result in compilation errors.
+type UnsafeMsgExtServer interface {
+	mustEmbedUnimplementedMsgExtServer()
+}
+
+func RegisterMsgExtServer(s grpc.ServiceRegistrar, srv MsgExtServer) {
+	s.RegisterService(&MsgExt_ServiceDesc, srv)
+}
+
+func _MsgExt_EditMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).EditMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_EditMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).EditMsg(ctx, req.(*EditMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_AddReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReactionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).AddReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_AddReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).AddReaction(ctx, req.(*AddReactionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_RemoveReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveReactionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).RemoveReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_RemoveReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).RemoveReaction(ctx, req.(*RemoveReactionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_GetReactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReactionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).GetReactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_GetReactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).GetReactions(ctx, req.(*GetReactionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_GetRevokeAudits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevokeAuditsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).GetRevokeAudits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_GetRevokeAudits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).GetRevokeAudits(ctx, req.(*GetRevokeAuditsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_CancelScheduledMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).CancelScheduledMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_CancelScheduledMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).CancelScheduledMsg(ctx, req.(*CancelScheduledMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_GetScheduledMsgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduledMsgsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).GetScheduledMsgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_GetScheduledMsgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).GetScheduledMsgs(ctx, req.(*GetScheduledMsgsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_SendThreadMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendThreadMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).SendThreadMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_SendThreadMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).SendThreadMsg(ctx, req.(*SendThreadMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_GetThreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).GetThreads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_GetThreads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).GetThreads(ctx, req.(*GetThreadsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_PinMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).PinMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_PinMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).PinMsg(ctx, req.(*PinMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_UnpinMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).UnpinMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_UnpinMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).UnpinMsg(ctx, req.(*UnpinMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_GetPinnedMsgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPinnedMsgsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).GetPinnedMsgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_GetPinnedMsgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).GetPinnedMsgs(ctx, req.(*GetPinnedMsgsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MsgExt_SearchMsgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMsgsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgExtServer).SearchMsgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgExt_SearchMsgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgExtServer).SearchMsgs(ctx, req.(*SearchMsgsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MsgExt_ServiceDesc is the grpc.ServiceDesc for MsgExt service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MsgExt_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "OpenIMServer.msgext.msgExt",
+	HandlerType: (*MsgExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EditMsg",
+			Handler:    _MsgExt_EditMsg_Handler,
+		},
+		{
+			MethodName: "AddReaction",
+			Handler:    _MsgExt_AddReaction_Handler,
+		},
+		{
+			MethodName: "RemoveReaction",
+			Handler:    _MsgExt_RemoveReaction_Handler,
+		},
+		{
+			MethodName: "GetReactions",
+			Handler:    _MsgExt_GetReactions_Handler,
+		},
+		{
+			MethodName: "GetRevokeAudits",
+			Handler:    _MsgExt_GetRevokeAudits_Handler,
+		},
+		{
+			MethodName: "CancelScheduledMsg",
+			Handler:    _MsgExt_CancelScheduledMsg_Handler,
+		},
+		{
+			MethodName: "GetScheduledMsgs",
+			Handler:    _MsgExt_GetScheduledMsgs_Handler,
+		},
+		{
+			MethodName: "SendThreadMsg",
+			Handler:    _MsgExt_SendThreadMsg_Handler,
+		},
+		{
+			MethodName: "GetThreads",
+			Handler:    _MsgExt_GetThreads_Handler,
+		},
+		{
+			MethodName: "PinMsg",
+			Handler:    _MsgExt_PinMsg_Handler,
+		},
+		{
+			MethodName: "UnpinMsg",
+			Handler:    _MsgExt_UnpinMsg_Handler,
+		},
+		{
+			MethodName: "GetPinnedMsgs",
+			Handler:    _MsgExt_GetPinnedMsgs_Handler,
+		},
+		{
+			MethodName: "SearchMsgs",
+			Handler:    _MsgExt_SearchMsgs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "msgext/msgext.proto",
+}
@@ -0,0 +1,249 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.22.0
+// source: pushext/pushext.proto
+
+package pushext
+
+import (
+	sdkws "github.com/OpenIMSDK/protocol/sdkws"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// messages pushed online to a connection of userID that were not acked by the client in time
+type OfflinePushUnackedReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID     string           `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	PlatformID int32            `protobuf:"varint,2,opt,name=platformID,proto3" json:"platformID,omitempty"`
+	Msgs       []*sdkws.MsgData `protobuf:"bytes,3,rep,name=msgs,proto3" json:"msgs,omitempty"`
+}
+
+func (x *OfflinePushUnackedReq) Reset() {
+	*x = OfflinePushUnackedReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pushext_pushext_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfflinePushUnackedReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfflinePushUnackedReq) ProtoMessage() {}
+
+func (x *OfflinePushUnackedReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pushext_pushext_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfflinePushUnackedReq.ProtoReflect.Descriptor instead.
+func (*OfflinePushUnackedReq) Descriptor() ([]byte, []int) {
+	return file_pushext_pushext_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OfflinePushUnackedReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *OfflinePushUnackedReq) GetPlatformID() int32 {
+	if x != nil {
+		return x.PlatformID
+	}
+	return 0
+}
+
+func (x *OfflinePushUnackedReq) GetMsgs() []*sdkws.MsgData {
+	if x != nil {
+		return x.Msgs
+	}
+	return nil
+}
+
+type OfflinePushUnackedResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *OfflinePushUnackedResp) Reset() {
+	*x = OfflinePushUnackedResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pushext_pushext_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfflinePushUnackedResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfflinePushUnackedResp) ProtoMessage() {}
+
+func (x *OfflinePushUnackedResp) ProtoReflect() protoreflect.Message {
+	mi := &file_pushext_pushext_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfflinePushUnackedResp.ProtoReflect.Descriptor instead.
+func (*OfflinePushUnackedResp) Descriptor() ([]byte, []int) {
+	return file_pushext_pushext_proto_rawDescGZIP(), []int{1}
+}
+
+var File_pushext_pushext_proto protoreflect.FileDescriptor
+
+var file_pushext_pushext_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x70, 0x75, 0x73, 0x68, 0x65, 0x78, 0x74, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x65, 0x78,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x65, 0x78, 0x74, 0x1a, 0x11, 0x73,
+	0x64, 0x6b, 0x77, 0x73, 0x2f, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x80, 0x01, 0x0a, 0x15, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x50, 0x75, 0x73, 0x68,
+	0x55, 0x6e, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x44,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d,
+	0x49, 0x44, 0x12, 0x2f, 0x0a, 0x04, 0x6d, 0x73, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x4d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x6d,
+	0x73, 0x67, 0x73, 0x22, 0x18, 0x0a, 0x16, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x50, 0x75,
+	0x73, 0x68, 0x55, 0x6e, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x32, 0x7d, 0x0a,
+	0x0a, 0x70, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x45, 0x78, 0x74, 0x12, 0x6f, 0x0a, 0x12, 0x4f,
+	0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x50, 0x75, 0x73, 0x68, 0x55, 0x6e, 0x61, 0x63, 0x6b, 0x65,
+	0x64, 0x12, 0x2b, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x70, 0x75, 0x73, 0x68, 0x65, 0x78, 0x74, 0x2e, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65,
+	0x50, 0x75, 0x73, 0x68, 0x55, 0x6e, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x71, 0x1a, 0x2c,
+	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x75,
+	0x73, 0x68, 0x65, 0x78, 0x74, 0x2e, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x50, 0x75, 0x73,
+	0x68, 0x55, 0x6e, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x42, 0x37, 0x5a, 0x35,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4f, 0x70, 0x65, 0x6e, 0x49,
+	0x4d, 0x53, 0x44, 0x4b, 0x2f, 0x4f, 0x70, 0x65, 0x6e, 0x2d, 0x49, 0x4d, 0x2d, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x75,
+	0x73, 0x68, 0x65, 0x78, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pushext_pushext_proto_rawDescOnce sync.Once
+	file_pushext_pushext_proto_rawDescData = file_pushext_pushext_proto_rawDesc
+)
+
+func file_pushext_pushext_proto_rawDescGZIP() []byte {
+	file_pushext_pushext_proto_rawDescOnce.Do(func() {
+		file_pushext_pushext_proto_rawDescData = protoimpl.X.CompressGZIP(file_pushext_pushext_proto_rawDescData)
+	})
+	return file_pushext_pushext_proto_rawDescData
+}
+
+var file_pushext_pushext_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pushext_pushext_proto_goTypes = []interface{}{
+	(*OfflinePushUnackedReq)(nil),  // 0: OpenIMServer.pushext.OfflinePushUnackedReq
+	(*OfflinePushUnackedResp)(nil), // 1: OpenIMServer.pushext.OfflinePushUnackedResp
+	(*sdkws.MsgData)(nil),          // 2: OpenIMServer.sdkws.MsgData
+}
+var file_pushext_pushext_proto_depIdxs = []int32{
+	2, // 0: OpenIMServer.pushext.OfflinePushUnackedReq.msgs:type_name -> OpenIMServer.sdkws.MsgData
+	0, // 1: OpenIMServer.pushext.pushMsgExt.OfflinePushUnacked:input_type -> OpenIMServer.pushext.OfflinePushUnackedReq
+	1, // 2: OpenIMServer.pushext.pushMsgExt.OfflinePushUnacked:output_type -> OpenIMServer.pushext.OfflinePushUnackedResp
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pushext_pushext_proto_init() }
+func file_pushext_pushext_proto_init() {
+	if File_pushext_pushext_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pushext_pushext_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfflinePushUnackedReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pushext_pushext_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfflinePushUnackedResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pushext_pushext_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pushext_pushext_proto_goTypes,
+		DependencyIndexes: file_pushext_pushext_proto_depIdxs,
+		MessageInfos:      file_pushext_pushext_proto_msgTypes,
+	}.Build()
+	File_pushext_pushext_proto = out.File
+	file_pushext_pushext_proto_rawDesc = nil
+	file_pushext_pushext_proto_goTypes = nil
+	file_pushext_pushext_proto_depIdxs = nil
+}
@@ -0,0 +1,121 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.22.0
+// source: pushext/pushext.proto
+
+package pushext
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PushMsgExt_OfflinePushUnacked_FullMethodName = "/OpenIMServer.pushext.pushMsgExt/OfflinePushUnacked"
+)
+
+// PushMsgExtClient is the client API for PushMsgExt service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PushMsgExtClient interface {
+	OfflinePushUnacked(ctx context.Context, in *OfflinePushUnackedReq, opts ...grpc.CallOption) (*OfflinePushUnackedResp, error)
+}
+
+type pushMsgExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPushMsgExtClient(cc grpc.ClientConnInterface) PushMsgExtClient {
+	return &pushMsgExtClient{cc}
+}
+
+func (c *pushMsgExtClient) OfflinePushUnacked(ctx context.Context, in *OfflinePushUnackedReq, opts ...grpc.CallOption) (*OfflinePushUnackedResp, error) {
+	out := new(OfflinePushUnackedResp)
+	err := c.cc.Invoke(ctx, PushMsgExt_OfflinePushUnacked_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PushMsgExtServer is the server API for PushMsgExt service.
+// All implementations should embed UnimplementedPushMsgExtServer
+// for forward compatibility
+type PushMsgExtServer interface {
+	OfflinePushUnacked(context.Context, *OfflinePushUnackedReq) (*OfflinePushUnackedResp, error)
+}
+
+// UnimplementedPushMsgExtServer should be embedded to have forward compatible implementations.
+type UnimplementedPushMsgExtServer struct {
+}
+
+func (UnimplementedPushMsgExtServer) OfflinePushUnacked(context.Context, *OfflinePushUnackedReq) (*OfflinePushUnackedResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OfflinePushUnacked not implemented")
+}
+
+// UnsafePushMsgExtServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PushMsgExtServer will
+// result in compilation errors.
+type UnsafePushMsgExtServer interface {
+	mustEmbedUnimplementedPushMsgExtServer()
+}
+
+func RegisterPushMsgExtServer(s grpc.ServiceRegistrar, srv PushMsgExtServer) {
+	s.RegisterService(&PushMsgExt_ServiceDesc, srv)
+}
+
+func _PushMsgExt_OfflinePushUnacked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfflinePushUnackedReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushMsgExtServer).OfflinePushUnacked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushMsgExt_OfflinePushUnacked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushMsgExtServer).OfflinePushUnacked(ctx, req.(*OfflinePushUnackedReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PushMsgExt_ServiceDesc is the grpc.ServiceDesc for PushMsgExt service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PushMsgExt_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "OpenIMServer.pushext.pushMsgExt",
+	HandlerType: (*PushMsgExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "OfflinePushUnacked",
+			Handler:    _PushMsgExt_OfflinePushUnacked_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pushext/pushext.proto",
+}
@@ -20,6 +20,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/authext"
 	"github.com/OpenIMSDK/protocol/auth"
 	"github.com/OpenIMSDK/tools/discoveryregistry"
 )
@@ -30,11 +31,12 @@ func NewAuth(discov discoveryregistry.SvcDiscoveryRegistry) *Auth {
 		panic(err)
 	}
 	client := auth.NewAuthClient(conn)
-	return &Auth{discov: discov, conn: conn, Client: client}
+	return &Auth{discov: discov, conn: conn, Client: client, ExtClient: authext.NewAuthExtClient(conn)}
 }
 
 type Auth struct {
-	conn   grpc.ClientConnInterface
-	Client auth.AuthClient
-	discov discoveryregistry.SvcDiscoveryRegistry
+	conn      grpc.ClientConnInterface
+	Client    auth.AuthClient
+	ExtClient authext.AuthExtClient
+	discov    discoveryregistry.SvcDiscoveryRegistry
 }
@@ -22,6 +22,8 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
 	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/protocol/msg"
 	"github.com/OpenIMSDK/protocol/sdkws"
@@ -71,9 +73,13 @@ func newContentTypeConf() map[int32]config.NotificationConf {
 		constant.ConversationUnreadNotification:      config.Config.Notification.ConversationChanged,
 		constant.ConversationPrivateChatNotification: config.Config.Notification.ConversationSetPrivate,
 		// msg
-		constant.MsgRevokeNotification:  {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
-		constant.HasReadReceipt:         {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
-		constant.DeleteMsgsNotification: {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		constant.MsgRevokeNotification:       {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		constant.HasReadReceipt:              {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		constant.DeleteMsgsNotification:      {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		msgprocessor.MsgEditNotification:     {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		msgprocessor.MsgReactionNotification: {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		msgprocessor.ThreadReplyNotification: {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		msgprocessor.MsgPinNotification:      {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
 	}
 }
 
@@ -122,9 +128,10 @@ func newSessionTypeConf() map[int32]int32 {
 }
 
 type Message struct {
-	conn   grpc.ClientConnInterface
-	Client msg.MsgClient
-	discov discoveryregistry.SvcDiscoveryRegistry
+	conn      grpc.ClientConnInterface
+	Client    msg.MsgClient
+	ExtClient msgext.MsgExtClient
+	discov    discoveryregistry.SvcDiscoveryRegistry
 }
 
 func NewMessage(discov discoveryregistry.SvcDiscoveryRegistry) *Message {
@@ -133,7 +140,7 @@ func NewMessage(discov discoveryregistry.SvcDiscoveryRegistry) *Message {
 		panic(err)
 	}
 	client := msg.NewMsgClient(conn)
-	return &Message{discov: discov, conn: conn, Client: client}
+	return &Message{discov: discov, conn: conn, Client: client, ExtClient: msgext.NewMsgExtClient(conn)}
 }
 
 type MessageRpcClient Message
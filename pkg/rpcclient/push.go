@@ -20,14 +20,16 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/pushext"
 	"github.com/OpenIMSDK/protocol/push"
 	"github.com/OpenIMSDK/tools/discoveryregistry"
 )
 
 type Push struct {
-	conn   grpc.ClientConnInterface
-	Client push.PushMsgServiceClient
-	discov discoveryregistry.SvcDiscoveryRegistry
+	conn      grpc.ClientConnInterface
+	Client    push.PushMsgServiceClient
+	ExtClient pushext.PushMsgExtClient
+	discov    discoveryregistry.SvcDiscoveryRegistry
 }
 
 func NewPush(discov discoveryregistry.SvcDiscoveryRegistry) *Push {
@@ -36,9 +38,10 @@ func NewPush(discov discoveryregistry.SvcDiscoveryRegistry) *Push {
 		panic(err)
 	}
 	return &Push{
-		discov: discov,
-		conn:   conn,
-		Client: push.NewPushMsgServiceClient(conn),
+		discov:    discov,
+		conn:      conn,
+		Client:    push.NewPushMsgServiceClient(conn),
+		ExtClient: pushext.NewPushMsgExtClient(conn),
 	}
 }
 
@@ -54,3 +57,10 @@ func (p *PushRpcClient) DelUserPushToken(
 ) (*push.DelUserPushTokenResp, error) {
 	return p.Client.DelUserPushToken(ctx, req)
 }
+
+func (p *PushRpcClient) OfflinePushUnacked(
+	ctx context.Context,
+	req *pushext.OfflinePushUnackedReq,
+) (*pushext.OfflinePushUnackedResp, error) {
+	return p.ExtClient.OfflinePushUnacked(ctx, req)
+}